@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/history"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/schedule"
+	"github.com/samber/lo"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// runDaemon scans the spaces on a schedule, recording the results of each scan in the history file. A scan that fails
+// is reported, and the daemon waits for the next scheduled scan. The daemon runs until it is stopped with Ctrl-C.
+func runDaemon(octolintConfig *config.OctolintConfig) int {
+	scanSchedule, err := schedule.ParseSchedule(octolintConfig.Schedule)
+
+	if err != nil {
+		errorExit("The schedule argument is invalid.\nThe error was: " + err.Error())
+	}
+
+	if octolintConfig.HistoryMaxRuns <= 0 {
+		errorExit("The historyMaxRuns argument must be greater than 0")
+	}
+
+	settings := parseScanSettings(octolintConfig)
+
+	// A history file that can not be read is reported before the first scan, rather than being overwritten
+	if _, err := readHistory(octolintConfig.HistoryFile); err != nil {
+		errorExit("Failed to read the history " + octolintConfig.HistoryFile + ".\nThe error was: " + err.Error())
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	for {
+		next := scanSchedule.Next(time.Now())
+		fmt.Println("The next scan starts at " + next.Format(time.RFC1123))
+
+		select {
+		case <-ctx.Done():
+			return 0
+		case <-time.After(time.Until(next)):
+		}
+
+		outcome, err := scan(ctx, octolintConfig, settings, os.Stdout)
+
+		// A scan interrupted by the daemon stopping is incomplete, so it is not recorded
+		if ctx.Err() != nil {
+			return 0
+		}
+
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			continue
+		}
+
+		if err := recordHistory(octolintConfig, outcome); err != nil {
+			fmt.Fprintln(os.Stderr, "Failed to record the scan in the history "+octolintConfig.HistoryFile+".\nThe error was: "+err.Error())
+		}
+
+		if octolintConfig.Output != "" {
+			reporter, err := reporters.NewOctopusCheckReporter(octolintConfig, settings.minSeverity, outcome.metadata)
+
+			if err != nil {
+				errorExit("Failed to create the reporter.\nThe error was: " + err.Error())
+			}
+
+			report, err := reporter.GenerateSpaces(outcome.spaceResults)
+
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Failed to generate the report.\nThe error was: "+err.Error())
+			} else {
+				writeReport(octolintConfig, report)
+			}
+		}
+	}
+}
+
+// recordHistory adds the results of a scan to the history file. The results are recorded before the suppressions and
+// baseline are applied, so suppressing or baselining an issue does not record it as resolved.
+func recordHistory(octolintConfig *config.OctolintConfig, outcome scanOutcome) error {
+	scanHistory, err := readHistory(octolintConfig.HistoryFile)
+
+	if err != nil {
+		return err
+	}
+
+	run := scanHistory.Record(outcome.unfilteredResults, outcome.metadata.StartTime, outcome.metadata.EndTime, octolintConfig.HistoryMaxRuns)
+
+	if err := history.WriteHistory(scanHistory, octolintConfig.HistoryFile); err != nil {
+		return err
+	}
+
+	fmt.Println("Recorded run #" + fmt.Sprint(run.Id) + " in " + octolintConfig.HistoryFile + ". " + run.Summary.String())
+
+	return nil
+}
+
+// readHistory loads the history file, returning an empty history if the file does not exist yet.
+func readHistory(path string) (history.OctopusHistory, error) {
+	scanHistory, err := history.ReadHistory(path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return history.NewOctopusHistory(), nil
+	}
+
+	return scanHistory, err
+}
+
+// printTrend reports the issues found by the latest scans in the history file, and when each issue was first found
+// and resolved.
+func printTrend(octolintConfig *config.OctolintConfig) {
+	format := strings.ToLower(strings.TrimSpace(octolintConfig.Format))
+	if format != reporters.PlainFormat && format != reporters.HtmlFormat && format != reporters.JsonFormat {
+		errorExit("The " + trendCommand + " command supports the " + reporters.PlainFormat + ", " + reporters.HtmlFormat + ", and " + reporters.JsonFormat + " formats")
+	}
+
+	if octolintConfig.TrendRuns < 0 {
+		errorExit("The trendRuns argument must be 0 or greater")
+	}
+
+	scanHistory, err := history.ReadHistory(octolintConfig.HistoryFile)
+
+	if errors.Is(err, os.ErrNotExist) {
+		errorExit("The history " + octolintConfig.HistoryFile + " does not exist. It is created by the " + daemonCommand + " command.")
+	}
+
+	if err != nil {
+		errorExit("Failed to read the history " + octolintConfig.HistoryFile + ".\nThe error was: " + err.Error())
+	}
+
+	// The onlyTests argument limits the trend to some checks
+	checkIds := lo.Filter(lo.Map(strings.Split(octolintConfig.OnlyTests, ","), func(checkId string, _ int) string {
+		return strings.TrimSpace(checkId)
+	}), func(checkId string, _ int) bool {
+		return checkId != ""
+	})
+
+	trend := history.NewOctopusTrend(scanHistory, octolintConfig.TrendRuns, checkIds)
+
+	switch format {
+	case reporters.JsonFormat:
+		content, err := json.MarshalIndent(trend, "", "  ")
+
+		if err != nil {
+			errorExit("Failed to generate the report")
+		}
+
+		writeReport(octolintConfig, string(content))
+	case reporters.HtmlFormat:
+		content, err := trend.Html(Version)
+
+		if err != nil {
+			errorExit("Failed to generate the report.\nThe error was: " + err.Error())
+		}
+
+		writeReport(octolintConfig, content)
+	default:
+		writeReport(octolintConfig, trend.String())
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/diff"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
+	"io"
+	"strings"
+)
+
+// diffReports compares the issues in two JSON reports or snapshots, and prints the issues that are new, resolved, and
+// unchanged. It returns exitCodeIssuesFound if the later scan found new issues.
+func diffReports(octolintConfig *config.OctolintConfig, before string, after string) int {
+	if before == "" || after == "" {
+		errorExit("You must specify the two reports or snapshots to compare, for example: octolint " + diffCommand + " before.json after.json")
+	}
+
+	format := strings.ToLower(strings.TrimSpace(octolintConfig.Format))
+	if format != reporters.PlainFormat && format != reporters.JsonFormat {
+		errorExit("The " + diffCommand + " command supports the " + reporters.PlainFormat + " and " + reporters.JsonFormat + " formats")
+	}
+
+	minSeverity, err := checks.ParseSeverity(octolintConfig.MinSeverity)
+
+	if err != nil {
+		errorExit("The minSeverity argument is invalid.\nThe error was: " + err.Error())
+	}
+
+	reportDiff := diff.Compare(loadResults(octolintConfig, before), loadResults(octolintConfig, after), minSeverity)
+
+	if format == reporters.JsonFormat {
+		content, err := json.MarshalIndent(reportDiff, "", "  ")
+
+		if err != nil {
+			errorExit("Failed to generate the report")
+		}
+
+		writeReport(octolintConfig, string(content))
+	} else {
+		writeReport(octolintConfig, reportDiff.String())
+	}
+
+	if reportDiff.Regressed() {
+		return exitCodeIssuesFound
+	}
+
+	return 0
+}
+
+// loadResults returns the results saved in a JSON report, or runs the checks against a snapshot. Snapshots are scanned
+// with the same settings as a normal scan, including the suppressions and severity overrides.
+func loadResults(octolintConfig *config.OctolintConfig, path string) []checks.OctopusSpaceResults {
+	if !strings.HasSuffix(path, ".gz") {
+		report, err := reporters.ReadJsonReport(path)
+
+		if err == nil {
+			results, err := report.SpaceResults()
+
+			if err != nil {
+				errorExit("Failed to read the report " + path + ".\nThe error was: " + err.Error())
+			}
+
+			return results
+		}
+
+		if !errors.Is(err, reporters.ErrNotJsonReport) {
+			errorExit("Failed to read the report " + path + ".\nThe error was: " + err.Error())
+		}
+	}
+
+	// Scanning a snapshot must not send the results to the webhooks or overwrite the baseline
+	scanConfig := *octolintConfig
+	scanConfig.Snapshot = path
+	scanConfig.Webhooks = nil
+	scanConfig.WriteBaseline = ""
+
+	outcome := runScan(context.Background(), &scanConfig, parseScanSettings(&scanConfig), io.Discard)
+
+	if outcome.metadata.Interrupted {
+		errorExit("The scan of the snapshot " + path + " was interrupted")
+	}
+
+	return outcome.spaceResults
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/registry"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/samber/lo"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+)
+
+// uninitializedArguments can not be set in the config file, as they are used before it is read
+var uninitializedArguments = []string{"configFile", "configPath", "version"}
+
+// initConfigFile writes a config file documenting every key, optionally seeded with the keys set by a policy file. The
+// file is saved to the file set by the output argument, or to the config file octolint reads by default. Existing
+// files are never overwritten.
+func initConfigFile(octolintConfig *config.OctolintConfig, policyFile string) {
+	var policy []byte
+	if policyFile != "" {
+		content, err := os.ReadFile(policyFile)
+
+		if err != nil {
+			errorExit("Failed to read the policy " + policyFile + ".\nThe error was: " + err.Error())
+		}
+
+		policy = content
+	}
+
+	content, err := config.GenerateConfigFile(configKeys(), policyFile, policy)
+
+	if err != nil {
+		errorExit("Failed to generate the config file.\nThe error was: " + err.Error())
+	}
+
+	path := octolintConfig.Output
+	if path == "" {
+		path = filepath.Join(octolintConfig.ConfigPath, octolintConfig.ConfigFile+".yaml")
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		errorExit("The file " + path + " already exists. Set the output argument to save the config file somewhere else.")
+	} else if !errors.Is(err, os.ErrNotExist) {
+		errorExit("Failed to write the config file to " + path + ".\nThe error was: " + err.Error())
+	}
+
+	if err := os.WriteFile(path, []byte(content+"\n"), 0644); err != nil {
+		errorExit("Failed to write the config file to " + path + ".\nThe error was: " + err.Error())
+	}
+
+	fmt.Println("Saved the config file to " + path)
+}
+
+// configKeys documents the arguments that can be set in the config file. The checks affected by an argument are the
+// checks its usage refers to, like "for the OctoLintEnvironmentCount check".
+func configKeys() []config.ConfigKey {
+	keys := []config.ConfigKey{}
+
+	flag.CommandLine.VisitAll(func(f *flag.Flag) {
+		if lo.Contains(uninitializedArguments, f.Name) {
+			return
+		}
+
+		key := config.ConfigKey{Name: f.Name, Usage: f.Usage, Checks: []string{}, Default: []string{}}
+
+		// The value of the flag may have been set by the command line or the config file, so the default is parsed from
+		// its string representation. String slices default to an empty list.
+		if getter, ok := f.Value.(flag.Getter); ok {
+			switch getter.Get().(type) {
+			case bool:
+				key.Default, _ = strconv.ParseBool(f.DefValue)
+			case int:
+				key.Default, _ = strconv.Atoi(f.DefValue)
+			default:
+				key.Default = f.DefValue
+			}
+		}
+
+		for _, check := range registry.AllChecks() {
+			if regexp.MustCompile(`\bthe\s+` + regexp.QuoteMeta(check.Id) + `\s+check\b`).MatchString(f.Usage) {
+				key.Checks = append(key.Checks, check.Id)
+			}
+		}
+
+		keys = append(keys, key)
+	})
+
+	return keys
+}
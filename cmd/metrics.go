@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
+	"go.uber.org/zap"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// serveMetrics serves the results of the latest scan in the OpenMetrics format from the /metrics endpoint, and scans the
+// spaces again after each interval. The endpoint returns a 503 until the first scan completes, and a scan that fails
+// keeps the metrics of the previous scan. The server runs until it is stopped with Ctrl-C.
+func serveMetrics(octolintConfig *config.OctolintConfig, settings scanSettings) int {
+	if octolintConfig.MetricsInterval <= 0 {
+		errorExit("The metricsInterval argument must be greater than 0")
+	}
+
+	listener, err := net.Listen("tcp", octolintConfig.MetricsAddress)
+
+	if err != nil {
+		errorExit("Failed to listen on " + octolintConfig.MetricsAddress + ".\nThe error was: " + err.Error())
+	}
+
+	var mutex sync.RWMutex
+	metrics := ""
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		mutex.RLock()
+		defer mutex.RUnlock()
+
+		if metrics == "" {
+			http.Error(w, "The first scan has not completed", http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", reporters.OpenMetricsContentType)
+		if _, err := io.WriteString(w, metrics); err != nil {
+			zap.L().Debug("Failed to write the metrics: " + err.Error())
+		}
+	})
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	interval := time.Duration(octolintConfig.MetricsInterval) * time.Minute
+
+	go func() {
+		for {
+			outcome, err := scan(ctx, octolintConfig, settings, os.Stdout)
+
+			// A scan interrupted by the server shutting down is incomplete, so the previous metrics are kept
+			if ctx.Err() != nil {
+				return
+			}
+
+			var report string
+			if err == nil {
+				report, err = reporters.NewOctopusOpenMetricsCheckReporter(settings.minSeverity, outcome.metadata).GenerateSpaces(outcome.spaceResults)
+			}
+
+			// A scan that fails keeps serving the previous metrics, and the spaces are scanned again after the interval
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Failed to update the metrics. The next scan starts in "+interval.String()+
+					".\nThe error was: "+err.Error())
+			} else {
+				mutex.Lock()
+				metrics = report
+				mutex.Unlock()
+
+				fmt.Println("Updated the metrics at " + outcome.metadata.EndTime.Format(time.RFC1123) +
+					". The next scan starts in " + interval.String())
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			zap.L().Debug("Failed to shut down the metrics server: " + err.Error())
+		}
+	}()
+
+	fmt.Println("Serving the metrics at http://" + listener.Addr().String() + "/metrics")
+
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		errorExit("The metrics server failed.\nThe error was: " + err.Error())
+	}
+
+	return 0
+}
@@ -1,38 +1,86 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"flag"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/spaces"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/baseline"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/factory"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/naming"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/organization"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/performance"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/registry"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/security"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/defaults"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/executor"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/notifications"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
-	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/octoclient"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/suppressions"
 	"github.com/briandowns/spinner"
+	"github.com/samber/lo"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"io"
 	"net/http"
 	"net/url"
 	"os"
+	"os/signal"
+	"reflect"
 	"strings"
+	"syscall"
+	"text/tabwriter"
 	"time"
 )
 
 var Version = "development"
 
+const exportCommand = "export"
+const listChecksCommand = "list-checks"
+const explainCommand = "explain"
+const diffCommand = "diff"
+const serveCommand = "serve"
+const daemonCommand = "daemon"
+const trendCommand = "trend"
+const initCommand = "init"
+
+// exitCodeIssuesFound is returned when the failOn argument is set and issues at or above its severity are found
+const exitCodeIssuesFound = 2
+
+// exitCodeChecksFailed is returned when any checks failed to run or the scan was interrupted
+const exitCodeChecksFailed = 3
+
 func main() {
-	octolintConfig, err := parseArgs()
+	command, args := splitCommand(os.Args[1:])
+
+	// The explain command takes the check ID before any arguments, like "octolint explain OctoLintEmptyProject"
+	checkId := ""
+	if command == explainCommand {
+		checkId, args = splitCommand(args)
+	}
+
+	// The diff command takes the two files before any arguments, like "octolint diff before.json after.json"
+	before, after := "", ""
+	if command == diffCommand {
+		before, args = splitCommand(args)
+		after, args = splitCommand(args)
+	}
+
+	// The init command takes an optional policy file before any arguments, like "octolint init policies/octopus_samples.yaml"
+	policyFile := ""
+	if command == initCommand {
+		policyFile, args = splitCommand(args)
+	}
+
+	octolintConfig, err := parseArgs(args)
 
 	if err != nil {
 		errorExit(err.Error())
@@ -41,90 +89,784 @@ func main() {
 
 	zap.ReplaceGlobals(createLogger(octolintConfig.Verbose))
 
+	if octolintConfig.Version {
+		fmt.Println("Version: " + Version)
+		os.Exit(0)
+	}
+
+	switch command {
+	case "":
+		os.Exit(runChecks(octolintConfig))
+	case exportCommand:
+		exportSnapshot(octolintConfig)
+	case listChecksCommand:
+		listChecks(octolintConfig)
+	case explainCommand:
+		explainCheck(checkId)
+	case diffCommand:
+		os.Exit(diffReports(octolintConfig, before, after))
+	case serveCommand:
+		os.Exit(serveApi(octolintConfig))
+	case daemonCommand:
+		os.Exit(runDaemon(octolintConfig))
+	case trendCommand:
+		printTrend(octolintConfig)
+	case initCommand:
+		initConfigFile(octolintConfig, policyFile)
+	default:
+		errorExit("Unknown command \"" + command + "\". The supported commands are: " +
+			strings.Join([]string{exportCommand, listChecksCommand, explainCommand, diffCommand, serveCommand, daemonCommand, trendCommand, initCommand}, ", "))
+	}
+}
+
+// startSpinner displays the spinner while a long running command is executing. The returned function stops the spinner.
+func startSpinner(octolintConfig *config.OctolintConfig) func() {
+	if !octolintConfig.Spinner || octolintConfig.Verbose {
+		return func() {}
+	}
+
 	s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
+	s.Start()
+	return s.Stop
+}
 
-	if octolintConfig.Spinner && !octolintConfig.Verbose {
-		s.Start()
+// splitCommand returns the optional command that precedes the arguments, like "export" in "octolint export -url ...".
+// Running octolint without a command runs the checks.
+func splitCommand(args []string) (string, []string) {
+	if len(args) != 0 && !strings.HasPrefix(args[0], "-") {
+		return args[0], args[1:]
 	}
 
-	defer func() {
-		if octolintConfig.Spinner && !octolintConfig.Verbose {
-			s.Stop()
+	return "", args
+}
+
+// scanSettings are the arguments that control how the results of a scan are filtered and reported, parsed once before
+// any spaces are scanned.
+type scanSettings struct {
+	severityOverrides map[string]int
+	minSeverity       int
+	failOn            int
+	scanBaseline      *baseline.OctopusBaseline
+	scoreWeights      checks.OctopusScoreWeights
+}
+
+// scanOutcome is the result of scanning all the spaces, after the suppressions, baseline and severity overrides have
+// been applied.
+type scanOutcome struct {
+	spaceResults []checks.OctopusSpaceResults
+	// unfilteredResults are the results with the severity overrides applied, but not the suppressions or baseline, so
+	// the issues that are suppressed or baselined are not mistaken for issues that were resolved
+	unfilteredResults []checks.OctopusSpaceResults
+	metadata          reporters.OctopusReportMetadata
+	suppressionReport suppressions.OctopusSuppressionReport
+	baselineReport    baseline.OctopusBaselineReport
+}
+
+// parseScanSettings validates the arguments that control a scan, exiting if any are invalid.
+func parseScanSettings(octolintConfig *config.OctolintConfig) scanSettings {
+	settings, err := newScanSettings(octolintConfig)
+
+	if err != nil {
+		errorExit(err.Error())
+	}
+
+	return settings
+}
+
+// newScanSettings validates the arguments that control a scan, returning an error describing the first invalid argument.
+func newScanSettings(octolintConfig *config.OctolintConfig) (scanSettings, error) {
+	severityOverrides, err := registry.ParseSeverityOverrides(octolintConfig.Severities)
+
+	if err != nil {
+		return scanSettings{}, errors.New("The severities argument is invalid.\nThe error was: " + err.Error())
+	}
+
+	minSeverity, err := checks.ParseSeverity(octolintConfig.MinSeverity)
+
+	if err != nil {
+		return scanSettings{}, errors.New("The minSeverity argument is invalid.\nThe error was: " + err.Error())
+	}
+
+	failOn, err := parseFailOn(octolintConfig.FailOn)
+
+	if err != nil {
+		return scanSettings{}, errors.New("The failOn argument is invalid.\nThe error was: " + err.Error())
+	}
+
+	if _, err := reporters.NewOctopusCheckReporter(octolintConfig, minSeverity, reporters.OctopusReportMetadata{}); err != nil {
+		return scanSettings{}, errors.New("The format argument is invalid.\nThe error was: " + err.Error())
+	}
+
+	if _, err := suppressions.NewOctopusSuppressionFilter(octolintConfig.Suppressions, time.Now()); err != nil {
+		return scanSettings{}, errors.New("The suppressions in the config file are invalid.\nThe error was: " + err.Error())
+	}
+
+	if err := notifications.ValidateWebhooks(octolintConfig.Webhooks); err != nil {
+		return scanSettings{}, errors.New("The webhooks in the config file are invalid.\nThe error was: " + err.Error())
+	}
+
+	scoreWeights, err := registry.ParseScoreWeights(octolintConfig.Score)
+
+	if err != nil {
+		return scanSettings{}, errors.New("The score weights in the config file are invalid.\nThe error was: " + err.Error())
+	}
+
+	settings := scanSettings{severityOverrides: severityOverrides, minSeverity: minSeverity, failOn: failOn, scoreWeights: scoreWeights}
+
+	if octolintConfig.Baseline != "" {
+		existingBaseline, err := baseline.ReadBaseline(octolintConfig.Baseline)
+
+		if err != nil {
+			return scanSettings{}, errors.New("Failed to read the baseline " + octolintConfig.Baseline + ".\nThe error was: " + err.Error())
 		}
+
+		settings.scanBaseline = &existingBaseline
+	}
+
+	// Validate the check settings before connecting to the server
+	if _, err := factory.NewOctopusCheckFactory(nil, "", "").BuildAllChecks(octolintConfig); err != nil {
+		return scanSettings{}, errors.New("Failed to create the checks.\nThe error was: " + err.Error())
+	}
+
+	return settings, nil
+}
+
+// runChecks scans the space and prints the report, returning the exit code of the process.
+func runChecks(octolintConfig *config.OctolintConfig) int {
+	settings := parseScanSettings(octolintConfig)
+
+	if octolintConfig.MetricsAddress != "" {
+		return serveMetrics(octolintConfig, settings)
+	}
+
+	messages := messageWriter(octolintConfig)
+
+	defer startSpinner(octolintConfig)()
+
+	// Time the execution
+	startTime := time.Now()
+	defer func() {
+		fmt.Fprintln(messages, "Report took "+fmt.Sprint(time.Since(startTime).Milliseconds()/1000)+" seconds")
 	}()
 
-	if octolintConfig.Version {
-		fmt.Println("Version: " + Version)
-		os.Exit(0)
+	// Cancel the checks on Ctrl-C, while still reporting on the checks that completed
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	outcome := runScan(ctx, octolintConfig, settings, messages)
+
+	reporter, err := reporters.NewOctopusCheckReporter(octolintConfig, settings.minSeverity, outcome.metadata)
+
+	if err != nil {
+		errorExit("Failed to create the reporter.\nThe error was: " + err.Error())
 	}
 
-	if octolintConfig.Url == "" {
-		errorExit("You must specify the URL with the -url argument")
+	report, err := reporter.GenerateSpaces(outcome.spaceResults)
+
+	if err != nil {
+		errorExit("Failed to generate the report")
 	}
 
-	if octolintConfig.ApiKey == "" {
-		errorExit("You must specify the API key with the -apiKey argument")
+	writeReport(octolintConfig, report)
+
+	summary := checks.NewOctopusCheckSummary(checks.AllResults(outcome.spaceResults))
+	if len(outcome.spaceResults) > 1 {
+		fmt.Fprintln(messages, "Summary for all "+fmt.Sprint(len(outcome.spaceResults))+" spaces: "+summary.Counts())
+	} else {
+		fmt.Fprintln(messages, summary.String())
 	}
+	printSuppressionReport(messages, outcome.suppressionReport)
+	printBaselineReport(messages, outcome.baselineReport)
 
-	if octolintConfig.Space == "" {
-		errorExit("You must specify the space key with the -space argument")
+	if outcome.metadata.Interrupted {
+		fmt.Fprintln(messages, "The scan was interrupted. This report only includes the checks that completed.")
+	}
+
+	// An incomplete scan can not prove there are no issues, so it takes precedence over the issues that were found, and
+	// is reported whether or not the failOn argument is set
+	if summary.Failed != 0 || outcome.metadata.Interrupted {
+		return exitCodeChecksFailed
 	}
 
-	if !strings.HasPrefix(octolintConfig.Space, "Spaces-") {
-		spaceId, err := lookupSpaceAsName(octolintConfig.Url, octolintConfig.Space, octolintConfig.ApiKey)
+	if settings.failOn != checks.Ok && summary.IssuesAtOrAbove(settings.failOn) != 0 {
+		return exitCodeIssuesFound
+	}
+
+	return 0
+}
+
+// runScan scans the spaces and applies the suppressions, baseline and severity overrides to the results, exiting if the
+// scan fails.
+func runScan(ctx context.Context, octolintConfig *config.OctolintConfig, settings scanSettings, messages io.Writer) scanOutcome {
+	outcome, err := scan(ctx, octolintConfig, settings, messages)
+
+	if err != nil {
+		errorExit(err.Error())
+	}
+
+	return outcome
+}
+
+// scan scans the spaces and applies the suppressions, baseline and severity overrides to the results. A new snapshot is
+// created for each scan, so scans that are repeated see the current state of the spaces.
+func scan(ctx context.Context, octolintConfig *config.OctolintConfig, settings scanSettings, messages io.Writer) (scanOutcome, error) {
+	// Suppressions are matched against the time of the scan, so they expire between scans that are repeated
+	suppressionFilter, err := suppressions.NewOctopusSuppressionFilter(octolintConfig.Suppressions, time.Now())
+
+	if err != nil {
+		return scanOutcome{}, errors.New("The suppressions in the config file are invalid.\nThe error was: " + err.Error())
+	}
+
+	targets, err := createSpaceTargets(ctx, octolintConfig)
+
+	if err != nil {
+		return scanOutcome{}, err
+	}
+
+	startTime := time.Now()
+	spaceResults, interrupted, err := scanSpaces(ctx, octolintConfig, targets, messages)
+	endTime := time.Now()
+
+	if err != nil {
+		return scanOutcome{}, err
+	}
+
+	applySeverityOverrides := func(results []checks.OctopusCheckResult) []checks.OctopusCheckResult {
+		return registry.ApplySeverityOverrides(results, settings.severityOverrides)
+	}
+
+	// Suppressions and baselines are applied to the results of all the spaces at once, so they are reported for the
+	// whole scan rather than for each space
+	outcome := scanOutcome{unfilteredResults: applyToAllSpaces(spaceResults, applySeverityOverrides)}
+	spaceResults = applyToAllSpaces(spaceResults, func(results []checks.OctopusCheckResult) []checks.OctopusCheckResult {
+		results, outcome.suppressionReport = suppressionFilter.Apply(results)
+		return results
+	})
+
+	if octolintConfig.WriteBaseline != "" && !interrupted {
+		newBaseline := baseline.NewOctopusBaseline(spaceResults, time.Now())
+
+		if err := baseline.WriteBaseline(newBaseline, octolintConfig.WriteBaseline); err != nil {
+			return scanOutcome{}, errors.New("Failed to write the baseline to " + octolintConfig.WriteBaseline + ".\nThe error was: " + err.Error())
+		}
+
+		fmt.Fprintln(messages, "Recorded "+fmt.Sprint(len(newBaseline.Findings))+" issue(s) in the baseline "+octolintConfig.WriteBaseline)
+	}
+
+	if settings.scanBaseline != nil {
+		spaceResults, outcome.baselineReport = settings.scanBaseline.Apply(spaceResults)
+	}
+
+	outcome.spaceResults = applyToAllSpaces(spaceResults, applySeverityOverrides)
+
+	outcome.metadata = reporters.OctopusReportMetadata{
+		OctolintVersion:   Version,
+		ServerUrl:         octolintConfig.Url,
+		StartTime:         startTime,
+		EndTime:           endTime,
+		Interrupted:       interrupted,
+		ScoreWeights:      settings.scoreWeights,
+		SeverityOverrides: settings.severityOverrides,
+	}
+
+	// An interrupted scan is incomplete, so it is not sent to the webhooks
+	if !interrupted {
+		notifyWebhooks(ctx, octolintConfig, settings, outcome, messages)
+	}
+
+	return outcome, nil
+}
+
+// notifyWebhooks sends the results to the webhooks defined in the config file. A webhook that can not be reached does not
+// fail the scan.
+func notifyWebhooks(ctx context.Context, octolintConfig *config.OctolintConfig, settings scanSettings, outcome scanOutcome, messages io.Writer) {
+	notifier := notifications.NewOctopusWebhookNotifier(defaults.WebhookAttempts, octolintConfig.WebhookState)
+
+	for _, result := range notifier.Notify(ctx, octolintConfig.Webhooks, outcome.spaceResults, outcome.metadata, settings.minSeverity) {
+		switch {
+		case result.Sent:
+			fmt.Fprintln(messages, "Sent the results to the webhook "+result.Host)
+		case result.Skipped != "":
+			fmt.Fprintln(messages, "Did not send the results to the webhook "+result.Host+" because "+result.Skipped)
+		default:
+			fmt.Fprintln(os.Stderr, "Failed to send the results to the webhook "+result.Host+".\nThe error was: "+result.Err.Error())
+		}
+	}
+}
+
+// resourcesScanned returns the number of resources each check read from the space, keyed by the check ID.
+func resourcesScanned(checkCollection []checks.OctopusCheck) map[string]int {
+	scanned := map[string]int{}
+	for _, check := range checkCollection {
+		if counted, ok := check.(checks.OctopusCountedCheck); ok {
+			scanned[check.Id()] = counted.ResourcesScanned()
+		}
+	}
+	return scanned
+}
+
+// scanSpaces runs the checks against each space in turn. The spaces that were not scanned before the scan was
+// interrupted are left out of the results.
+func scanSpaces(ctx context.Context, octolintConfig *config.OctolintConfig, targets []spaceTarget, messages io.Writer) ([]checks.OctopusSpaceResults, bool, error) {
+	executor := executor.NewOctopusCheckExecutor(time.Duration(octolintConfig.CheckTimeout)*time.Second, uint(octolintConfig.CheckRetries))
+	spaceResults := []checks.OctopusSpaceResults{}
+
+	// The instance scoped checks would report the same issues in every space, so when many spaces are scanned they are
+	// run once, against the first space, and reported under their own instance level entry
+	splitInstanceChecks := len(targets) > 1
+
+	if splitInstanceChecks {
+		instanceTarget := spaceTarget{name: instanceSpaceName, snapshot: targets[0].snapshot}
+		results, interrupted, err := scanSpace(ctx, octolintConfig, executor, instanceTarget, func(check checks.OctopusCheck) bool {
+			return registry.IsInstanceScoped(check.Id())
+		}, messages)
 
 		if err != nil {
-			errorExit("Failed to create the Octopus client_wrapper. Check that the url, api key, and space are correct.\nThe error was: " + err.Error())
+			return nil, false, err
 		}
 
-		octolintConfig.Space = spaceId
+		spaceResults = append(spaceResults, results)
+
+		if interrupted {
+			return spaceResults, true, nil
+		}
 	}
 
-	client, err := octoclient.CreateClient(octolintConfig.Url, octolintConfig.Space, octolintConfig.ApiKey)
+	for _, target := range targets {
+		results, interrupted, err := scanSpace(ctx, octolintConfig, executor, target, func(check checks.OctopusCheck) bool {
+			return !splitInstanceChecks || !registry.IsInstanceScoped(check.Id())
+		}, messages)
 
-	if err != nil {
-		errorExit("Failed to create the Octopus client_wrapper. Check that the url, api key, and space are correct.\nThe error was: " + err.Error())
+		if err != nil {
+			return nil, false, err
+		}
+
+		spaceResults = append(spaceResults, results)
+
+		if interrupted {
+			return spaceResults, true, nil
+		}
 	}
 
-	factory := factory.NewOctopusCheckFactory(client, octolintConfig.Url, octolintConfig.Space)
+	return spaceResults, false, nil
+}
+
+// scanSpace runs the checks accepted by the include function against the target, returning true if the scan was
+// interrupted.
+func scanSpace(ctx context.Context, octolintConfig *config.OctolintConfig, executor executor.OctopusCheckExecutor, target spaceTarget, include func(check checks.OctopusCheck) bool, messages io.Writer) (checks.OctopusSpaceResults, bool, error) {
+	factory := factory.NewOctopusCheckFactory(target.snapshot, octolintConfig.Url, target.id)
 	checkCollection, err := factory.BuildAllChecks(octolintConfig)
 
 	if err != nil {
-		errorExit("Failed to create the checks")
+		return checks.OctopusSpaceResults{}, false, errors.New("Failed to create the checks.\nThe error was: " + err.Error())
 	}
 
-	// Time the execution
-	startTime := time.Now().UnixMilli()
-	defer func() {
-		endTime := time.Now().UnixMilli()
-		fmt.Println("Report took " + fmt.Sprint((endTime-startTime)/1000) + " seconds")
-	}()
+	checkCollection = lo.Filter(checkCollection, func(item checks.OctopusCheck, index int) bool {
+		return include(item)
+	})
 
-	executor := executor.NewOctopusCheckExecutor()
-	results, err := executor.ExecuteChecks(checkCollection, func(check checks.OctopusCheck, err error) error {
-		fmt.Fprintf(os.Stderr, "Failed to execute check "+check.Id())
+	results, durations, err := executor.ExecuteTimedChecks(ctx, checkCollection, func(check checks.OctopusCheck, err error) error {
+		fmt.Fprintln(os.Stderr, "Failed to execute check "+check.Id()+" in "+target.description())
 		if octolintConfig.VerboseErrors {
-			fmt.Println("##octopus[stdout-verbose]")
-			fmt.Println(err.Error())
-			fmt.Println("##octopus[stdout-default]")
+			fmt.Fprintln(messages, "##octopus[stdout-verbose]")
+			fmt.Fprintln(messages, err.Error())
+			fmt.Fprintln(messages, "##octopus[stdout-default]")
 		} else {
 			fmt.Fprintf(os.Stderr, err.Error()+"\n")
 		}
 		return nil
 	})
 
+	interrupted := errors.Is(err, context.Canceled)
+
+	if err != nil && !interrupted {
+		return checks.OctopusSpaceResults{}, false, errors.New("Failed to run the checks")
+	}
+
+	// The instance level entry has no projects to link to
+	repositories := map[string]checks.OctopusProjectRepository{}
+	if target.id != "" {
+		repositories = projectRepositories(ctx, target.snapshot)
+	}
+	urlBuilder := checks.NewOctopusUrlBuilder(octolintConfig.Url, target.id).WithProjectRepositories(repositories)
+
+	return checks.OctopusSpaceResults{
+		SpaceId:             target.id,
+		SpaceName:           target.name,
+		Results:             urlBuilder.WithFindingUrls(results),
+		Durations:           durations,
+		ResourcesScanned:    resourcesScanned(checkCollection),
+		ProjectRepositories: repositories,
+	}, interrupted, nil
+}
+
+// projectRepositories returns the repositories of the version controlled projects in the space. The repositories only
+// add detail to the report, so they are left out if the projects can not be read.
+func projectRepositories(ctx context.Context, spaceSnapshot snapshot.OctopusSpaceSnapshot) map[string]checks.OctopusProjectRepository {
+	repositories := map[string]checks.OctopusProjectRepository{}
+
+	allProjects, err := spaceSnapshot.GetProjects(ctx, 0)
+
+	if err != nil {
+		zap.L().Debug("Failed to read the projects to find their repositories: " + err.Error())
+		return repositories
+	}
+
+	for _, project := range allProjects {
+		gitSettings, ok := project.PersistenceSettings.(projects.GitPersistenceSettings)
+
+		if !ok || gitSettings.URL() == nil {
+			continue
+		}
+
+		repositories[project.ID] = checks.OctopusProjectRepository{
+			Url:           gitSettings.URL().String(),
+			BasePath:      gitSettings.BasePath(),
+			DefaultBranch: gitSettings.DefaultBranch(),
+		}
+	}
+
+	return repositories
+}
+
+// applyToAllSpaces passes the results of every space to the apply function at once, and then splits the returned
+// results back into their spaces. The apply function must return one result for each result it is passed.
+func applyToAllSpaces(spaceResults []checks.OctopusSpaceResults, apply func(results []checks.OctopusCheckResult) []checks.OctopusCheckResult) []checks.OctopusSpaceResults {
+	applied := apply(checks.AllResults(spaceResults))
+
+	splitResults := []checks.OctopusSpaceResults{}
+	for _, space := range spaceResults {
+		space.Results = applied[:len(space.Results)]
+		applied = applied[len(space.Results):]
+		splitResults = append(splitResults, space)
+	}
+
+	return splitResults
+}
+
+// messageWriter returns where the summary and other messages are printed. A machine readable report printed to std out
+// must not be mixed with other messages, so the messages are printed to std err instead.
+func messageWriter(octolintConfig *config.OctolintConfig) io.Writer {
+	textFormat := lo.ContainsBy(reporters.TextFormats, func(item string) bool {
+		return strings.EqualFold(item, octolintConfig.Format)
+	})
+
+	if octolintConfig.Output == "" && !textFormat {
+		return os.Stderr
+	}
+
+	return os.Stdout
+}
+
+// writeReport prints the report to std out, or saves it to the file set by the output argument. The file is written to a
+// temporary file that is then renamed, so tools that watch the file, like the node_exporter textfile collector, never
+// read a partially written report.
+func writeReport(octolintConfig *config.OctolintConfig, report string) {
+	if octolintConfig.Output == "" {
+		fmt.Println(report)
+		return
+	}
+
+	temporaryFile := octolintConfig.Output + ".tmp"
+
+	if err := os.WriteFile(temporaryFile, []byte(report+"\n"), 0644); err != nil {
+		errorExit("Failed to write the report to " + octolintConfig.Output + ".\nThe error was: " + err.Error())
+	}
+
+	if err := os.Rename(temporaryFile, octolintConfig.Output); err != nil {
+		errorExit("Failed to write the report to " + octolintConfig.Output + ".\nThe error was: " + err.Error())
+	}
+
+	fmt.Println("Saved the report to " + octolintConfig.Output)
+}
+
+// printSuppressionReport lists the suppressions that no longer apply, so they can be reviewed or removed.
+func printSuppressionReport(messages io.Writer, report suppressions.OctopusSuppressionReport) {
+	if report.Suppressed != 0 {
+		fmt.Fprintln(messages, "Suppressed "+fmt.Sprint(report.Suppressed)+" issue(s)")
+	}
+
+	for _, s := range report.Expired {
+		fmt.Fprintln(messages, "The suppression for "+suppressions.DescribeSuppression(s)+" expired on "+s.Expires+
+			" and no longer applies. The justification was: "+s.Justification)
+	}
+
+	for _, s := range report.Unmatched {
+		fmt.Fprintln(messages, "The suppression for "+suppressions.DescribeSuppression(s)+" did not match any issues and can be removed")
+	}
+}
+
+// printBaselineReport lists the number of issues hidden by the baseline, and the number that have since been fixed.
+func printBaselineReport(messages io.Writer, report baseline.OctopusBaselineReport) {
+	if report.Baselined != 0 {
+		fmt.Fprintln(messages, fmt.Sprint(report.Baselined)+" issue(s) recorded in the baseline are not shown")
+	}
+
+	if report.Resolved != 0 {
+		fmt.Fprintln(messages, fmt.Sprint(report.Resolved)+" issue(s) recorded in the baseline were not found and may have been fixed")
+	}
+}
+
+// parseFailOn returns the lowest severity that fails the scan, or checks.Ok if the scan never fails due to issues.
+func parseFailOn(failOn string) (int, error) {
+	if failOn == "" || strings.EqualFold(failOn, "none") {
+		return checks.Ok, nil
+	}
+
+	severity, err := checks.ParseSeverity(failOn)
+
+	if err != nil || (severity != checks.Error && severity != checks.Warning && severity != checks.Info) {
+		return 0, errors.New("the failOn argument must be none, error, warning, or info")
+	}
+
+	return severity, nil
+}
+
+// exportSnapshot saves everything the checks read from the space to a bundle that can be scanned later with the
+// -snapshot argument.
+func exportSnapshot(octolintConfig *config.OctolintConfig) {
+	defer startSpinner(octolintConfig)()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client := createClient(ctx, octolintConfig)
+
+	source := snapshot.NewOctopusClientSpaceSnapshot(client)
+	bundle, err := snapshot.Export(ctx, source, octolintConfig.Url, Version, octolintConfig.MaxDeploymentTasks)
+
 	if err != nil {
-		errorExit("Failed to run the checks")
+		errorExit("Failed to export the space.\nThe error was: " + err.Error())
+	}
+
+	if err := snapshot.WriteBundle(bundle, octolintConfig.ExportFile); err != nil {
+		errorExit("Failed to write the snapshot to " + octolintConfig.ExportFile + ".\nThe error was: " + err.Error())
+	}
+
+	for key, message := range bundle.Errors {
+		fmt.Fprintln(os.Stderr, "Failed to export "+key+": "+message)
+	}
+
+	fmt.Println("Exported space " + bundle.SpaceId + " to " + octolintConfig.ExportFile)
+}
+
+// listChecks prints the ID, category, and default severity of each check, optionally limited to a single category.
+func listChecks(octolintConfig *config.OctolintConfig) {
+	allChecks := registry.AllChecks()
+
+	if octolintConfig.Category != "" {
+		allChecks = registry.ChecksInCategory(octolintConfig.Category)
+
+		if len(allChecks) == 0 {
+			errorExit("Unknown category \"" + octolintConfig.Category + "\". The supported categories are: " +
+				strings.Join(registry.Categories(), ", "))
+		}
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ID\tCATEGORY\tSEVERITY\tDESCRIPTION")
+	for _, check := range allChecks {
+		fmt.Fprintln(writer, check.Id+"\t"+check.Category+"\t"+checks.SeverityName(check.DefaultSeverity)+"\t"+check.Description)
+	}
+	writer.Flush()
+}
+
+// explainCheck prints the full documentation of a single check.
+func explainCheck(checkId string) {
+	if checkId == "" {
+		errorExit("You must specify the check to explain, for example: octolint " + explainCommand + " " + organization.OctoLintEmptyProject)
+	}
+
+	check, found := registry.GetCheck(checkId)
+
+	if !found {
+		errorExit("Unknown check \"" + checkId + "\". Run \"octolint " + listChecksCommand + "\" to see the available checks.")
 	}
 
-	reporter := reporters.NewOctopusPlainCheckReporter(checks.Warning)
-	report, err := reporter.Generate(results)
+	configKeys := "None"
+	if len(check.ConfigKeys) != 0 {
+		configKeys = strings.Join(check.ConfigKeys, ", ")
+	}
+
+	fmt.Println(check.Id)
+	fmt.Println()
+	fmt.Println(check.Description)
+	fmt.Println()
+	fmt.Println("Category: " + check.Category)
+	fmt.Println("Default severity: " + checks.SeverityName(check.DefaultSeverity))
+	fmt.Println("Settings: " + configKeys)
+	fmt.Println()
+	fmt.Println("Why it matters:")
+	fmt.Println(check.Rationale)
+	fmt.Println()
+	fmt.Println("How to fix it:")
+	fmt.Println(check.Remediation)
+	fmt.Println()
+	fmt.Println("Documentation: " + check.DocumentationUrl)
+}
+
+// instanceSpaceName is the name of the entry holding the results of the instance scoped checks when many spaces are
+// scanned.
+const instanceSpaceName = "Instance"
+
+// spaceTarget is a space to be scanned, along with the snapshot the checks read from. The target of the instance scoped
+// checks has no ID.
+type spaceTarget struct {
+	id       string
+	name     string
+	snapshot snapshot.OctopusSpaceSnapshot
+}
+
+func (o spaceTarget) description() string {
+	if o.id == "" {
+		return "the instance"
+	}
+
+	return "space " + o.id
+}
+
+// createSpaceTargets returns the spaces the checks are run against. This is either the space in a bundle saved by the
+// export command, or the spaces matched by the space argument on the Octopus server.
+func createSpaceTargets(ctx context.Context, octolintConfig *config.OctolintConfig) ([]spaceTarget, error) {
+	if octolintConfig.Snapshot != "" {
+		bundleSnapshot, err := createBundleSnapshot(octolintConfig)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return []spaceTarget{{id: octolintConfig.Space, name: octolintConfig.Space, snapshot: bundleSnapshot}}, nil
+	}
+
+	// All the spaces share the one HTTP client, and therefore its connections. The requests are sent with the context
+	// of the scan, so any that are in flight when the scan is cancelled are stopped.
+	httpClient := &http.Client{Transport: client_wrapper.NewContextTransport(ctx, http.DefaultTransport)}
+
+	matchedSpaces, err := resolveSpaces(octolintConfig, httpClient)
 
 	if err != nil {
-		errorExit("Failed to generate the report")
+		return nil, err
+	}
+
+	// The users and their API keys belong to the instance, so they are loaded once and shared by every space
+	instanceResources := snapshot.NewOctopusInstanceResources()
+
+	targets := []spaceTarget{}
+	for _, space := range matchedSpaces {
+		spaceClient, err := createSpaceClient(octolintConfig, httpClient, space.ID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		targets = append(targets, spaceTarget{
+			id:       space.ID,
+			name:     space.Name,
+			snapshot: snapshot.NewOctopusClientSpaceSnapshot(spaceClient).WithInstanceResources(instanceResources),
+		})
+	}
+
+	return targets, nil
+}
+
+func createBundleSnapshot(octolintConfig *config.OctolintConfig) (snapshot.OctopusSpaceSnapshot, error) {
+	bundle, err := snapshot.ReadBundle(octolintConfig.Snapshot)
+
+	if err != nil {
+		return nil, errors.New("Failed to read the snapshot " + octolintConfig.Snapshot + ".\nThe error was: " + err.Error())
+	}
+
+	// The URL is only used to build links, so it can be overridden if the server has moved since the export
+	if octolintConfig.Url == "" {
+		octolintConfig.Url = bundle.ServerUrl
+	}
+	octolintConfig.Space = bundle.SpaceId
+
+	bundleSnapshot, err := snapshot.NewOctopusBundleSpaceSnapshot(bundle)
+
+	if err != nil {
+		return nil, errors.New("Failed to read the snapshot " + octolintConfig.Snapshot + ".\nThe error was: " + err.Error())
+	}
+
+	return bundleSnapshot, nil
+}
+
+// createClient returns a client for the single space matched by the space argument, exiting if the space can not be
+// found. The client's requests are stopped when the context is done.
+func createClient(ctx context.Context, octolintConfig *config.OctolintConfig) *client.Client {
+	httpClient := &http.Client{Transport: client_wrapper.NewContextTransport(ctx, http.DefaultTransport)}
+	matchedSpaces, err := resolveSpaces(octolintConfig, httpClient)
+
+	if err != nil {
+		errorExit(err.Error())
+	}
+
+	if len(matchedSpaces) != 1 {
+		errorExit("The space argument must match a single space, but it matched " + fmt.Sprint(len(matchedSpaces)) + " spaces")
+	}
+
+	octolintConfig.Space = matchedSpaces[0].ID
+
+	spaceClient, err := createSpaceClient(octolintConfig, httpClient, octolintConfig.Space)
+
+	if err != nil {
+		errorExit(err.Error())
+	}
+
+	return spaceClient
+}
+
+// resolveSpaces returns the spaces matched by the space argument, which can be a comma separated list of space names,
+// space IDs, or glob patterns, or "all" to match every space.
+func resolveSpaces(octolintConfig *config.OctolintConfig, httpClient *http.Client) ([]*spaces.Space, error) {
+	if octolintConfig.Url == "" {
+		return nil, errors.New("You must specify the URL with the -url argument")
+	}
+
+	if octolintConfig.ApiKey == "" {
+		return nil, errors.New("You must specify the API key with the -apiKey argument")
+	}
+
+	if octolintConfig.Space == "" {
+		return nil, errors.New("You must specify the space key with the -space argument")
+	}
+
+	// A single space ID does not need to be looked up
+	if client_wrapper.IsSingleSpaceId(octolintConfig.Space) {
+		space := spaces.NewSpace(strings.TrimSpace(octolintConfig.Space))
+		space.ID = space.Name
+		return []*spaces.Space{space}, nil
+	}
+
+	allSpaces, err := client_wrapper.GetSpaces(httpClient, octolintConfig.Url, octolintConfig.ApiKey)
+
+	if err != nil {
+		return nil, errors.New("Failed to list the spaces. Check that the url and api key are correct.\nThe error was: " + err.Error())
+	}
+
+	matchedSpaces, err := client_wrapper.FilterSpaces(allSpaces, octolintConfig.Space)
+
+	if err != nil {
+		return nil, errors.New("Failed to find the spaces matching " + octolintConfig.Space + ".\nThe error was: " + err.Error())
+	}
+
+	return matchedSpaces, nil
+}
+
+func createSpaceClient(octolintConfig *config.OctolintConfig, httpClient *http.Client, spaceId string) (*client.Client, error) {
+	apiUrl, err := url.Parse(octolintConfig.Url)
+
+	if err != nil {
+		return nil, errors.New("Failed to create the Octopus client_wrapper. Check that the url, api key, and space are correct.\nThe error was: " + err.Error())
+	}
+
+	spaceClient, err := client.NewClient(httpClient, apiUrl, octolintConfig.ApiKey, spaceId)
+
+	if err != nil {
+		return nil, errors.New("Failed to create the Octopus client_wrapper. Check that the url, api key, and space are correct.\nThe error was: " + err.Error())
 	}
 
-	fmt.Println(report)
+	return spaceClient, nil
 }
 
 func createLogger(verbose bool) *zap.Logger {
@@ -165,20 +907,48 @@ func errorExit(message string) {
 	os.Exit(1)
 }
 
-func parseArgs() (*config.OctolintConfig, error) {
+func parseArgs(args []string) (*config.OctolintConfig, error) {
 	config := config.OctolintConfig{}
 
 	flag.StringVar(&config.Url, "url", "", "The Octopus URL e.g. https://myinstance.octopus.app")
-	flag.StringVar(&config.Space, "space", "", "The Octopus space name or ID")
+	flag.StringVar(&config.Space, "space", "", "A comma separated list of Octopus space names or IDs, glob patterns like \"Team *\", or \"all\" to scan every space. The "+exportCommand+" command only accepts a single space")
 	flag.StringVar(&config.ApiKey, "apiKey", "", "The Octopus api key")
 	flag.StringVar(&config.SkipTests, "skipTests", "", "A comma separated list of tests to skip")
 	flag.StringVar(&config.OnlyTests, "onlyTests", "", "A comma separated list of tests to include")
+	flag.Var(&config.Severities, "severities", "A comma separated list of severity overrides in the format CheckId=severity, e.g. OctoLintPerpetualApiKeys=error. The severity can be error, warning, or info")
+	flag.StringVar(&config.FailOn, "failOn", "none", "Exit with code "+fmt.Sprint(exitCodeIssuesFound)+" if any issues at or above this severity are found. Can be none, error, warning, or info. Scans where any checks failed to run always exit with code "+fmt.Sprint(exitCodeChecksFailed))
+	flag.StringVar(&config.Baseline, "baseline", "", "A baseline file created with the writeBaseline argument. Issues recorded in the baseline are not reported")
+	flag.StringVar(&config.WriteBaseline, "writeBaseline", "", "Record the issues found by the scan in a baseline file")
+	flag.StringVar(&config.MinSeverity, "minSeverity", "warning", "The minimum severity of the results included in the report. Can be error, warning, info, permission, or ok. The "+reporters.OpenMetricsFormat+" format compares it to the severity each check reports when it finds an issue")
 	flag.StringVar(&config.ConfigFile, "configFile", "octolint", "The name of the configuration file to use. Do not include the extension. Defaults to octolint")
 	flag.StringVar(&config.ConfigPath, "configPath", ".", "The path of the configuration file to use. Defaults to the current directory")
 	flag.BoolVar(&config.Verbose, "verbose", false, "Print verbose logs")
 	flag.BoolVar(&config.VerboseErrors, "verboseErrors", false, "Print error details as verbose logs in Octopus")
 	flag.BoolVar(&config.Version, "version", false, "Print the version")
 	flag.BoolVar(&config.Spinner, "spinner", true, "Display the spinner")
+	flag.StringVar(&config.Snapshot, "snapshot", "", "Run the checks against a snapshot file created by the export command rather than an Octopus server")
+	flag.StringVar(&config.Format, "format", reporters.PlainFormat, "The format of the report. Can be "+strings.Join(reporters.Formats, ", "))
+	flag.IntVar(&config.MarkdownMaxFindings, "markdownMaxFindings", defaults.MarkdownMaxFindings, "The maximum number of resources listed for each check in the markdown report. Set to 0 to list all resources.")
+	flag.IntVar(&config.MarkdownMaxLength, "markdownMaxLength", defaults.MarkdownMaxLength, "The maximum number of characters in the markdown report. Checks that do not fit are left out. Set to 0 to disable the limit.")
+	flag.BoolVar(&config.MarkdownCollapse, "markdownCollapse", false, "Place the resources listed for each check in the markdown report in a collapsible details block")
+	flag.StringVar(&config.ArtifactDirectory, "artifactDirectory", ".", "The directory the "+reporters.OctopusFormat+" format saves the HTML and JSON reports to before attaching them to the Octopus task as artifacts. Set to an empty string to disable the artifacts.")
+	flag.StringVar(&config.MetricsAddress, "metricsAddress", "", "Serve the results in the "+reporters.OpenMetricsFormat+" format from a /metrics endpoint on this address, e.g. :9090, scanning the space again after each metricsInterval")
+	flag.IntVar(&config.MetricsInterval, "metricsInterval", defaults.MetricsInterval, "The number of minutes between the scans served by the metrics endpoint")
+	flag.StringVar(&config.ServeAddress, "serveAddress", defaults.ServeAddress, "The address the "+serveCommand+" command listens on")
+	flag.IntVar(&config.ServeWorkers, "serveWorkers", defaults.ServeWorkers, "The number of scans the "+serveCommand+" command runs at the same time")
+	flag.IntVar(&config.ServeQueueSize, "serveQueueSize", defaults.ServeQueueSize, "The number of scans the "+serveCommand+" command queues before rejecting new requests")
+	flag.IntVar(&config.ServeHistory, "serveHistory", defaults.ServeHistory, "The number of past scans the "+serveCommand+" command remembers")
+	flag.StringVar(&config.ServeToken, "serveToken", "", "The bearer token that requests to the "+serveCommand+" command must include. Required when the serveAddress accepts connections from other hosts")
+	flag.StringVar(&config.Schedule, "schedule", "", "The cron expression, like \"0 2 * * *\", or interval, like \"@every 6h\", that the "+daemonCommand+" command scans the spaces on")
+	flag.StringVar(&config.HistoryFile, "historyFile", "octolint-history.json", "The file the "+daemonCommand+" command records the results of each scan in, and the "+trendCommand+" command reports on")
+	flag.IntVar(&config.HistoryMaxRuns, "historyMaxRuns", defaults.HistoryMaxRuns, "The number of scans kept in the historyFile")
+	flag.IntVar(&config.TrendRuns, "trendRuns", defaults.TrendRuns, "The number of recent scans reported by the "+trendCommand+" command. Set to 0 to report every scan in the historyFile")
+	flag.StringVar(&config.WebhookState, "webhookState", "octolint-webhooks.json", "The file recording the issues last sent to the webhooks with onChange enabled")
+	flag.StringVar(&config.Output, "output", "", "The file the report is saved to. Defaults to printing the report to std out")
+	flag.StringVar(&config.Category, "category", "", "Limits the checks printed by the list-checks command to a single category, e.g. Security")
+	flag.StringVar(&config.ExportFile, "exportFile", "octolint-snapshot.json", "The file the export command saves the snapshot to. Files ending in .gz are compressed")
+	flag.IntVar(&config.CheckTimeout, "checkTimeout", defaults.CheckTimeout, "The maximum number of seconds each check can run for. Set to 0 to disable the timeout.")
+	flag.IntVar(&config.CheckRetries, "checkRetries", defaults.CheckRetries, "The number of times a check is attempted when it fails with a transient network or server error")
 	flag.IntVar(&config.MaxEnvironments, "maxEnvironments", defaults.MaxEnvironments, "Maximum number of environments for the "+organization.OctopusEnvironmentCountCheckName+" check")
 	flag.IntVar(&config.MaxDaysSinceLastTask, "maxDaysSinceLastTask", defaults.MaxTimeSinceLastTask, "Maximum number of days since the last project task for the "+organization.OctopusUnusedProjectsCheckName+" check")
 	flag.IntVar(&config.MaxDuplicateVariables, "maxDuplicateVariables", defaults.MaxDuplicateVariables, "Maximum number of duplicate variables to report on for the "+organization.OctoLintDuplicatedVariables+" check. Set to 0 to report all duplicate variables.")
@@ -209,12 +979,14 @@ func parseArgs() (*config.OctolintConfig, error) {
 	flag.StringVar(&config.TargetNameRegex, "targetNameRegex", "", "The regular expression used to validate target names for the "+naming.OctoLintInvalidTargetNames+" check")
 	flag.StringVar(&config.TargetRoleRegex, "targetRoleRegex", "", "The regular expression used to validate target roles for the "+naming.OctoLintInvalidTargetRoles+" check")
 	flag.StringVar(&config.ProjectReleaseTemplateRegex, "projectReleaseTemplateRegex", "", "The regular expression used to validate project release templates for the "+naming.OctoLintProjectReleaseTemplate+" check")
-	flag.StringVar(&config.ProjectStepWorkerPoolRegex, "projectStepWorkerPoolRegex", "", "The regular expression used to validate step worker pools for the  "+naming.OctoLintProjectReleaseTemplate+" check")
+	flag.StringVar(&config.ProjectStepWorkerPoolRegex, "projectStepWorkerPoolRegex", "", "The regular expression used to validate step worker pools for the "+naming.OctoLintProjectWorkerPool+" check")
 	flag.StringVar(&config.LifecycleNameRegex, "lifecycleNameRegex", "", "The regular expression used to validate lifecycle names for the  "+naming.OctoLintInvalidLifecycleNames+" check")
 
-	flag.Parse()
+	if err := flag.CommandLine.Parse(args); err != nil {
+		return nil, err
+	}
 
-	err := overrideArgs(config.ConfigPath, config.ConfigFile)
+	err := overrideArgs(config.ConfigPath, config.ConfigFile, &config)
 
 	if err != nil {
 		return nil, err
@@ -233,7 +1005,7 @@ func parseArgs() (*config.OctolintConfig, error) {
 
 // Inspired by https://github.com/carolynvs/stingoftheviper
 // Viper needs manual handling to implement reading settings from env vars, config files, and from the command line
-func overrideArgs(configPath string, configFile string) error {
+func overrideArgs(configPath string, configFile string, octolintConfig *config.OctolintConfig) error {
 	v := viper.New()
 
 	// Set the base name of the config file, without the file extension.
@@ -268,10 +1040,32 @@ func overrideArgs(configPath string, configFile string) error {
 	// like --favorite-color which we fix in the bindFlags function
 	v.AutomaticEnv()
 
+	// Structured settings like suppressions can not be expressed as flags, so they are read directly from the config file
+	if err := v.UnmarshalKey("suppressions", &octolintConfig.Suppressions, viper.DecodeHook(dateToStringHook)); err != nil {
+		return err
+	}
+
+	if err := v.UnmarshalKey("webhooks", &octolintConfig.Webhooks); err != nil {
+		return err
+	}
+
+	if err := v.UnmarshalKey("score", &octolintConfig.Score); err != nil {
+		return err
+	}
+
 	// Bind the current command's flags to viper
 	return bindFlags(v)
 }
 
+// dateToStringHook converts unquoted YAML dates, which are parsed as timestamps, back to strings like 2024-12-31.
+func dateToStringHook(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if date, ok := data.(time.Time); ok && to.Kind() == reflect.String {
+		return date.Format(suppressions.ExpiresFormat), nil
+	}
+
+	return data, nil
+}
+
 // Bind each flag to its associated viper configuration (config file and environment variable)
 func bindFlags(v *viper.Viper) (funErr error) {
 	var funcError error = nil
@@ -296,47 +1090,3 @@ func bindFlags(v *viper.Viper) (funErr error) {
 
 	return funcError
 }
-
-func lookupSpaceAsName(octopusUrl string, spaceName string, apiKey string) (string, error) {
-	if len(strings.TrimSpace(spaceName)) == 0 {
-		return "", errors.New("space can not be empty")
-	}
-
-	requestURL := fmt.Sprintf("%s/api/Spaces?take=1000&partialName=%s", octopusUrl, url.QueryEscape(spaceName))
-
-	req, err := http.NewRequest(http.MethodGet, requestURL, nil)
-
-	if err != nil {
-		return "", err
-	}
-
-	if apiKey != "" {
-		req.Header.Set("X-Octopus-ApiKey", apiKey)
-	}
-
-	res, err := http.DefaultClient.Do(req)
-
-	if err != nil {
-		return "", err
-	}
-
-	if res.StatusCode != 200 {
-		return "", nil
-	}
-	defer res.Body.Close()
-
-	collection := resources.Resources[spaces.Space]{}
-	err = json.NewDecoder(res.Body).Decode(&collection)
-
-	if err != nil {
-		return "", err
-	}
-
-	for _, space := range collection.Items {
-		if space.Name == spaceName {
-			return space.ID, nil
-		}
-	}
-
-	return "", errors.New("did not find space with name " + spaceName)
-}
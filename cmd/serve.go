@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/defaults"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/server"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// serverArguments can only be set when the server is started. They hold the credentials and files of the server, or
+// control the server and the output of the command line tool rather than a scan.
+var serverArguments = []string{
+	"url", "apiKey", "snapshot", "configFile", "configPath", "baseline", "writeBaseline", "webhookState", "output",
+	"exportFile", "artifactDirectory", "format", "category", "metricsAddress", "metricsInterval", "serveAddress",
+	"serveWorkers", "serveQueueSize", "serveHistory", "serveToken", "schedule", "historyFile", "historyMaxRuns", "trendRuns",
+	"version", "verbose", "verboseErrors", "spinner",
+}
+
+// serveApi serves the HTTP API that starts scans, reports their status, and returns their results. Scans are run by a
+// fixed number of workers, and requests are rejected once the queue is full. The server runs until it is stopped with
+// Ctrl-C, which interrupts the running scans.
+func serveApi(octolintConfig *config.OctolintConfig) int {
+	if octolintConfig.ServeWorkers <= 0 {
+		errorExit("The serveWorkers argument must be greater than 0")
+	}
+
+	if octolintConfig.ServeQueueSize <= 0 {
+		errorExit("The serveQueueSize argument must be greater than 0")
+	}
+
+	if octolintConfig.ServeHistory <= 0 {
+		errorExit("The serveHistory argument must be greater than 0")
+	}
+
+	// Requests inherit the arguments of the server, so invalid arguments are reported before the server starts
+	if _, err := newScanSettings(octolintConfig); err != nil {
+		errorExit(err.Error())
+	}
+
+	listener, err := net.Listen("tcp", octolintConfig.ServeAddress)
+
+	if err != nil {
+		errorExit("Failed to listen on " + octolintConfig.ServeAddress + ".\nThe error was: " + err.Error())
+	}
+
+	// Scans run with the credentials of the server, so the API is only exposed to other hosts when it requires a token
+	if octolintConfig.ServeToken == "" && !isLoopback(listener.Addr()) {
+		_ = listener.Close()
+		errorExit("The serveToken argument must be set when the serveAddress " + octolintConfig.ServeAddress +
+			" accepts connections from other hosts. Set the serveToken argument, or listen on a loopback address like " +
+			defaults.ServeAddress + ".")
+	}
+
+	queue := server.NewOctopusScanQueue(octolintConfig.ServeQueueSize, octolintConfig.ServeHistory)
+	scanServer := server.NewOctopusScanServer(octolintConfig, scanArguments(), prepareScan, queue, octolintConfig.ServeToken)
+
+	httpServer := &http.Server{Handler: scanServer, ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	queueStopped := make(chan struct{})
+	go func() {
+		queue.Run(ctx, octolintConfig.ServeWorkers)
+		close(queueStopped)
+	}()
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			zap.L().Debug("Failed to shut down the API server: " + err.Error())
+		}
+	}()
+
+	fmt.Println("Serving the API at http://" + listener.Addr().String() + server.ScansPath)
+
+	if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		errorExit("The API server failed.\nThe error was: " + err.Error())
+	}
+
+	// Wait for the running scans to be interrupted
+	<-queueStopped
+
+	return 0
+}
+
+// isLoopback returns true if the address only accepts connections from the local host. Addresses that listen on every
+// interface, like ":8080", are not loopback addresses.
+func isLoopback(addr net.Addr) bool {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	return ok && tcpAddr.IP.IsLoopback()
+}
+
+// prepareScan validates the arguments of a scan requested through the API, returning the function that runs it in the
+// same way as the command line tool.
+func prepareScan(scanConfig *config.OctolintConfig) (server.OctopusScanFunc, error) {
+	settings, err := newScanSettings(scanConfig)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) (server.OctopusScanOutcome, error) {
+		outcome, err := scan(ctx, scanConfig, settings, os.Stdout)
+
+		if err != nil {
+			return server.OctopusScanOutcome{}, err
+		}
+
+		return server.OctopusScanOutcome{
+			Spaces:      outcome.spaceResults,
+			Metadata:    outcome.metadata,
+			MinSeverity: settings.minSeverity,
+		}, nil
+	}, nil
+}
+
+// scanArguments returns the names of the arguments that a request to the API can set
+func scanArguments() []string {
+	arguments := []string{}
+
+	flag.CommandLine.VisitAll(func(f *flag.Flag) {
+		if !lo.Contains(serverArguments, f.Name) {
+			arguments = append(arguments, f.Name)
+		}
+	})
+
+	return arguments
+}
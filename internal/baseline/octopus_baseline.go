@@ -0,0 +1,175 @@
+package baseline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"os"
+	"time"
+)
+
+// BaselineVersion is the version of the baseline file format. It is incremented when a change to the format means
+// older versions of octolint can no longer read the file.
+const BaselineVersion = 1
+
+// OctopusBaselineFinding records a finding that has been accepted into the baseline. Only the fingerprint is used to
+// match findings; the other fields make the file easier to review.
+type OctopusBaselineFinding struct {
+	Fingerprint  string `json:"fingerprint"`
+	CheckId      string `json:"checkId"`
+	ResourceType string `json:"resourceType"`
+	ResourceId   string `json:"resourceId,omitempty"`
+	ResourceName string `json:"resourceName"`
+	ProjectName  string `json:"projectName,omitempty"`
+	// SpaceId is the space that was scanned when the finding was recorded. It is empty for the findings of instance
+	// scoped checks, and in baselines recorded by older versions of octolint.
+	SpaceId string `json:"spaceId,omitempty"`
+}
+
+// OctopusBaseline is the set of findings that existed when the baseline was recorded.
+type OctopusBaseline struct {
+	Version  int                      `json:"version"`
+	Created  time.Time                `json:"created"`
+	Findings []OctopusBaselineFinding `json:"findings"`
+}
+
+// OctopusBaselineReport describes how a baseline was applied to a scan.
+type OctopusBaselineReport struct {
+	// Baselined is the number of findings that were removed from the results because they are in the baseline
+	Baselined int
+	// Resolved is the number of findings in the baseline that were not found by the scan, even though the check that
+	// found them ran successfully in the same space
+	Resolved int
+}
+
+// NewOctopusBaseline records every finding in the results of the spaces.
+func NewOctopusBaseline(spaces []checks.OctopusSpaceResults, created time.Time) OctopusBaseline {
+	baseline := OctopusBaseline{
+		Version:  BaselineVersion,
+		Created:  created,
+		Findings: []OctopusBaselineFinding{},
+	}
+
+	fingerprints := map[string]bool{}
+	for _, space := range spaces {
+		for _, r := range space.Results {
+			if r.Category() == checks.GeneralError {
+				continue
+			}
+
+			for _, f := range r.Findings() {
+				fingerprint := checks.Fingerprint(r.Code(), f)
+
+				// Some checks report the same resource more than once, like a variable duplicated in many projects
+				if fingerprints[fingerprint] {
+					continue
+				}
+				fingerprints[fingerprint] = true
+
+				baseline.Findings = append(baseline.Findings, OctopusBaselineFinding{
+					Fingerprint:  fingerprint,
+					CheckId:      r.Code(),
+					ResourceType: f.ResourceType,
+					ResourceId:   f.ResourceId,
+					ResourceName: f.ResourceName,
+					ProjectName:  f.ProjectName,
+					SpaceId:      space.SpaceId,
+				})
+			}
+		}
+	}
+
+	return baseline
+}
+
+// Apply removes the findings that are in the baseline from the results, leaving only the new findings. A finding in the
+// baseline is only counted as resolved if the check that found it ran successfully in its space, so the findings of
+// checks that were skipped, failed, lacked permission, or did not complete before the scan was interrupted are not.
+func (o OctopusBaseline) Apply(spaces []checks.OctopusSpaceResults) ([]checks.OctopusSpaceResults, OctopusBaselineReport) {
+	baselined := map[string]bool{}
+	for _, f := range o.Findings {
+		baselined[f.Fingerprint] = false
+	}
+
+	report := OctopusBaselineReport{}
+	completed := map[string]bool{}
+	ranSpaces := map[string]int{}
+	completedSpaces := map[string]int{}
+	filteredSpaces := []checks.OctopusSpaceResults{}
+	for _, space := range spaces {
+		filteredResults := []checks.OctopusCheckResult{}
+		for _, r := range space.Results {
+			ranSpaces[r.Code()]++
+			if r.Category() != checks.GeneralError && r.Severity() != checks.Permission {
+				completed[checkKey(r.Code(), space.SpaceId)] = true
+				completedSpaces[r.Code()]++
+			}
+
+			filtered, removed := checks.WithoutFindings(r, func(finding checks.OctopusCheckFinding) bool {
+				fingerprint := checks.Fingerprint(r.Code(), finding)
+				if _, found := baselined[fingerprint]; found {
+					baselined[fingerprint] = true
+					return true
+				}
+				return false
+			}, "recorded in the baseline")
+
+			report.Baselined += removed
+			filteredResults = append(filteredResults, filtered)
+		}
+
+		space.Results = filteredResults
+		filteredSpaces = append(filteredSpaces, space)
+	}
+
+	for _, f := range o.Findings {
+		if baselined[f.Fingerprint] {
+			continue
+		}
+
+		// Findings recorded without their space are only resolved if the check ran successfully everywhere it ran
+		if (f.SpaceId != "" && completed[checkKey(f.CheckId, f.SpaceId)]) ||
+			(f.SpaceId == "" && completedSpaces[f.CheckId] == ranSpaces[f.CheckId] && completedSpaces[f.CheckId] != 0) {
+			report.Resolved++
+		}
+	}
+
+	return filteredSpaces, report
+}
+
+func checkKey(checkId string, spaceId string) string {
+	return checkId + "/" + spaceId
+}
+
+// WriteBaseline saves the baseline to a JSON file.
+func WriteBaseline(baseline OctopusBaseline, path string) error {
+	content, err := json.MarshalIndent(baseline, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0644)
+}
+
+// ReadBaseline loads a baseline saved by WriteBaseline.
+func ReadBaseline(path string) (OctopusBaseline, error) {
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return OctopusBaseline{}, err
+	}
+
+	baseline := OctopusBaseline{}
+	if err := json.Unmarshal(content, &baseline); err != nil {
+		return OctopusBaseline{}, err
+	}
+
+	if baseline.Version < 1 || baseline.Version > BaselineVersion {
+		return OctopusBaseline{}, errors.New("the baseline version " + fmt.Sprint(baseline.Version) +
+			" is not supported. This version of octolint supports baseline versions up to " + fmt.Sprint(BaselineVersion))
+	}
+
+	return baseline, nil
+}
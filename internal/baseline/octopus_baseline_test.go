@@ -0,0 +1,120 @@
+package baseline
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/organization"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func unusedVariablesResult(description string, variables ...string) checks.OctopusCheckResult {
+	findings := []checks.OctopusCheckFinding{}
+	for _, v := range variables {
+		findings = append(findings, checks.OctopusCheckFinding{
+			ResourceType: checks.VariableResource,
+			ResourceId:   "Variables-" + v,
+			ResourceName: v,
+			ProjectId:    "Projects-1",
+			ProjectName:  "App",
+		})
+	}
+
+	return checks.NewOctopusCheckResultImpl(description, organization.OctoLintUnusedVariables, "", checks.Warning, checks.Organization, findings...)
+}
+
+func spaceResults(spaceId string, results ...checks.OctopusCheckResult) []checks.OctopusSpaceResults {
+	return []checks.OctopusSpaceResults{{SpaceId: spaceId, SpaceName: spaceId, Results: results}}
+}
+
+func TestBaselineOnlyReportsNewFindings(t *testing.T) {
+	baseline := NewOctopusBaseline(spaceResults("Spaces-1",
+		unusedVariablesResult("The following variables may be unused:\nApp: A\nApp: B", "A", "B")), time.Now())
+
+	// The description has changed, but the fingerprints of A and B have not
+	spaces, report := baseline.Apply(spaceResults("Spaces-1",
+		unusedVariablesResult("These variables are unused:\nApp: B\nApp: C", "B", "C")))
+	results := spaces[0].Results
+
+	if report.Baselined != 1 || report.Resolved != 1 {
+		t.Fatalf("Unexpected report: %+v", report)
+	}
+
+	if len(results[0].Findings()) != 1 || results[0].Findings()[0].ResourceName != "C" {
+		t.Fatal("Should have only reported the new finding")
+	}
+}
+
+func TestBaselineWithNoNewFindingsPasses(t *testing.T) {
+	original := spaceResults("Spaces-1", unusedVariablesResult("The following variables may be unused:\nApp: A", "A"))
+	spaces, _ := NewOctopusBaseline(original, time.Now()).Apply(original)
+
+	if spaces[0].Results[0].Severity() != checks.Ok {
+		t.Fatal("Should have reported the check as passing")
+	}
+}
+
+func TestBaselineOnlyResolvesFindingsOfChecksThatRan(t *testing.T) {
+	baseline := NewOctopusBaseline(spaceResults("Spaces-1", unusedVariablesResult("Unused", "A")), time.Now())
+
+	failed := checks.NewOctopusCheckResultImpl("Failed", organization.OctoLintUnusedVariables, "", checks.Error, checks.GeneralError)
+	noPermission := checks.NewOctopusCheckResultImpl("No permission", organization.OctoLintUnusedVariables, "", checks.Permission, checks.Organization)
+
+	scans := map[string][]checks.OctopusSpaceResults{
+		"skipped":       spaceResults("Spaces-1"),
+		"failed":        spaceResults("Spaces-1", failed),
+		"no permission": spaceResults("Spaces-1", noPermission),
+		"other space":   spaceResults("Spaces-2", unusedVariablesResult("Unused")),
+	}
+
+	for name, scan := range scans {
+		if _, report := baseline.Apply(scan); report.Resolved != 0 {
+			t.Fatalf("Should not have resolved the finding when the check was %s: %+v", name, report)
+		}
+	}
+
+	if _, report := baseline.Apply(spaceResults("Spaces-1", unusedVariablesResult("Unused"))); report.Resolved != 1 {
+		t.Fatalf("Should have resolved the finding when the check ran: %+v", report)
+	}
+}
+
+func TestBaselineResolvesFindingsOfInstanceScopedChecks(t *testing.T) {
+	// The instance scoped checks are reported under an entry with no space ID when many spaces are scanned
+	scan := append(spaceResults("", unusedVariablesResult("Unused", "A")), spaceResults("Spaces-1")...)
+	baseline := NewOctopusBaseline(scan, time.Now())
+
+	if _, report := baseline.Apply(append(spaceResults("", unusedVariablesResult("Unused")), spaceResults("Spaces-1")...)); report.Resolved != 1 {
+		t.Fatalf("Should have resolved the finding when the check ran: %+v", report)
+	}
+}
+
+func TestBaselineRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	baseline := NewOctopusBaseline(spaceResults("Spaces-1", unusedVariablesResult("Unused", "A", "B")), time.Now())
+
+	if err := WriteBaseline(baseline, path); err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	loaded, err := ReadBaseline(path)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if len(loaded.Findings) != 2 || loaded.Findings[0].Fingerprint != baseline.Findings[0].Fingerprint {
+		t.Fatal("Should have loaded the findings")
+	}
+}
+
+func TestUnsupportedBaselineVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+
+	if err := WriteBaseline(OctopusBaseline{Version: BaselineVersion + 1}, path); err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if _, err := ReadBaseline(path); err == nil {
+		t.Fatal("Should have rejected the newer baseline version")
+	}
+}
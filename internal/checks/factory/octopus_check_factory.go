@@ -1,31 +1,35 @@
 package factory
 
 import (
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"errors"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/naming"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/organization"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/performance"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/registry"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/security"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"github.com/samber/lo"
 	"golang.org/x/exp/slices"
 	"strings"
 )
 
 // OctopusCheckFactory builds all the lint checks. This is where you can customize things like error handlers.
+// All the checks share the one snapshot, so each resource is only loaded from the space once.
 type OctopusCheckFactory struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	url          string
 	space        string
 }
 
-func NewOctopusCheckFactory(client *client.Client, url string, space string) OctopusCheckFactory {
-	return OctopusCheckFactory{client: client, url: url, space: space, errorHandler: checks.OctopusClientPermissiveErrorHandler{}}
+func NewOctopusCheckFactory(snapshot snapshot.OctopusSpaceSnapshot, url string, space string) OctopusCheckFactory {
+	return OctopusCheckFactory{snapshot: snapshot, url: url, space: space, errorHandler: checks.OctopusClientPermissiveErrorHandler{}}
 }
 
-// BuildAllChecks creates new instances of all the checks and returns them as an array.
+// BuildAllChecks creates new instances of all the checks and returns them as an array. An error is returned if the
+// skipTests or onlyTests settings reference a check that does not exist.
 func (o OctopusCheckFactory) BuildAllChecks(config *config.OctolintConfig) ([]checks.OctopusCheck, error) {
 	skipChecksSlice := lo.FilterMap(strings.Split(config.SkipTests, ","), func(item string, index int) (string, bool) {
 		itemTrimmed := strings.TrimSpace(item)
@@ -37,36 +41,61 @@ func (o OctopusCheckFactory) BuildAllChecks(config *config.OctolintConfig) ([]ch
 		return itemTrimmed, len(itemTrimmed) != 0
 	})
 
+	if err := registry.ValidateCheckIds(skipChecksSlice); err != nil {
+		return nil, errors.New("the skipTests setting is invalid: " + err.Error())
+	}
+
+	if err := registry.ValidateCheckIds(onlyChecksSlice); err != nil {
+		return nil, errors.New("the onlyTests setting is invalid: " + err.Error())
+	}
+
+	// Each check reads the shared snapshot through its own counting snapshot, so the number of resources scanned by
+	// each check can be reported. The checks are built in order, so the counters line up with the checks.
+	counters := []*snapshot.OctopusCountingSpaceSnapshot{}
+	counted := func() snapshot.OctopusSpaceSnapshot {
+		counter := snapshot.NewOctopusCountingSpaceSnapshot(o.snapshot)
+		counters = append(counters, counter)
+		return counter
+	}
+
 	allChecks := []checks.OctopusCheck{
-		security.NewOctopusUnrotatedAccountsCheck(o.client, config, o.errorHandler),
-		security.NewOctopusDeploymentQueuedByAdminCheck(o.client, config, o.errorHandler),
-		security.NewOctopusPerpetualApiKeysCheck(o.client, config, o.errorHandler),
-		security.NewOctopusDuplicatedGitCredentialsCheck(o.client, config, o.errorHandler),
-		security.NewOctopusInsecureK8sCheck(o.client, config, o.errorHandler),
-		security.NewOctopusInsecureFeedsCheck(o.client, config, o.errorHandler),
-		security.NewOctopusInsecureSubscriptionsCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusEnvironmentCountCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusDefaultProjectGroupCountCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusEmptyProjectCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusUnusedVariablesCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusDuplicatedVariablesCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusProjectTooManyStepsCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusLifecycleRetentionPolicyCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusUnusedTargetsCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusProjectSpecificEnvironmentCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusTenantsInsteadOfTagsCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusProjectGroupsWithExclusiveEnvironmentsCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusUnhealthyTargetCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusUnusedProjectsCheck(o.client, config, o.errorHandler),
-		performance.NewOctopusDeploymentQueuedTimeCheck(o.client, config, o.url, o.space, o.errorHandler),
-		naming.NewOctopusProjectContainerImageRegex(o.client, config, o.errorHandler),
-		naming.NewOctopusInvalidVariableNameCheck(o.client, config, o.errorHandler),
-		naming.NewOctopusInvalidTargetName(o.client, config, o.errorHandler),
-		naming.NewOctopusInvalidTargetRole(o.client, config, o.errorHandler),
-		naming.NewOctopusProjectReleaseTemplateRegex(o.client, config, o.errorHandler),
-		naming.NewOctopusProjectWorkerPoolRegex(o.client, config, o.errorHandler),
-		naming.NewOctopusInvalidLifecycleName(o.client, config, o.errorHandler),
-		naming.NewOctopusProjectDefaultStepNames(o.client, config, o.errorHandler),
+		security.NewOctopusUnrotatedAccountsCheck(counted(), config, o.errorHandler),
+		security.NewOctopusDeploymentQueuedByAdminCheck(counted(), config, o.errorHandler),
+		security.NewOctopusPerpetualApiKeysCheck(counted(), config, o.errorHandler),
+		security.NewOctopusDuplicatedGitCredentialsCheck(counted(), config, o.errorHandler),
+		security.NewOctopusInsecureK8sCheck(counted(), config, o.errorHandler),
+		security.NewOctopusInsecureFeedsCheck(counted(), config, o.errorHandler),
+		security.NewOctopusInsecureSubscriptionsCheck(counted(), config, o.errorHandler),
+		organization.NewOctopusEnvironmentCountCheck(counted(), config, o.errorHandler),
+		organization.NewOctopusDefaultProjectGroupCountCheck(counted(), config, o.errorHandler),
+		organization.NewOctopusEmptyProjectCheck(counted(), config, o.errorHandler),
+		organization.NewOctopusUnusedVariablesCheck(counted(), config, o.errorHandler),
+		organization.NewOctopusDuplicatedVariablesCheck(counted(), config, o.errorHandler),
+		organization.NewOctopusProjectTooManyStepsCheck(counted(), config, o.errorHandler),
+		organization.NewOctopusLifecycleRetentionPolicyCheck(counted(), config, o.errorHandler),
+		organization.NewOctopusUnusedTargetsCheck(counted(), config, o.errorHandler),
+		organization.NewOctopusProjectSpecificEnvironmentCheck(counted(), config, o.errorHandler),
+		organization.NewOctopusTenantsInsteadOfTagsCheck(counted(), config, o.errorHandler),
+		organization.NewOctopusProjectGroupsWithExclusiveEnvironmentsCheck(counted(), config, o.errorHandler),
+		organization.NewOctopusUnhealthyTargetCheck(counted(), config, o.errorHandler),
+		organization.NewOctopusUnusedProjectsCheck(counted(), config, o.errorHandler),
+		performance.NewOctopusDeploymentQueuedTimeCheck(counted(), config, o.url, o.space, o.errorHandler),
+		naming.NewOctopusProjectContainerImageRegex(counted(), config, o.errorHandler),
+		naming.NewOctopusInvalidVariableNameCheck(counted(), config, o.errorHandler),
+		naming.NewOctopusInvalidTargetName(counted(), config, o.errorHandler),
+		naming.NewOctopusInvalidTargetRole(counted(), config, o.errorHandler),
+		naming.NewOctopusProjectReleaseTemplateRegex(counted(), config, o.errorHandler),
+		naming.NewOctopusProjectWorkerPoolRegex(counted(), config, o.errorHandler),
+		naming.NewOctopusInvalidLifecycleName(counted(), config, o.errorHandler),
+		naming.NewOctopusProjectDefaultStepNames(counted(), config, o.errorHandler),
+	}
+
+	for i, check := range allChecks {
+		allChecks[i] = octopusCountedCheck{OctopusCheck: check, counter: counters[i]}
+
+		if _, found := registry.GetCheck(check.Id()); !found {
+			return nil, errors.New("the check " + check.Id() + " has not been added to the registry")
+		}
 	}
 
 	return lo.Filter(allChecks, func(item checks.OctopusCheck, index int) bool {
@@ -74,3 +103,13 @@ func (o OctopusCheckFactory) BuildAllChecks(config *config.OctolintConfig) ([]ch
 			(len(onlyChecksSlice) == 0 || slices.Index(onlyChecksSlice, item.Id()) != -1)
 	}), nil
 }
+
+// octopusCountedCheck reports the number of resources read by a check through its counting snapshot
+type octopusCountedCheck struct {
+	checks.OctopusCheck
+	counter *snapshot.OctopusCountingSpaceSnapshot
+}
+
+func (o octopusCountedCheck) ResourcesScanned() int {
+	return o.counter.ResourcesScanned()
+}
@@ -0,0 +1,89 @@
+package factory
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/organization"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/registry"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"testing"
+)
+
+func TestAllChecksAreRegistered(t *testing.T) {
+	allChecks, err := NewOctopusCheckFactory(nil, "", "").BuildAllChecks(&config.OctolintConfig{})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if len(allChecks) != len(registry.AllChecks()) {
+		t.Fatalf("Built %d checks, but the registry has %d checks", len(allChecks), len(registry.AllChecks()))
+	}
+}
+
+func TestOnlyTests(t *testing.T) {
+	allChecks, err := NewOctopusCheckFactory(nil, "", "").BuildAllChecks(&config.OctolintConfig{
+		OnlyTests: organization.OctoLintEmptyProject + ", " + organization.OctoLintUnusedVariables,
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if len(allChecks) != 2 {
+		t.Fatalf("Should have built 2 checks, but built %d", len(allChecks))
+	}
+}
+
+func TestSkipTests(t *testing.T) {
+	allChecks, err := NewOctopusCheckFactory(nil, "", "").BuildAllChecks(&config.OctolintConfig{
+		SkipTests: organization.OctoLintEmptyProject,
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	for _, check := range allChecks {
+		if check.Id() == organization.OctoLintEmptyProject {
+			t.Fatal("Should have skipped the check " + organization.OctoLintEmptyProject)
+		}
+	}
+}
+
+func TestUnknownTestsAreRejected(t *testing.T) {
+	_, err := NewOctopusCheckFactory(nil, "", "").BuildAllChecks(&config.OctolintConfig{
+		SkipTests: "OctoLintEmptyProjects",
+	})
+
+	if err == nil {
+		t.Fatal("Should have returned an error for the misspelled skipTests check")
+	}
+
+	_, err = NewOctopusCheckFactory(nil, "", "").BuildAllChecks(&config.OctolintConfig{
+		OnlyTests: "OctoLintDoesNotExist",
+	})
+
+	if err == nil {
+		t.Fatal("Should have returned an error for the unknown onlyTests check")
+	}
+}
+
+func TestChecksCountScannedResources(t *testing.T) {
+	allChecks, err := NewOctopusCheckFactory(nil, "", "").BuildAllChecks(&config.OctolintConfig{})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	for _, check := range allChecks {
+		counted, ok := check.(checks.OctopusCountedCheck)
+
+		if !ok {
+			t.Fatal("The check " + check.Id() + " should count the resources it scans")
+		}
+
+		if counted.ResourcesScanned() != 0 {
+			t.Fatal("The check " + check.Id() + " should not have scanned any resources before it was executed")
+		}
+	}
+}
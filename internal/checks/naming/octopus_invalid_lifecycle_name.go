@@ -1,11 +1,12 @@
 package naming
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
 	"regexp"
 	"strings"
@@ -15,14 +16,14 @@ const OctoLintInvalidLifecycleNames = "OctoLintInvalidLifecycleNames"
 
 // OctopusInvalidLifecycleName find targets that have not been healthy in the last 30 days.
 type OctopusInvalidLifecycleName struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusInvalidLifecycleName(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusInvalidLifecycleName {
+func NewOctopusInvalidLifecycleName(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusInvalidLifecycleName {
 	return OctopusInvalidLifecycleName{
-		client:       client,
+		snapshot:     snapshot,
 		errorHandler: errorHandler,
 		config:       config,
 	}
@@ -32,9 +33,9 @@ func (o OctopusInvalidLifecycleName) Id() string {
 	return OctoLintInvalidLifecycleNames
 }
 
-func (o OctopusInvalidLifecycleName) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusInvalidLifecycleName) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -53,23 +54,32 @@ func (o OctopusInvalidLifecycleName) Execute() (checks.OctopusCheckResult, error
 		return checks.NewOctopusCheckResultImpl(
 			"The supplied regex "+o.config.LifecycleNameRegex+" does not compile",
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Error,
 			checks.Naming), nil
 	}
 
-	lifecycles, err := o.client.Lifecycles.GetAll()
+	lifecycles, err := o.snapshot.GetLifecycles(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
 	}
 
 	responses := []string{}
+	findings := []checks.OctopusCheckFinding{}
 	for i, l := range lifecycles {
 		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(lifecycles))*100) + "% complete")
 
 		if !regex.Match([]byte(l.Name)) {
 			responses = append(responses, l.Name)
+			findings = append(findings, checks.OctopusCheckFinding{
+				ResourceType: checks.LifecycleResource,
+				ResourceId:   l.ID,
+				ResourceName: l.Name,
+				SpaceId:      l.SpaceID,
+				Message:      "The lifecycle name does not match the regex " + o.config.LifecycleNameRegex,
+				Evidence:     l.Name,
+			})
 		}
 	}
 
@@ -77,15 +87,16 @@ func (o OctopusInvalidLifecycleName) Execute() (checks.OctopusCheckResult, error
 		return checks.NewOctopusCheckResultImpl(
 			"The following lifecycle names do not match the regex "+o.config.LifecycleNameRegex+":\n"+strings.Join(responses, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Naming), nil
+			checks.Naming,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"All lifecycles match the regex "+o.config.LifecycleNameRegex,
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Naming), nil
 }
@@ -1,10 +1,12 @@
 package naming
 
 import (
+	"context"
 	"errors"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/octoclient"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/test"
 	"path/filepath"
@@ -31,13 +33,13 @@ func TestLifecyclesInvalidName(t *testing.T) {
 		}
 
 		check := NewOctopusInvalidLifecycleName(
-			newSpaceClient,
+			snapshot.NewOctopusClientSpaceSnapshot(newSpaceClient),
 			&config.OctolintConfig{
 				LifecycleNameRegex: "thiswontmatch",
 			},
 			checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute()
+		result, err := check.Execute(context.Background())
 
 		// Assert
 		if result == nil || result.Severity() != checks.Warning {
@@ -1,12 +1,12 @@
 package naming
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
 	"regexp"
 	"strings"
@@ -16,14 +16,14 @@ const OctoLintInvalidTargetRoles = "OctoLintInvalidTargetRoles"
 
 // OctopusInvalidTargetRole find targets that have not been healthy in the last 30 days.
 type OctopusInvalidTargetRole struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusInvalidTargetRole(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusInvalidTargetRole {
+func NewOctopusInvalidTargetRole(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusInvalidTargetRole {
 	return OctopusInvalidTargetRole{
-		client:       client,
+		snapshot:     snapshot,
 		errorHandler: errorHandler,
 		config:       config,
 	}
@@ -33,9 +33,9 @@ func (o OctopusInvalidTargetRole) Id() string {
 	return OctoLintInvalidTargetRoles
 }
 
-func (o OctopusInvalidTargetRole) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusInvalidTargetRole) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -54,18 +54,19 @@ func (o OctopusInvalidTargetRole) Execute() (checks.OctopusCheckResult, error) {
 		return checks.NewOctopusCheckResultImpl(
 			"The supplied regex "+o.config.TargetNameRegex+" does not compile",
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Error,
 			checks.Naming), nil
 	}
 
-	allMachines, err := client_wrapper.GetMachines(o.config.MaxInvalidRoleTargets, o.client, o.client.GetSpaceID())
+	allMachines, err := o.snapshot.GetMachines(ctx, o.config.MaxInvalidRoleTargets)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
 	}
 
 	responses := []string{}
+	findings := []checks.OctopusCheckFinding{}
 	for i, m := range allMachines {
 		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allMachines))*100) + "% complete")
 
@@ -78,6 +79,14 @@ func (o OctopusInvalidTargetRole) Execute() (checks.OctopusCheckResult, error) {
 
 		if len(invalidRoles) != 0 {
 			responses = append(responses, m.Name+" - "+strings.Join(invalidRoles, ","))
+			findings = append(findings, checks.OctopusCheckFinding{
+				ResourceType: checks.MachineResource,
+				ResourceId:   m.ID,
+				ResourceName: m.Name,
+				SpaceId:      m.SpaceID,
+				Message:      "The target roles do not match the regex " + o.config.TargetRoleRegex,
+				Evidence:     strings.Join(invalidRoles, ","),
+			})
 		}
 	}
 
@@ -85,15 +94,16 @@ func (o OctopusInvalidTargetRole) Execute() (checks.OctopusCheckResult, error) {
 		return checks.NewOctopusCheckResultImpl(
 			"The following target roles do not match the regex "+o.config.TargetRoleRegex+":\n"+strings.Join(responses, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Naming), nil
+			checks.Naming,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"All targets match the regex "+o.config.TargetNameRegex,
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Naming), nil
 }
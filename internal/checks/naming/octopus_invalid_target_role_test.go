@@ -1,10 +1,12 @@
 package naming
 
 import (
+	"context"
 	"errors"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/octoclient"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/test"
 	"path/filepath"
@@ -31,13 +33,13 @@ func TestInvalidTargetRole(t *testing.T) {
 		}
 
 		check := NewOctopusInvalidTargetRole(
-			newSpaceClient,
+			snapshot.NewOctopusClientSpaceSnapshot(newSpaceClient),
 			&config.OctolintConfig{
 				TargetRoleRegex: "thiswontmatch",
 			},
 			checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute()
+		result, err := check.Execute(context.Background())
 
 		if err != nil {
 			return err
@@ -1,37 +1,32 @@
 package naming
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
 	projects2 "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
 	"regexp"
 	"strings"
 )
 
-var linkOptions = regexp.MustCompile(`\{.*?}`)
-
 const OctoLintInvalidVariableNames = "OctoLintInvalidVariableNames"
 
 // OctopusInvalidVariableNameCheck checks to see if any project variables are unused.
 type OctopusInvalidVariableNameCheck struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusInvalidVariableNameCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusInvalidVariableNameCheck {
+func NewOctopusInvalidVariableNameCheck(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusInvalidVariableNameCheck {
 	return OctopusInvalidVariableNameCheck{
-		client:       client,
+		snapshot:     snapshot,
 		errorHandler: errorHandler,
 		config:       config,
 	}
@@ -41,9 +36,9 @@ func (o OctopusInvalidVariableNameCheck) Id() string {
 	return OctoLintInvalidVariableNames
 }
 
-func (o OctopusInvalidVariableNameCheck) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusInvalidVariableNameCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -52,7 +47,7 @@ func (o OctopusInvalidVariableNameCheck) Execute() (checks.OctopusCheckResult, e
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjects(o.config.MaxInvalidVariableProjects, o.client, o.client.GetSpaceID())
+	projects, err := o.snapshot.GetProjects(ctx, o.config.MaxInvalidVariableProjects)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
@@ -64,16 +59,17 @@ func (o OctopusInvalidVariableNameCheck) Execute() (checks.OctopusCheckResult, e
 		return checks.NewOctopusCheckResultImpl(
 			"The supplied regex "+o.config.VariableNameRegex+" does not compile",
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Error,
 			checks.Naming), nil
 	}
 
 	messages := []string{}
+	findings := []checks.OctopusCheckFinding{}
 	for i, p := range projects {
 		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
 
-		variableSet, err := o.client.Variables.GetAll(p.ID)
+		variableSet, err := o.snapshot.GetVariableSet(ctx, p.ID)
 
 		if err != nil {
 			if !o.errorHandler.ShouldContinue(err) {
@@ -89,6 +85,16 @@ func (o OctopusInvalidVariableNameCheck) Execute() (checks.OctopusCheckResult, e
 
 			if !regex.Match([]byte(v.Name)) {
 				messages = append(messages, p.Name+": "+v.Name)
+				findings = append(findings, checks.OctopusCheckFinding{
+					ResourceType: checks.VariableResource,
+					ResourceId:   v.ID,
+					ResourceName: v.Name,
+					ProjectId:    p.ID,
+					ProjectName:  p.Name,
+					SpaceId:      p.SpaceID,
+					Message:      "The variable name does not match the regex " + o.config.VariableNameRegex,
+					Evidence:     v.Name,
+				})
 			}
 
 		}
@@ -99,22 +105,23 @@ func (o OctopusInvalidVariableNameCheck) Execute() (checks.OctopusCheckResult, e
 		return checks.NewOctopusCheckResultImpl(
 			"The following variables do not match the regex "+o.config.VariableNameRegex+":\n"+strings.Join(messages, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Naming), nil
+			checks.Naming,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no unused variables",
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Naming), nil
 }
 
-func (o OctopusInvalidVariableNameCheck) getDeploymentSteps(p *projects2.Project) ([]*deployments.DeploymentStep, error) {
+func (o OctopusInvalidVariableNameCheck) getDeploymentSteps(ctx context.Context, p *projects2.Project) ([]*deployments.DeploymentStep, error) {
 	deploymentProcesses := []*deployments.DeploymentStep{}
-	deploymentProcess, err := o.client.DeploymentProcesses.GetByID(p.DeploymentProcessID)
+	deploymentProcess, err := o.snapshot.GetDeploymentProcess(ctx, p.DeploymentProcessID)
 
 	if err != nil {
 		if !o.errorHandler.ShouldContinue(err) {
@@ -126,27 +133,25 @@ func (o OctopusInvalidVariableNameCheck) getDeploymentSteps(p *projects2.Project
 		}
 	}
 
-	if link, ok := p.Links["Runbooks"]; ok {
-		runbooks, err := newclient.Get[resources.Resources[runbooks.Runbook]](o.client.HttpSession(), linkOptions.ReplaceAllString(link, ""))
+	runbooks, err := o.snapshot.GetProjectRunbooks(ctx, p)
+
+	if err != nil {
+		if !o.errorHandler.ShouldContinue(err) {
+			return nil, err
+		}
+	}
+
+	for _, runbook := range runbooks {
+		runbookProcess, err := o.snapshot.GetRunbookProcess(ctx, runbook.RunbookProcessID)
 
 		if err != nil {
 			if !o.errorHandler.ShouldContinue(err) {
 				return nil, err
 			}
-		}
-
-		for _, runbook := range runbooks.Items {
-			runbookProcess, err := o.client.RunbookProcesses.GetByID(runbook.RunbookProcessID)
-
-			if err != nil {
-				if !o.errorHandler.ShouldContinue(err) {
-					return nil, err
-				}
-				continue
-			} else {
-				if runbookProcess != nil && runbookProcess.Steps != nil {
-					deploymentProcesses = append(deploymentProcesses, runbookProcess.Steps...)
-				}
+			continue
+		} else {
+			if runbookProcess != nil && runbookProcess.Steps != nil {
+				deploymentProcesses = append(deploymentProcesses, runbookProcess.Steps...)
 			}
 		}
 	}
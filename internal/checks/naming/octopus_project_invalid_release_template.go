@@ -1,14 +1,14 @@
 package naming
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
 	"regexp"
 	"strings"
@@ -18,14 +18,14 @@ const OctoLintProjectReleaseTemplate = "OctoLintProjectReleaseTemplate"
 
 // OctopusProjectReleaseTemplateRegex checks to see if any project has too many steps.
 type OctopusProjectReleaseTemplateRegex struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusProjectReleaseTemplateRegex(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusProjectReleaseTemplateRegex {
+func NewOctopusProjectReleaseTemplateRegex(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusProjectReleaseTemplateRegex {
 	return OctopusProjectReleaseTemplateRegex{
-		client:       client,
+		snapshot:     snapshot,
 		errorHandler: errorHandler,
 		config:       config,
 	}
@@ -35,9 +35,9 @@ func (o OctopusProjectReleaseTemplateRegex) Id() string {
 	return OctoLintProjectReleaseTemplate
 }
 
-func (o OctopusProjectReleaseTemplateRegex) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusProjectReleaseTemplateRegex) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -57,23 +57,34 @@ func (o OctopusProjectReleaseTemplateRegex) Execute() (checks.OctopusCheckResult
 		return checks.NewOctopusCheckResultImpl(
 			"The supplied regex "+o.config.ProjectReleaseTemplateRegex+" does not compile",
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Error,
 			checks.Naming), nil
 	}
 
-	projects, err := client_wrapper.GetProjects(o.config.MaxInvalidReleaseTemplateProjects, o.client, o.client.GetSpaceID())
+	projects, err := o.snapshot.GetProjects(ctx, o.config.MaxInvalidReleaseTemplateProjects)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
 	}
 
 	results := []string{}
+	findings := []checks.OctopusCheckFinding{}
 	for i, p := range projects {
 		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
 
 		if p.VersioningStrategy != nil && !regex.Match([]byte(p.VersioningStrategy.Template)) {
 			results = append(results, p.Name+" - "+p.VersioningStrategy.Template)
+			findings = append(findings, checks.OctopusCheckFinding{
+				ResourceType: checks.ProjectResource,
+				ResourceId:   p.ID,
+				ResourceName: p.Name,
+				ProjectId:    p.ID,
+				ProjectName:  p.Name,
+				SpaceId:      p.SpaceID,
+				Message:      "The project release template does not match the regex " + o.config.ProjectReleaseTemplateRegex,
+				Evidence:     p.VersioningStrategy.Template,
+			})
 		}
 	}
 
@@ -81,25 +92,26 @@ func (o OctopusProjectReleaseTemplateRegex) Execute() (checks.OctopusCheckResult
 		return checks.NewOctopusCheckResultImpl(
 			"The following project release templates do not match the regex "+o.config.ProjectReleaseTemplateRegex+":\n"+strings.Join(results, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Naming), nil
+			checks.Naming,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"All projects match the release templates regex "+o.config.ProjectReleaseTemplateRegex,
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Naming), nil
 }
 
-func (o OctopusProjectReleaseTemplateRegex) stepsInDeploymentProcess(deploymentProcessID string) (*deployments.DeploymentProcess, error) {
+func (o OctopusProjectReleaseTemplateRegex) stepsInDeploymentProcess(ctx context.Context, deploymentProcessID string) (*deployments.DeploymentProcess, error) {
 	if deploymentProcessID == "" {
 		return nil, nil
 	}
 
-	resource, err := o.client.DeploymentProcesses.GetByID(deploymentProcessID)
+	resource, err := o.snapshot.GetDeploymentProcess(ctx, deploymentProcessID)
 
 	if err != nil {
 		// If we can't find the deployment process, assume zero steps
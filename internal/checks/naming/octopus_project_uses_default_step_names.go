@@ -1,14 +1,14 @@
 package naming
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
 	"strings"
@@ -18,14 +18,14 @@ const OctoLintProjectDefaultStepNames = "OctoLintProjectDefaultStepNames"
 
 // OctopusProjectDefaultStepNames checks to see if any project has too many steps.
 type OctopusProjectDefaultStepNames struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusProjectDefaultStepNames(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusProjectDefaultStepNames {
+func NewOctopusProjectDefaultStepNames(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusProjectDefaultStepNames {
 	return OctopusProjectDefaultStepNames{
-		client:       client,
+		snapshot:     snapshot,
 		errorHandler: errorHandler,
 		config:       config,
 	}
@@ -35,9 +35,9 @@ func (o OctopusProjectDefaultStepNames) Id() string {
 	return OctoLintProjectDefaultStepNames
 }
 
-func (o OctopusProjectDefaultStepNames) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusProjectDefaultStepNames) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -46,17 +46,18 @@ func (o OctopusProjectDefaultStepNames) Execute() (checks.OctopusCheckResult, er
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjects(o.config.MaxDefaultStepNameProjects, o.client, o.client.GetSpaceID())
+	projects, err := o.snapshot.GetProjects(ctx, o.config.MaxDefaultStepNameProjects)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
 	}
 
 	actionsWithDefaultNames := []string{}
+	findings := []checks.OctopusCheckFinding{}
 	for i, p := range projects {
 		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
 
-		deploymentProcess, err := o.stepsInDeploymentProcess(p.DeploymentProcessID)
+		deploymentProcess, err := o.stepsInDeploymentProcess(ctx, p.DeploymentProcessID)
 
 		if err != nil {
 			if !o.errorHandler.ShouldContinue(err) {
@@ -73,6 +74,16 @@ func (o OctopusProjectDefaultStepNames) Execute() (checks.OctopusCheckResult, er
 			for _, a := range s.Actions {
 				if slices.Index(checks.DefaultStepNames, a.Name) != -1 {
 					actionsWithDefaultNames = append(actionsWithDefaultNames, p.Name+"/"+a.Name)
+					findings = append(findings, checks.OctopusCheckFinding{
+						ResourceType: checks.StepResource,
+						ResourceId:   a.ID,
+						ResourceName: a.Name,
+						ProjectId:    p.ID,
+						ProjectName:  p.Name,
+						SpaceId:      p.SpaceID,
+						Message:      "The step uses a default step name",
+						Evidence:     a.Name,
+					})
 				}
 			}
 		}
@@ -83,25 +94,26 @@ func (o OctopusProjectDefaultStepNames) Execute() (checks.OctopusCheckResult, er
 		return checks.NewOctopusCheckResultImpl(
 			"The following project actions use the default step names:\n"+strings.Join(actionsWithDefaultNames, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no project actions default step names",
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
 
-func (o OctopusProjectDefaultStepNames) stepsInDeploymentProcess(deploymentProcessID string) (*deployments.DeploymentProcess, error) {
+func (o OctopusProjectDefaultStepNames) stepsInDeploymentProcess(ctx context.Context, deploymentProcessID string) (*deployments.DeploymentProcess, error) {
 	if deploymentProcessID == "" {
 		return nil, nil
 	}
 
-	resource, err := o.client.DeploymentProcesses.GetByID(deploymentProcessID)
+	resource, err := o.snapshot.GetDeploymentProcess(ctx, deploymentProcessID)
 
 	if err != nil {
 		// If we can't find the deployment process, assume zero steps
@@ -1,10 +1,12 @@
 package naming
 
 import (
+	"context"
 	"errors"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/octoclient"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/test"
 	"path/filepath"
@@ -31,11 +33,11 @@ func TestDefaultStepNames(t *testing.T) {
 		}
 
 		check := NewOctopusProjectDefaultStepNames(
-			newSpaceClient,
+			snapshot.NewOctopusClientSpaceSnapshot(newSpaceClient),
 			&config.OctolintConfig{},
 			checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute()
+		result, err := check.Execute(context.Background())
 
 		if err != nil {
 			return err
@@ -46,6 +48,10 @@ func TestDefaultStepNames(t *testing.T) {
 			return errors.New("check should have produced a warning")
 		}
 
+		if len(result.Findings()) == 0 || result.Findings()[0].ResourceType != checks.StepResource {
+			return errors.New("check should have reported the steps as findings")
+		}
+
 		return nil
 	})
 }
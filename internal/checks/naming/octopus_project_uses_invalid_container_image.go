@@ -1,14 +1,14 @@
 package naming
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
 	"regexp"
 	"strings"
@@ -18,14 +18,14 @@ const OctoLintContainerImageName = "OctoLintProjectContainerImageName"
 
 // OctopusProjectContainerImageRegex checks to see if any project has too many steps.
 type OctopusProjectContainerImageRegex struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusProjectContainerImageRegex(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusProjectContainerImageRegex {
+func NewOctopusProjectContainerImageRegex(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusProjectContainerImageRegex {
 	return OctopusProjectContainerImageRegex{
-		client:       client,
+		snapshot:     snapshot,
 		errorHandler: errorHandler,
 		config:       config,
 	}
@@ -35,9 +35,9 @@ func (o OctopusProjectContainerImageRegex) Id() string {
 	return OctoLintContainerImageName
 }
 
-func (o OctopusProjectContainerImageRegex) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusProjectContainerImageRegex) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -57,22 +57,23 @@ func (o OctopusProjectContainerImageRegex) Execute() (checks.OctopusCheckResult,
 		return checks.NewOctopusCheckResultImpl(
 			"The supplied regex "+o.config.ContainerImageRegex+" does not compile",
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Error,
 			checks.Naming), nil
 	}
 
-	projects, err := client_wrapper.GetProjects(o.config.MaxInvalidContainerImageProjects, o.client, o.client.GetSpaceID())
+	projects, err := o.snapshot.GetProjects(ctx, o.config.MaxInvalidContainerImageProjects)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
 	}
 
 	actionsWithInvalidImages := []string{}
+	findings := []checks.OctopusCheckFinding{}
 	for i, p := range projects {
 		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
 
-		deploymentProcess, err := o.stepsInDeploymentProcess(p.DeploymentProcessID)
+		deploymentProcess, err := o.stepsInDeploymentProcess(ctx, p.DeploymentProcessID)
 
 		if err != nil {
 			if !o.errorHandler.ShouldContinue(err) {
@@ -93,6 +94,16 @@ func (o OctopusProjectContainerImageRegex) Execute() (checks.OctopusCheckResult,
 
 				if !regex.Match([]byte(a.Container.Image)) {
 					actionsWithInvalidImages = append(actionsWithInvalidImages, p.Name+"/"+a.Name+": "+a.Container.Image)
+					findings = append(findings, checks.OctopusCheckFinding{
+						ResourceType: checks.StepResource,
+						ResourceId:   a.ID,
+						ResourceName: a.Name,
+						ProjectId:    p.ID,
+						ProjectName:  p.Name,
+						SpaceId:      p.SpaceID,
+						Message:      "The step container image does not match the regex " + o.config.ContainerImageRegex,
+						Evidence:     a.Container.Image,
+					})
 				}
 			}
 		}
@@ -103,25 +114,26 @@ func (o OctopusProjectContainerImageRegex) Execute() (checks.OctopusCheckResult,
 		return checks.NewOctopusCheckResultImpl(
 			"The following project actions do not match the regex "+o.config.ContainerImageRegex+":\n"+strings.Join(actionsWithInvalidImages, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no project actions with invalid container images",
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
 
-func (o OctopusProjectContainerImageRegex) stepsInDeploymentProcess(deploymentProcessID string) (*deployments.DeploymentProcess, error) {
+func (o OctopusProjectContainerImageRegex) stepsInDeploymentProcess(ctx context.Context, deploymentProcessID string) (*deployments.DeploymentProcess, error) {
 	if deploymentProcessID == "" {
 		return nil, nil
 	}
 
-	resource, err := o.client.DeploymentProcesses.GetByID(deploymentProcessID)
+	resource, err := o.snapshot.GetDeploymentProcess(ctx, deploymentProcessID)
 
 	if err != nil {
 		// If we can't find the deployment process, assume zero steps
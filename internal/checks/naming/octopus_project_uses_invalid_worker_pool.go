@@ -1,15 +1,15 @@
 package naming
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
 	"regexp"
@@ -20,14 +20,14 @@ const OctoLintProjectWorkerPool = "OctoLintProjectWorkerPool"
 
 // OctopusProjectWorkerPoolRegex checks to see if any project has too many steps.
 type OctopusProjectWorkerPoolRegex struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusProjectWorkerPoolRegex(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusProjectWorkerPoolRegex {
+func NewOctopusProjectWorkerPoolRegex(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusProjectWorkerPoolRegex {
 	return OctopusProjectWorkerPoolRegex{
-		client:       client,
+		snapshot:     snapshot,
 		errorHandler: errorHandler,
 		config:       config,
 	}
@@ -37,9 +37,9 @@ func (o OctopusProjectWorkerPoolRegex) Id() string {
 	return OctoLintProjectWorkerPool
 }
 
-func (o OctopusProjectWorkerPoolRegex) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusProjectWorkerPoolRegex) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -59,18 +59,18 @@ func (o OctopusProjectWorkerPoolRegex) Execute() (checks.OctopusCheckResult, err
 		return checks.NewOctopusCheckResultImpl(
 			"The supplied regex "+o.config.ProjectStepWorkerPoolRegex+" does not compile",
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Error,
 			checks.Naming), nil
 	}
 
-	projects, err := client_wrapper.GetProjects(o.config.MaxInvalidWorkerPoolProjects, o.client, o.client.GetSpaceID())
+	projects, err := o.snapshot.GetProjects(ctx, o.config.MaxInvalidWorkerPoolProjects)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
 	}
 
-	workerPools, err := o.client.WorkerPools.GetAll()
+	workerPools, err := o.snapshot.GetWorkerPools(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
@@ -86,10 +86,11 @@ func (o OctopusProjectWorkerPoolRegex) Execute() (checks.OctopusCheckResult, err
 	}
 
 	actionsWithInvalidWorkerPools := []string{}
+	findings := []checks.OctopusCheckFinding{}
 	for i, p := range projects {
 		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
 
-		deploymentProcess, err := o.stepsInDeploymentProcess(p.DeploymentProcessID)
+		deploymentProcess, err := o.stepsInDeploymentProcess(ctx, p.DeploymentProcessID)
 
 		if err != nil {
 			if !o.errorHandler.ShouldContinue(err) {
@@ -113,6 +114,16 @@ func (o OctopusProjectWorkerPoolRegex) Execute() (checks.OctopusCheckResult, err
 					if defaultWorkerPool != "" && !regex.Match([]byte(defaultWorkerPool)) {
 
 						actionsWithInvalidWorkerPools = append(actionsWithInvalidWorkerPools, p.Name+"/"+a.Name+": "+defaultWorkerPool+" (default)")
+						findings = append(findings, checks.OctopusCheckFinding{
+							ResourceType: checks.StepResource,
+							ResourceId:   a.ID,
+							ResourceName: a.Name,
+							ProjectId:    p.ID,
+							ProjectName:  p.Name,
+							SpaceId:      p.SpaceID,
+							Message:      "The step uses a default worker pool that does not match the regex " + o.config.ProjectStepWorkerPoolRegex,
+							Evidence:     defaultWorkerPool,
+						})
 					}
 				} else if !regex.Match([]byte(a.WorkerPool)) {
 					workerPool := lo.Filter(workerPools, func(item *workerpools.WorkerPoolListResult, index int) bool {
@@ -121,6 +132,16 @@ func (o OctopusProjectWorkerPoolRegex) Execute() (checks.OctopusCheckResult, err
 
 					if len(workerPool) == 1 && !regex.Match([]byte(workerPool[0].Name)) {
 						actionsWithInvalidWorkerPools = append(actionsWithInvalidWorkerPools, p.Name+"/"+a.Name+": "+workerPool[0].Name)
+						findings = append(findings, checks.OctopusCheckFinding{
+							ResourceType: checks.StepResource,
+							ResourceId:   a.ID,
+							ResourceName: a.Name,
+							ProjectId:    p.ID,
+							ProjectName:  p.Name,
+							SpaceId:      p.SpaceID,
+							Message:      "The step uses a worker pool that does not match the regex " + o.config.ProjectStepWorkerPoolRegex,
+							Evidence:     workerPool[0].Name,
+						})
 					}
 				}
 			}
@@ -132,25 +153,26 @@ func (o OctopusProjectWorkerPoolRegex) Execute() (checks.OctopusCheckResult, err
 		return checks.NewOctopusCheckResultImpl(
 			"The following project actions use worker pools that do not match the regex "+o.config.ContainerImageRegex+":\n"+strings.Join(actionsWithInvalidWorkerPools, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no actions that use worker pools that do not match the regex "+o.config.ContainerImageRegex,
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
 
-func (o OctopusProjectWorkerPoolRegex) stepsInDeploymentProcess(deploymentProcessID string) (*deployments.DeploymentProcess, error) {
+func (o OctopusProjectWorkerPoolRegex) stepsInDeploymentProcess(ctx context.Context, deploymentProcessID string) (*deployments.DeploymentProcess, error) {
 	if deploymentProcessID == "" {
 		return nil, nil
 	}
 
-	resource, err := o.client.DeploymentProcesses.GetByID(deploymentProcessID)
+	resource, err := o.snapshot.GetDeploymentProcess(ctx, deploymentProcessID)
 
 	if err != nil {
 		// If we can't find the deployment process, assume zero steps
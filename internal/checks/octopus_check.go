@@ -1,9 +1,19 @@
 package checks
 
+import "context"
+
 // OctopusCheck defines the contract for each lint check
 type OctopusCheck interface {
-	// Execute runs the check
-	Execute() (OctopusCheckResult, error)
+	// Execute runs the check. The check stops reading from the space and returns the context error when the context is
+	// done.
+	Execute(ctx context.Context) (OctopusCheckResult, error)
 	// Id returns the unique ID of the check, used to cross-reference with documentation
 	Id() string
 }
+
+// OctopusCountedCheck is implemented by checks that count the resources they scanned
+type OctopusCountedCheck interface {
+	OctopusCheck
+	// ResourcesScanned returns the number of resources the check has read from the space
+	ResourcesScanned() int
+}
@@ -0,0 +1,119 @@
+package checks
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	ProjectResource      string = "Project"
+	ProjectGroupResource        = "ProjectGroup"
+	VariableResource            = "Variable"
+	StepResource                = "Step"
+	EnvironmentResource         = "Environment"
+	LifecycleResource           = "Lifecycle"
+	MachineResource             = "Machine"
+	TenantResource              = "Tenant"
+	AccountResource             = "Account"
+	CertificateResource         = "Certificate"
+	FeedResource                = "Feed"
+	UserResource                = "User"
+	ApiKeyResource              = "ApiKey"
+	SubscriptionResource        = "Subscription"
+	DeploymentResource          = "Deployment"
+	SpaceResource               = "Space"
+)
+
+// OctopusCheckFinding describes an individual resource that was flagged by a check. A check result can contain
+// many findings, allowing reporters and other tooling to sort, filter, and count individual issues.
+type OctopusCheckFinding struct {
+	// ResourceType is the kind of resource that was flagged, e.g. Project or Variable
+	ResourceType string `json:"resourceType"`
+	// ResourceId is the ID of the flagged resource, if the resource has an ID
+	ResourceId string `json:"resourceId,omitempty"`
+	// ResourceName is the name of the flagged resource
+	ResourceName string `json:"resourceName"`
+	// ProjectId is the ID of the project that owns the resource, if any
+	ProjectId string `json:"projectId,omitempty"`
+	// ProjectName is the name of the project that owns the resource, if any
+	ProjectName string `json:"projectName,omitempty"`
+	// UserId is the ID of the user that owns the resource, if any, like the owner of an API key
+	UserId string `json:"userId,omitempty"`
+	// SpaceId is the ID of the space the resource belongs to
+	SpaceId string `json:"spaceId,omitempty"`
+	// Message is a human readable description of the issue with this resource
+	Message string `json:"message"`
+	// Evidence captures the value that caused the resource to be flagged, like a step name or an insecure URL
+	Evidence string `json:"evidence,omitempty"`
+	// Url is a link to the resource in the Octopus web UI, if the resource has a page
+	Url string `json:"url,omitempty"`
+}
+
+// Fingerprint returns a stable identifier for a finding reported by a check. It is based on the check and the resource
+// that was flagged, rather than the description, so it does not change when the wording of a message changes. The space
+// is included because resource IDs are only unique within a space. The resource name is only used for resources without
+// an ID.
+func Fingerprint(checkId string, finding OctopusCheckFinding) string {
+	resource := finding.ResourceId
+	if resource == "" {
+		resource = finding.ResourceName
+	}
+
+	hash := sha256.Sum256([]byte(strings.Join([]string{checkId, finding.SpaceId, finding.ResourceType, finding.ProjectId, resource}, "\x00")))
+	return hex.EncodeToString(hash[:])
+}
+
+// WithoutFindings returns a copy of the result without the findings matched by the remove function, and the number of
+// findings that were removed. A result whose findings are all removed is reported as passing. Otherwise the description
+// is rebuilt from its first line and the remaining findings, as the original description lists the removed resources
+// too. The reason completes the sentence "issues that have been ...".
+func WithoutFindings(result OctopusCheckResult, remove func(finding OctopusCheckFinding) bool, reason string) (OctopusCheckResult, int) {
+	if len(result.Findings()) == 0 || result.Category() == GeneralError {
+		return result, 0
+	}
+
+	remaining := []OctopusCheckFinding{}
+	for _, f := range result.Findings() {
+		if !remove(f) {
+			remaining = append(remaining, f)
+		}
+	}
+
+	removed := len(result.Findings()) - len(remaining)
+
+	if removed == 0 {
+		return result, 0
+	}
+
+	if len(remaining) == 0 {
+		return NewOctopusCheckResultImpl(
+			"All "+fmt.Sprint(removed)+" issues reported by this check have been "+reason,
+			result.Code(),
+			result.Link(),
+			Ok,
+			result.Category()), removed
+	}
+
+	header, _, _ := strings.Cut(result.Description(), "\n")
+
+	lines := []string{header}
+	for _, f := range remaining {
+		if f.ProjectName != "" {
+			lines = append(lines, f.ProjectName+": "+f.ResourceName)
+		} else {
+			lines = append(lines, f.ResourceName)
+		}
+	}
+
+	lines = append(lines, fmt.Sprint(removed)+" issue(s) that have been "+reason+" are not shown")
+
+	return NewOctopusCheckResultImpl(
+		strings.Join(lines, "\n"),
+		result.Code(),
+		result.Link(),
+		result.Severity(),
+		result.Category(),
+		remaining...), removed
+}
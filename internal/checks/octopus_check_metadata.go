@@ -0,0 +1,33 @@
+package checks
+
+// DocumentationUrl is the base URL of the check documentation.
+const DocumentationUrl = "https://github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/wiki"
+
+// DocumentationLink returns the URL of the page documenting the check.
+func DocumentationLink(checkId string) string {
+	return DocumentationUrl + "/" + checkId
+}
+
+// OctopusCheckMetadata describes a check independently of any space it is run against. It is used to list and explain
+// the checks, and to validate check IDs supplied by the user.
+type OctopusCheckMetadata struct {
+	// Id is the unique ID of the check, matching OctopusCheck.Id()
+	Id string
+	// Category is the category of the results reported by the check, e.g. Security
+	Category string
+	// DefaultSeverity is the severity of the results reported when the check finds an issue
+	DefaultSeverity int
+	// Description is a one line summary of what the check looks for
+	Description string
+	// Rationale explains why the issue is worth fixing
+	Rationale string
+	// Remediation describes how to fix the issue
+	Remediation string
+	// DocumentationUrl links to the documentation for the check
+	DocumentationUrl string
+	// ConfigKeys lists the configuration keys read by the check
+	ConfigKeys []string
+	// InstanceScoped is true if the check only reads resources shared by every space, like users and their API keys, so
+	// it is run once when many spaces are scanned rather than reporting the same issues in every space
+	InstanceScoped bool
+}
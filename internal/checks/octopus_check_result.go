@@ -1,5 +1,10 @@
 package checks
 
+import (
+	"errors"
+	"strings"
+)
+
 const (
 	Error      int = 20
 	Warning        = 15
@@ -17,6 +22,40 @@ const (
 	GeneralError        = "GeneralError"
 )
 
+// SeverityName returns a display name for a result severity.
+func SeverityName(severity int) string {
+	switch {
+	case severity >= Error:
+		return "Error"
+	case severity >= Warning:
+		return "Warning"
+	case severity >= Info:
+		return "Info"
+	case severity >= Permission:
+		return "Permission"
+	default:
+		return "Ok"
+	}
+}
+
+// ParseSeverity converts a severity name, like "warning", to a severity. The name is case insensitive.
+func ParseSeverity(name string) (int, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "error":
+		return Error, nil
+	case "warning":
+		return Warning, nil
+	case "info":
+		return Info, nil
+	case "permission":
+		return Permission, nil
+	case "ok":
+		return Ok, nil
+	default:
+		return 0, errors.New("unknown severity \"" + name + "\". The supported severities are error, warning, info, permission, and ok")
+	}
+}
+
 // OctopusCheckResult describes the result of an OctopusCheck
 type OctopusCheckResult interface {
 	Description() string
@@ -24,6 +63,8 @@ type OctopusCheckResult interface {
 	Link() string
 	Severity() int
 	Category() string
+	// Findings returns the individual resources that were flagged by the check
+	Findings() []OctopusCheckFinding
 }
 
 type OctopusCheckResultImpl struct {
@@ -32,15 +73,17 @@ type OctopusCheckResultImpl struct {
 	link        string
 	severity    int
 	category    string
+	findings    []OctopusCheckFinding
 }
 
-func NewOctopusCheckResultImpl(description string, code string, link string, severity int, category string) OctopusCheckResultImpl {
+func NewOctopusCheckResultImpl(description string, code string, link string, severity int, category string, findings ...OctopusCheckFinding) OctopusCheckResultImpl {
 	return OctopusCheckResultImpl{
 		description: description,
 		code:        code,
 		link:        link,
 		severity:    severity,
 		category:    category,
+		findings:    findings,
 	}
 }
 
@@ -63,3 +106,10 @@ func (o OctopusCheckResultImpl) Severity() int {
 func (o OctopusCheckResultImpl) Category() string {
 	return o.category
 }
+
+func (o OctopusCheckResultImpl) Findings() []OctopusCheckFinding {
+	if o.findings == nil {
+		return []OctopusCheckFinding{}
+	}
+	return o.findings
+}
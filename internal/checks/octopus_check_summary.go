@@ -0,0 +1,83 @@
+package checks
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OctopusCheckSummary counts the results of a scan by severity. Checks that failed to run are counted separately from
+// the issues reported by the checks that completed.
+type OctopusCheckSummary struct {
+	Errors     int `json:"errors"`
+	Warnings   int `json:"warnings"`
+	Info       int `json:"info"`
+	Permission int `json:"permission"`
+	Ok         int `json:"ok"`
+	Failed     int `json:"failed"`
+}
+
+func NewOctopusCheckSummary(results []OctopusCheckResult) OctopusCheckSummary {
+	summary := OctopusCheckSummary{}
+
+	for _, r := range results {
+		if r.Category() == GeneralError {
+			summary.Failed++
+			continue
+		}
+
+		switch {
+		case r.Severity() >= Error:
+			summary.Errors++
+		case r.Severity() >= Warning:
+			summary.Warnings++
+		case r.Severity() >= Info:
+			summary.Info++
+		case r.Severity() >= Permission:
+			summary.Permission++
+		default:
+			summary.Ok++
+		}
+	}
+
+	return summary
+}
+
+// IssuesAtOrAbove returns the number of issues with a severity equal to or higher than the supplied severity.
+func (o OctopusCheckSummary) IssuesAtOrAbove(severity int) int {
+	count := 0
+
+	if severity <= Error {
+		count += o.Errors
+	}
+
+	if severity <= Warning {
+		count += o.Warnings
+	}
+
+	if severity <= Info {
+		count += o.Info
+	}
+
+	return count
+}
+
+func (o OctopusCheckSummary) String() string {
+	return "Summary: " + o.Counts()
+}
+
+// Counts lists the number of results with each severity.
+func (o OctopusCheckSummary) Counts() string {
+	counts := []string{
+		fmt.Sprint(o.Errors) + " error(s)",
+		fmt.Sprint(o.Warnings) + " warning(s)",
+		fmt.Sprint(o.Info) + " info",
+		fmt.Sprint(o.Permission) + " permission error(s)",
+		fmt.Sprint(o.Ok) + " passed",
+	}
+
+	if o.Failed != 0 {
+		counts = append(counts, fmt.Sprint(o.Failed)+" failed to run")
+	}
+
+	return strings.Join(counts, ", ")
+}
@@ -0,0 +1,27 @@
+package checks
+
+import "testing"
+
+func TestSummaryCountsBySeverity(t *testing.T) {
+	summary := NewOctopusCheckSummary([]OctopusCheckResult{
+		NewOctopusCheckResultImpl("Error", "OctoRecError", "", Error, Security),
+		NewOctopusCheckResultImpl("Warning", "OctoRecWarning1", "", Warning, Organization),
+		NewOctopusCheckResultImpl("Warning", "OctoRecWarning2", "", Warning, Organization),
+		NewOctopusCheckResultImpl("Info", "OctoRecInfo", "", Info, Naming),
+		NewOctopusCheckResultImpl("Permission", "OctoRecPermission", "", Permission, Organization),
+		NewOctopusCheckResultImpl("Ok", "OctoRecOk", "", Ok, Organization),
+		NewOctopusCheckResultImpl("The check failed to run", "OctoRecFailed", "", Error, GeneralError),
+	})
+
+	if summary.Errors != 1 || summary.Warnings != 2 || summary.Info != 1 || summary.Permission != 1 || summary.Ok != 1 {
+		t.Fatalf("Unexpected summary: %+v", summary)
+	}
+
+	if summary.Failed != 1 {
+		t.Fatal("Should have counted the check that failed to run separately")
+	}
+
+	if summary.IssuesAtOrAbove(Error) != 1 || summary.IssuesAtOrAbove(Warning) != 3 || summary.IssuesAtOrAbove(Info) != 4 {
+		t.Fatal("Should have counted the issues at or above each severity")
+	}
+}
@@ -1,6 +1,8 @@
 package checks
 
 import (
+	"context"
+	"errors"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
 	"net/http"
 	"strings"
@@ -16,7 +18,7 @@ func (o OctopusClientPermissiveErrorHandler) HandleError(id string, group string
 		return NewOctopusCheckResultImpl(
 			"You do not have permission to run the check: "+err.Error(),
 			id,
-			"",
+			DocumentationLink(id),
 			Permission,
 			group), nil
 	}
@@ -25,8 +27,18 @@ func (o OctopusClientPermissiveErrorHandler) HandleError(id string, group string
 
 // ShouldContinue is used to determine if an error was a permissions error. Things like 404s are also treated
 // as permission errors (we saw this a lot trying to get deployment processes). Interestingly we also saw a lot of
-// StatusCode's set to 0, so this function also reads the error to work out what is going on.
+// StatusCode's set to 0, so this function also reads the error to work out what is going on. Transient errors, like
+// dropped connections and timeouts, are not permission errors, and are returned to the executor so the check is retried.
+// Errors returned because the check timed out or the scan was cancelled stop the check.
 func (o OctopusClientPermissiveErrorHandler) ShouldContinue(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if IsTransientError(err) {
+		return false
+	}
+
 	apiError, ok := err.(*core.APIError)
 	if ok {
 		return apiError.StatusCode == http.StatusUnauthorized ||
@@ -0,0 +1,283 @@
+package checks
+
+import (
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/defaults"
+	"math"
+	"sort"
+	"strings"
+)
+
+// maxScoreContributors is the number of checks listed as the top contributors to a health score
+const maxScoreContributors = 5
+
+// ScoredCategories lists the categories that are given a health score, in the order they are reported.
+var ScoredCategories = []string{Organization, Naming, Security, Performance, Optimization}
+
+// OctopusScoreWeights sets how much the issues found by each check lower the health score.
+type OctopusScoreWeights struct {
+	// Severities maps the Error, Warning, and Info severities to the weight of an issue with that severity
+	Severities map[int]float64
+	// Checks maps check IDs to a multiplier applied to the weight of their issues. Checks that are not listed have a
+	// multiplier of 1, and checks with a multiplier of 0 are left out of the score.
+	Checks map[string]float64
+}
+
+// DefaultScoreWeights returns the weights used when none are configured.
+func DefaultScoreWeights() OctopusScoreWeights {
+	return OctopusScoreWeights{
+		Severities: map[int]float64{
+			Error:   defaults.ScoreErrorWeight,
+			Warning: defaults.ScoreWarningWeight,
+			Info:    defaults.ScoreInfoWeight,
+		},
+		Checks: map[string]float64{},
+	}
+}
+
+// OctopusHealthScore rates a space from 0 to 100, where 100 means no checks found an issue. Each check that ran can
+// lower the score by up to its weight multiplied by the highest severity weight. The issues of a check lower the score
+// by the weight of their severity, multiplied by the fraction of the resources read by the check that were affected, so
+// a single issue in a large space costs less than the same issue in a small space.
+type OctopusHealthScore struct {
+	Score float64 `json:"score"`
+	Grade string  `json:"grade"`
+	// Checks is the number of checks that contributed to the score. Checks that failed to run or did not have
+	// permission to read the space are not scored.
+	Checks          int                       `json:"checks"`
+	Categories      []OctopusCategoryScore    `json:"categories"`
+	TopContributors []OctopusScoreContributor `json:"topContributors"`
+}
+
+// OctopusCategoryScore is the health score of the checks in a single category.
+type OctopusCategoryScore struct {
+	Category string  `json:"category"`
+	Score    float64 `json:"score"`
+	Grade    string  `json:"grade"`
+	Checks   int     `json:"checks"`
+}
+
+// OctopusScoreContributor is a check that lowered the health score.
+type OctopusScoreContributor struct {
+	CheckId  string `json:"checkId"`
+	Category string `json:"category"`
+	Severity string `json:"severity"`
+	// Affected is the number of resources with an issue. A result that does not list resources counts as one.
+	Affected int `json:"affected"`
+	// Scanned is the number of resources read by the check, or 0 if the number is not known
+	Scanned int `json:"scanned"`
+	// Points is the amount the check lowered the score of the space
+	Points float64 `json:"points"`
+}
+
+// scoredCheck is the penalty of a single check, and the highest penalty it could have had
+type scoredCheck struct {
+	contributor OctopusScoreContributor
+	penalty     float64
+	maxPenalty  float64
+}
+
+// NewOctopusHealthScore calculates the health score of a space. The default weights are used if no severity weights
+// are supplied.
+func NewOctopusHealthScore(space OctopusSpaceResults, weights OctopusScoreWeights) OctopusHealthScore {
+	if len(weights.Severities) == 0 {
+		weights.Severities = DefaultScoreWeights().Severities
+	}
+
+	maxSeverityWeight := 0.0
+	for _, weight := range weights.Severities {
+		maxSeverityWeight = max(maxSeverityWeight, weight)
+	}
+
+	scored := []scoredCheck{}
+	for _, r := range space.Results {
+		checkWeight, found := weights.Checks[r.Code()]
+		if !found {
+			checkWeight = 1
+		}
+
+		if r.Category() == GeneralError || r.Severity() == Permission || checkWeight <= 0 {
+			continue
+		}
+
+		check := scoredCheck{
+			contributor: OctopusScoreContributor{
+				CheckId:  r.Code(),
+				Category: r.Category(),
+				Severity: SeverityName(r.Severity()),
+				Scanned:  space.ResourcesScanned[r.Code()],
+			},
+			maxPenalty: checkWeight * maxSeverityWeight,
+		}
+
+		if r.Severity() >= Info {
+			check.contributor.Affected = max(len(r.Findings()), 1)
+			fraction := 1.0
+			if !affectsWholeSpace(r) {
+				fraction = affectedFraction(check.contributor)
+			}
+			check.penalty = checkWeight * weights.Severities[severityLevel(r.Severity())] * fraction
+		}
+
+		scored = append(scored, check)
+	}
+
+	healthScore := OctopusHealthScore{
+		Checks:          len(scored),
+		Categories:      []OctopusCategoryScore{},
+		TopContributors: []OctopusScoreContributor{},
+	}
+	healthScore.Score, healthScore.Grade = score(scored)
+
+	for _, category := range ScoredCategories {
+		inCategory := []scoredCheck{}
+		for _, check := range scored {
+			if check.contributor.Category == category {
+				inCategory = append(inCategory, check)
+			}
+		}
+
+		if len(inCategory) == 0 {
+			continue
+		}
+
+		categoryScore := OctopusCategoryScore{Category: category, Checks: len(inCategory)}
+		categoryScore.Score, categoryScore.Grade = score(inCategory)
+		healthScore.Categories = append(healthScore.Categories, categoryScore)
+	}
+
+	totalMaxPenalty := 0.0
+	for _, check := range scored {
+		totalMaxPenalty += check.maxPenalty
+	}
+
+	for _, check := range scored {
+		if check.penalty > 0 && totalMaxPenalty > 0 {
+			check.contributor.Points = round(100 * check.penalty / totalMaxPenalty)
+			healthScore.TopContributors = append(healthScore.TopContributors, check.contributor)
+		}
+	}
+
+	sort.SliceStable(healthScore.TopContributors, func(i, j int) bool {
+		return healthScore.TopContributors[i].Points > healthScore.TopContributors[j].Points
+	})
+
+	if len(healthScore.TopContributors) > maxScoreContributors {
+		healthScore.TopContributors = healthScore.TopContributors[:maxScoreContributors]
+	}
+
+	return healthScore
+}
+
+// Scored returns true if at least one check contributed to the score.
+func (o OctopusHealthScore) Scored() bool {
+	return o.Checks != 0
+}
+
+func (o OctopusHealthScore) String() string {
+	if !o.Scored() {
+		return "Health score: not available, as no checks could be scored"
+	}
+
+	return "Health score: " + FormatScore(o.Score) + "/100 (" + o.Grade + ")"
+}
+
+// CategoryScores lists the score of each category, like "Security 90 (A)".
+func (o OctopusHealthScore) CategoryScores() string {
+	scores := []string{}
+	for _, category := range o.Categories {
+		scores = append(scores, category.Category+" "+FormatScore(category.Score)+" ("+category.Grade+")")
+	}
+	return strings.Join(scores, ", ")
+}
+
+// Describe summarises the contribution of a check, like "OctoLintUnusedVariables (Warning, 12 of 300 resources): -1.2".
+func (o OctopusScoreContributor) Describe() string {
+	resources := fmt.Sprint(o.Affected) + " resource(s)"
+	if o.Scanned != 0 {
+		resources = fmt.Sprint(o.Affected) + " of " + fmt.Sprint(o.Scanned) + " resources"
+	}
+
+	return o.CheckId + " (" + o.Severity + ", " + resources + "): -" + FormatScore(o.Points)
+}
+
+// FormatScore prints a score without a trailing decimal place when it is a whole number.
+func FormatScore(score float64) string {
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", score), ".0")
+}
+
+// Grade converts a score to a letter grade, from A for a score of 90 or more to F for a score below 60.
+func Grade(score float64) string {
+	switch {
+	case score >= 90:
+		return "A"
+	case score >= 80:
+		return "B"
+	case score >= 70:
+		return "C"
+	case score >= 60:
+		return "D"
+	default:
+		return "F"
+	}
+}
+
+func score(scored []scoredCheck) (float64, string) {
+	penalty := 0.0
+	maxPenalty := 0.0
+
+	for _, check := range scored {
+		penalty += check.penalty
+		maxPenalty += check.maxPenalty
+	}
+
+	if maxPenalty == 0 {
+		return 100, Grade(100)
+	}
+
+	value := round(100 * (1 - penalty/maxPenalty))
+	return value, Grade(value)
+}
+
+// affectedFraction is the fraction of the resources read by the check that have an issue. Checks that did not report
+// the number of resources they read, or that report issues about more resources than they read, are treated as if
+// every resource was affected.
+func affectedFraction(contributor OctopusScoreContributor) float64 {
+	if contributor.Scanned <= contributor.Affected {
+		return 1
+	}
+
+	return float64(contributor.Affected) / float64(contributor.Scanned)
+}
+
+// affectsWholeSpace returns true if the result reports an issue with the space rather than individual resources, like
+// a space with too many environments. These results are not scaled by the number of resources the check read.
+func affectsWholeSpace(result OctopusCheckResult) bool {
+	if len(result.Findings()) == 0 {
+		return true
+	}
+
+	for _, finding := range result.Findings() {
+		if finding.ResourceType == SpaceResource || finding.ResourceType == "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// severityLevel maps a severity to the Error, Warning, or Info level that sets its weight.
+func severityLevel(severity int) int {
+	switch {
+	case severity >= Error:
+		return Error
+	case severity >= Warning:
+		return Warning
+	default:
+		return Info
+	}
+}
+
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,78 @@
+package checks
+
+import "testing"
+
+func scoredSpace() OctopusSpaceResults {
+	return OctopusSpaceResults{
+		SpaceId: "Spaces-1",
+		Results: []OctopusCheckResult{
+			NewOctopusCheckResultImpl("Error", "OctoRecError", "", Error, Security),
+			NewOctopusCheckResultImpl("Warning", "OctoRecWarning", "", Warning, Organization,
+				OctopusCheckFinding{ResourceType: ProjectResource, ResourceId: "Projects-1"},
+				OctopusCheckFinding{ResourceType: ProjectResource, ResourceId: "Projects-2"}),
+			NewOctopusCheckResultImpl("Ok", "OctoRecOk", "", Ok, Organization),
+			NewOctopusCheckResultImpl("Permission", "OctoRecPermission", "", Permission, Naming),
+			NewOctopusCheckResultImpl("The check failed to run", "OctoRecFailed", "", Error, GeneralError),
+		},
+		ResourcesScanned: map[string]int{"OctoRecWarning": 100},
+	}
+}
+
+func TestHealthScore(t *testing.T) {
+	score := NewOctopusHealthScore(scoredSpace(), OctopusScoreWeights{})
+
+	// The error costs its full weight, while the warning only affected 2 of the 100 resources read by the check
+	if score.Score != 66.3 || score.Grade != "D" || score.Checks != 3 {
+		t.Fatalf("Unexpected score: %+v", score)
+	}
+
+	if len(score.Categories) != 2 || score.Categories[0].Category != Organization || score.Categories[0].Score != 99.5 ||
+		score.Categories[1].Category != Security || score.Categories[1].Score != 0 || score.Categories[1].Grade != "F" {
+		t.Fatalf("Should have scored the categories with checks that ran: %+v", score.Categories)
+	}
+
+	if len(score.TopContributors) != 2 || score.TopContributors[0].CheckId != "OctoRecError" || score.TopContributors[0].Points != 33.3 ||
+		score.TopContributors[1].Affected != 2 || score.TopContributors[1].Scanned != 100 {
+		t.Fatalf("Should have listed the checks that lowered the score, starting with the largest: %+v", score.TopContributors)
+	}
+}
+
+func TestHealthScoreWeights(t *testing.T) {
+	weights := DefaultScoreWeights()
+	weights.Checks["OctoRecError"] = 0
+
+	score := NewOctopusHealthScore(scoredSpace(), weights)
+
+	if score.Score != 99.5 || score.Checks != 2 || len(score.TopContributors) != 1 {
+		t.Fatalf("Should have left the check with a weight of 0 out of the score: %+v", score)
+	}
+}
+
+func TestHealthScoreWithoutScoredChecks(t *testing.T) {
+	score := NewOctopusHealthScore(OctopusSpaceResults{Results: []OctopusCheckResult{
+		NewOctopusCheckResultImpl("Permission", "OctoRecPermission", "", Permission, Naming),
+	}}, OctopusScoreWeights{})
+
+	if score.Scored() || score.String() != "Health score: not available, as no checks could be scored" {
+		t.Fatal("Should not have scored a space where no checks ran")
+	}
+}
+
+func TestHealthScoreForSpaceLevelIssues(t *testing.T) {
+	for _, result := range []OctopusCheckResult{
+		NewOctopusCheckResultImpl("Too many environments", "OctoRecSpace", "", Warning, Organization,
+			OctopusCheckFinding{ResourceType: SpaceResource, ResourceId: "Spaces-1"}),
+		NewOctopusCheckResultImpl("Too many environments", "OctoRecSpace", "", Warning, Organization),
+	} {
+		score := NewOctopusHealthScore(OctopusSpaceResults{
+			SpaceId:          "Spaces-1",
+			Results:          []OctopusCheckResult{result},
+			ResourcesScanned: map[string]int{"OctoRecSpace": 30},
+		}, OctopusScoreWeights{})
+
+		// The warning costs its full weight, rather than 1 of the 30 resources read by the check
+		if score.Score != 50 {
+			t.Fatalf("Should have treated the issue as affecting the whole space: %+v", score)
+		}
+	}
+}
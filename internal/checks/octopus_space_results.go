@@ -0,0 +1,33 @@
+package checks
+
+import "time"
+
+// OctopusSpaceResults holds the results of the checks run against a single space.
+type OctopusSpaceResults struct {
+	SpaceId   string
+	SpaceName string
+	Results   []OctopusCheckResult
+	// Durations is the time each check took to run, keyed by the check ID
+	Durations map[string]time.Duration
+	// ResourcesScanned is the number of resources each check read from the space, keyed by the check ID
+	ResourcesScanned map[string]int
+	// ProjectRepositories maps the ID of each version controlled project to the repository holding its OCL files. It is
+	// used to link findings to the files, and to the default branch in the web UI.
+	ProjectRepositories map[string]OctopusProjectRepository
+}
+
+// OctopusProjectRepository is the location of the OCL files of a version controlled project.
+type OctopusProjectRepository struct {
+	Url           string
+	BasePath      string
+	DefaultBranch string
+}
+
+// AllResults returns the results of every space in a single slice.
+func AllResults(spaces []OctopusSpaceResults) []OctopusCheckResult {
+	results := []OctopusCheckResult{}
+	for _, space := range spaces {
+		results = append(results, space.Results...)
+	}
+	return results
+}
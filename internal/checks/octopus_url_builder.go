@@ -0,0 +1,170 @@
+package checks
+
+import (
+	"net/url"
+	"strings"
+)
+
+// OctopusUrlBuilder builds links to resources in the Octopus web UI.
+type OctopusUrlBuilder struct {
+	serverUrl    string
+	spaceId      string
+	repositories map[string]OctopusProjectRepository
+}
+
+func NewOctopusUrlBuilder(serverUrl string, spaceId string) OctopusUrlBuilder {
+	return OctopusUrlBuilder{serverUrl: strings.TrimSuffix(serverUrl, "/"), spaceId: spaceId}
+}
+
+// WithProjectRepositories returns a builder that links the process and variables of version controlled projects to
+// their default branch. The repositories are keyed by project ID.
+func (o OctopusUrlBuilder) WithProjectRepositories(repositories map[string]OctopusProjectRepository) OctopusUrlBuilder {
+	o.repositories = repositories
+	return o
+}
+
+// WithFindingUrls returns the results with a link to the resource of each finding that does not already have one.
+func (o OctopusUrlBuilder) WithFindingUrls(results []OctopusCheckResult) []OctopusCheckResult {
+	linked := []OctopusCheckResult{}
+
+	for _, r := range results {
+		findings := r.Findings()
+		updated := false
+
+		for i, f := range findings {
+			if f.Url != "" {
+				continue
+			}
+
+			if url := o.FindingUrl(f); url != "" {
+				if !updated {
+					// The findings are copied so the original result is left unchanged
+					findings = append([]OctopusCheckFinding{}, findings...)
+					updated = true
+				}
+				findings[i].Url = url
+			}
+		}
+
+		if updated {
+			r = NewOctopusCheckResultImpl(r.Description(), r.Code(), r.Link(), r.Severity(), r.Category(), findings...)
+		}
+
+		linked = append(linked, r)
+	}
+
+	return linked
+}
+
+// DeploymentUrl returns a link to a deployment of a release.
+func (o OctopusUrlBuilder) DeploymentUrl(projectId string, releaseId string, deploymentId string) string {
+	return o.spaceUrl("projects", projectId, "deployments", "releases", releaseId, "deployments", deploymentId)
+}
+
+// FindingUrl returns a link to the resource flagged by a finding, or an empty string if the resource has no page in the
+// web UI. The space of the finding is used if it is set, otherwise the space of the builder is used.
+func (o OctopusUrlBuilder) FindingUrl(finding OctopusCheckFinding) string {
+	if finding.Url != "" {
+		return finding.Url
+	}
+
+	builder := o
+	if finding.SpaceId != "" {
+		builder.spaceId = finding.SpaceId
+	}
+
+	switch finding.ResourceType {
+	case ProjectResource:
+		if finding.ProjectId == "" {
+			return builder.spaceUrl("projects", finding.ResourceId)
+		}
+		return builder.spaceUrl("projects", finding.ProjectId)
+	case StepResource:
+		if branch := builder.defaultBranch(finding.ProjectId); branch != "" {
+			return builder.spaceUrl("projects", finding.ProjectId, "branches", branch, "deployments", "process")
+		}
+		return builder.spaceUrl("projects", finding.ProjectId, "deployments", "process")
+	case VariableResource:
+		if branch := builder.defaultBranch(finding.ProjectId); branch != "" {
+			return builder.spaceUrl("projects", finding.ProjectId, "branches", branch, "variables")
+		}
+		if finding.ProjectId != "" {
+			return builder.spaceUrl("projects", finding.ProjectId, "variables")
+		}
+		return builder.spaceUrl("library", "variables")
+	case ProjectGroupResource:
+		return builder.spaceUrl("projectGroups", finding.ResourceId)
+	case EnvironmentResource:
+		return builder.spaceUrl("infrastructure", "environments", finding.ResourceId)
+	case LifecycleResource:
+		return builder.spaceUrl("library", "lifecycles", finding.ResourceId)
+	case MachineResource:
+		if strings.HasPrefix(finding.ResourceId, "Workers-") {
+			return builder.spaceUrl("infrastructure", "workers", finding.ResourceId, "settings")
+		}
+		return builder.spaceUrl("infrastructure", "machines", finding.ResourceId, "settings")
+	case TenantResource:
+		return builder.spaceUrl("tenants", finding.ResourceId, "overview")
+	case AccountResource:
+		return builder.spaceUrl("infrastructure", "accounts", finding.ResourceId)
+	case CertificateResource:
+		return builder.spaceUrl("library", "certificates", finding.ResourceId)
+	case FeedResource:
+		return builder.spaceUrl("library", "feeds", finding.ResourceId, "edit")
+	case SubscriptionResource:
+		return builder.spaceUrl("configuration", "subscriptions", finding.ResourceId)
+	case DeploymentResource:
+		return builder.spaceUrl("deployments", finding.ResourceId)
+	case SpaceResource:
+		return builder.instanceUrl(finding.ResourceId)
+	case UserResource:
+		return builder.instanceUrl("configuration", "users", finding.ResourceId)
+	case ApiKeyResource:
+		// API keys are listed on the page of the user that owns them
+		return builder.instanceUrl("configuration", "users", finding.UserId)
+	default:
+		return ""
+	}
+}
+
+// defaultBranch returns the full name of the default branch of a version controlled project, or an empty string if the
+// project is saved in the database. The web UI identifies branches by their full name, like refs/heads/main.
+func (o OctopusUrlBuilder) defaultBranch(projectId string) string {
+	repository, found := o.repositories[projectId]
+
+	if projectId == "" || !found || repository.DefaultBranch == "" {
+		return ""
+	}
+
+	if strings.HasPrefix(repository.DefaultBranch, "refs/") {
+		return repository.DefaultBranch
+	}
+
+	return "refs/heads/" + repository.DefaultBranch
+}
+
+// spaceUrl returns a link to a page in the space, or an empty string if any part of the path is missing.
+func (o OctopusUrlBuilder) spaceUrl(path ...string) string {
+	if o.spaceId == "" {
+		return ""
+	}
+
+	return o.instanceUrl(append([]string{o.spaceId}, path...)...)
+}
+
+// instanceUrl returns a link to a page that is not in a space, or an empty string if any part of the path is missing.
+func (o OctopusUrlBuilder) instanceUrl(path ...string) string {
+	if o.serverUrl == "" {
+		return ""
+	}
+
+	escaped := []string{}
+	for _, p := range path {
+		if p == "" {
+			return ""
+		}
+		escaped = append(escaped, url.PathEscape(p))
+	}
+
+	return o.serverUrl + "/app#/" + strings.Join(escaped, "/")
+}
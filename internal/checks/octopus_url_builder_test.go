@@ -0,0 +1,73 @@
+package checks
+
+import "testing"
+
+func TestFindingUrl(t *testing.T) {
+	builder := NewOctopusUrlBuilder("https://example.octopus.app/", "Spaces-1")
+
+	urls := map[string]OctopusCheckFinding{
+		"https://example.octopus.app/app#/Spaces-1/projects/Projects-1":                         {ResourceType: ProjectResource, ResourceId: "Projects-1"},
+		"https://example.octopus.app/app#/Spaces-1/projects/Projects-1/deployments/process":     {ResourceType: StepResource, ResourceName: "Deploy", ProjectId: "Projects-1"},
+		"https://example.octopus.app/app#/Spaces-1/projects/Projects-1/variables":               {ResourceType: VariableResource, ResourceId: "Variables-1", ProjectId: "Projects-1"},
+		"https://example.octopus.app/app#/Spaces-2/infrastructure/machines/Machines-1/settings": {ResourceType: MachineResource, ResourceId: "Machines-1", SpaceId: "Spaces-2"},
+		"https://example.octopus.app/app#/Spaces-3":                                             {ResourceType: SpaceResource, ResourceId: "Spaces-3"},
+		"https://example.octopus.app/app#/configuration/users/Users-1":                          {ResourceType: UserResource, ResourceId: "Users-1"},
+		"https://example.octopus.app/app#/configuration/users/Users-2":                          {ResourceType: ApiKeyResource, ResourceId: "APIKeys-2", UserId: "Users-2"},
+		"": {ResourceType: ApiKeyResource, ResourceId: "APIKeys-1"},
+	}
+
+	for expected, finding := range urls {
+		if actual := builder.FindingUrl(finding); actual != expected {
+			t.Fatalf("Expected %q for %+v, but got %q", expected, finding, actual)
+		}
+	}
+
+	if NewOctopusUrlBuilder("", "Spaces-1").FindingUrl(OctopusCheckFinding{ResourceType: ProjectResource, ResourceId: "Projects-1"}) != "" {
+		t.Fatal("Should not have built a link without the server URL")
+	}
+}
+
+func TestFindingUrlForVersionControlledProjects(t *testing.T) {
+	builder := NewOctopusUrlBuilder("https://example.octopus.app", "Spaces-1").WithProjectRepositories(map[string]OctopusProjectRepository{
+		"Projects-1": {Url: "https://github.com/example/repo.git", BasePath: ".octopus", DefaultBranch: "main"},
+	})
+
+	urls := map[string]OctopusCheckFinding{
+		"https://example.octopus.app/app#/Spaces-1/projects/Projects-1/branches/refs%2Fheads%2Fmain/deployments/process": {ResourceType: StepResource, ResourceName: "Deploy", ProjectId: "Projects-1"},
+		"https://example.octopus.app/app#/Spaces-1/projects/Projects-1/branches/refs%2Fheads%2Fmain/variables":           {ResourceType: VariableResource, ResourceId: "Variables-1", ProjectId: "Projects-1"},
+		"https://example.octopus.app/app#/Spaces-1/projects/Projects-2/deployments/process":                              {ResourceType: StepResource, ResourceName: "Deploy", ProjectId: "Projects-2"},
+	}
+
+	for expected, finding := range urls {
+		if actual := builder.FindingUrl(finding); actual != expected {
+			t.Fatalf("Expected %q for %+v, but got %q", expected, finding, actual)
+		}
+	}
+}
+
+func TestWithFindingUrls(t *testing.T) {
+	builder := NewOctopusUrlBuilder("https://example.octopus.app", "Spaces-1")
+
+	result := NewOctopusCheckResultImpl("The following projects are empty", "OctoLintEmptyProject", DocumentationLink("OctoLintEmptyProject"), Warning, Organization,
+		OctopusCheckFinding{ResourceType: ProjectResource, ResourceId: "Projects-1"},
+		OctopusCheckFinding{ResourceType: DeploymentResource, ResourceId: "Deployments-1", Url: "https://example.octopus.app/app#/custom"},
+		OctopusCheckFinding{ResourceType: ApiKeyResource, ResourceId: "APIKeys-1"})
+
+	linked := builder.WithFindingUrls([]OctopusCheckResult{result})
+
+	if linked[0].Findings()[0].Url != "https://example.octopus.app/app#/Spaces-1/projects/Projects-1" {
+		t.Fatal("Should have linked the project")
+	}
+
+	if linked[0].Findings()[1].Url != "https://example.octopus.app/app#/custom" {
+		t.Fatal("Should have kept the link set by the check")
+	}
+
+	if linked[0].Findings()[2].Url != "" || result.Findings()[0].Url != "" {
+		t.Fatal("Should not have linked a resource without a page, or modified the original result")
+	}
+
+	if linked[0].Link() != DocumentationLink("OctoLintEmptyProject") {
+		t.Fatal("Should have kept the link to the documentation")
+	}
+}
@@ -1,36 +1,41 @@
 package organization
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projectgroups"
+	projects2 "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 )
 
+const OctoLintDefaultProjectGroupChildCount = "OctoLintDefaultProjectGroupChildCount"
+
 const maxProjectsInDefaultGroup = 10
 
 // OctopusDefaultProjectGroupCountCheck checks to see if the default project group contains too many projects. This is
 // usually an indication that additional projects groups should be created to organize the dashboard.
 type OctopusDefaultProjectGroupCountCheck struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusDefaultProjectGroupCountCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusDefaultProjectGroupCountCheck {
-	return OctopusDefaultProjectGroupCountCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusDefaultProjectGroupCountCheck(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusDefaultProjectGroupCountCheck {
+	return OctopusDefaultProjectGroupCountCheck{config: config, snapshot: snapshot, errorHandler: errorHandler}
 }
 
 func (o OctopusDefaultProjectGroupCountCheck) Id() string {
-	return "OctoLintDefaultProjectGroupChildCount"
+	return OctoLintDefaultProjectGroupChildCount
 }
 
-func (o OctopusDefaultProjectGroupCountCheck) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusDefaultProjectGroupCountCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -39,43 +44,61 @@ func (o OctopusDefaultProjectGroupCountCheck) Execute() (checks.OctopusCheckResu
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	resource, err := o.client.ProjectGroups.GetByName("Default Project Group")
+	projectGroups, err := o.snapshot.GetProjectGroups(ctx)
 
 	if err != nil {
-		apiError, ok := err.(*core.APIError)
-		if (ok && apiError.StatusCode == 404) || err.Error() == "cannot find the item" {
-			return checks.NewOctopusCheckResultImpl(
-				"The default project group was not found",
-				o.Id(),
-				"",
-				checks.Ok,
-				checks.Organization), nil
-		}
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
+	resource, found := lo.Find(projectGroups, func(item *projectgroups.ProjectGroup) bool {
+		return item.Name == "Default Project Group"
+	})
+
+	if !found {
+		return checks.NewOctopusCheckResultImpl(
+			"The default project group was not found",
+			o.Id(),
+			checks.DocumentationLink(o.Id()),
+			checks.Ok,
+			checks.Organization), nil
+	}
+
 	if resource != nil {
 
-		projects, err := o.client.ProjectGroups.GetProjects(resource)
+		allProjects, err := o.snapshot.GetProjects(ctx, 0)
 
 		if err != nil {
 			return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 		}
 
+		projects := lo.Filter(allProjects, func(item *projects2.Project, index int) bool {
+			return item.ProjectGroupID == resource.ID
+		})
+
 		if len(projects) > maxProjectsInDefaultGroup {
+			findings := []checks.OctopusCheckFinding{{
+				ResourceType: checks.ProjectGroupResource,
+				ResourceId:   resource.ID,
+				ResourceName: resource.Name,
+				SpaceId:      resource.SpaceID,
+				Message:      "The default project group contains " + fmt.Sprint(len(projects)) + " projects",
+				Evidence:     fmt.Sprint(len(projects)),
+			}}
+
 			return checks.NewOctopusCheckResultImpl(
 				"The default project group contains "+fmt.Sprint(len(projects))+" projects. You may want to organize these projects into additional project groups.",
 				o.Id(),
-				"",
+				checks.DocumentationLink(o.Id()),
 				checks.Warning,
-				checks.Organization), nil
+				checks.Organization,
+				findings...), nil
 		}
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"The number of projects in the default project group is OK",
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
@@ -1,11 +1,13 @@
 package organization
 
 import (
+	"context"
 	"errors"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/users"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/octoclient"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/test"
 	"path/filepath"
@@ -28,9 +30,9 @@ func TestNormalProjectCount(t *testing.T) {
 			return err
 		}
 
-		check := NewOctopusDefaultProjectGroupCountCheck(newSpaceClient, &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
+		check := NewOctopusDefaultProjectGroupCountCheck(snapshot.NewOctopusClientSpaceSnapshot(newSpaceClient), &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute()
+		result, err := check.Execute(context.Background())
 
 		if err != nil {
 			return err
@@ -61,9 +63,9 @@ func TestExcessiveProjectCount(t *testing.T) {
 			return err
 		}
 
-		check := NewOctopusDefaultProjectGroupCountCheck(newSpaceClient, &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
+		check := NewOctopusDefaultProjectGroupCountCheck(snapshot.NewOctopusClientSpaceSnapshot(newSpaceClient), &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute()
+		result, err := check.Execute(context.Background())
 
 		if err != nil {
 			return err
@@ -113,9 +115,9 @@ func TestExcessiveProjectCountWithPermissionsError(t *testing.T) {
 			return err
 		}
 
-		check := NewOctopusDefaultProjectGroupCountCheck(limitedClient, &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
+		check := NewOctopusDefaultProjectGroupCountCheck(snapshot.NewOctopusClientSpaceSnapshot(limitedClient), &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute()
+		result, err := check.Execute(context.Background())
 
 		if err != nil {
 			return err
@@ -1,14 +1,14 @@
 package organization
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	projects2 "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
 	"strconv"
@@ -27,22 +27,22 @@ type projectVar struct {
 // OctopusDuplicatedVariablesCheck checks for variables with the same value across projects. This may be an indication
 // that library variable sets should be used to capture shared values.
 type OctopusDuplicatedVariablesCheck struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusDuplicatedVariablesCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusDuplicatedVariablesCheck {
-	return OctopusDuplicatedVariablesCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusDuplicatedVariablesCheck(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusDuplicatedVariablesCheck {
+	return OctopusDuplicatedVariablesCheck{config: config, snapshot: snapshot, errorHandler: errorHandler}
 }
 
 func (o OctopusDuplicatedVariablesCheck) Id() string {
 	return OctoLintDuplicatedVariables
 }
 
-func (o OctopusDuplicatedVariablesCheck) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusDuplicatedVariablesCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -51,7 +51,7 @@ func (o OctopusDuplicatedVariablesCheck) Execute() (checks.OctopusCheckResult, e
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjects(o.config.MaxDuplicateVariableProjects, o.client, o.client.GetSpaceID())
+	projects, err := o.snapshot.GetProjects(ctx, o.config.MaxDuplicateVariableProjects)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
@@ -65,7 +65,7 @@ func (o OctopusDuplicatedVariablesCheck) Execute() (checks.OctopusCheckResult, e
 
 		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
 
-		variableSet, err := o.client.Variables.GetAll(p.ID)
+		variableSet, err := o.snapshot.GetVariableSet(ctx, p.ID)
 
 		if err != nil {
 			if !o.errorHandler.ShouldContinue(err) {
@@ -109,22 +109,34 @@ OuterLoop:
 
 	if len(duplicateVars) > 0 {
 		messages := []string{}
+		findings := []checks.OctopusCheckFinding{}
 		for _, variable := range duplicateVars {
 			messages = append(messages, variable.project1.Name+"/"+variable.variable1.Name+" == "+variable.project2.Name+"/"+variable.variable2.Name)
+			findings = append(findings, checks.OctopusCheckFinding{
+				ResourceType: checks.VariableResource,
+				ResourceId:   variable.variable1.ID,
+				ResourceName: variable.variable1.Name,
+				ProjectId:    variable.project1.ID,
+				ProjectName:  variable.project1.Name,
+				SpaceId:      variable.project1.SpaceID,
+				Message:      "The variable has the same value as " + variable.project2.Name + "/" + variable.variable2.Name,
+				Evidence:     variable.project2.Name + "/" + variable.variable2.Name,
+			})
 		}
 
 		return checks.NewOctopusCheckResultImpl(
 			"The following variables are duplicated between projects. Consider moving these into library variable sets:\n"+strings.Join(messages, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no duplicated variables",
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
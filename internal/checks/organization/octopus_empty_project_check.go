@@ -1,13 +1,13 @@
 package organization
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
 	"strings"
 )
@@ -16,22 +16,22 @@ const OctoLintEmptyProject = "OctoLintEmptyProject"
 
 // OctopusEmptyProjectCheck checks for projects with no steps and no runbooks.
 type OctopusEmptyProjectCheck struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusEmptyProjectCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusEmptyProjectCheck {
-	return OctopusEmptyProjectCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusEmptyProjectCheck(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusEmptyProjectCheck {
+	return OctopusEmptyProjectCheck{config: config, snapshot: snapshot, errorHandler: errorHandler}
 }
 
 func (o OctopusEmptyProjectCheck) Id() string {
 	return OctoLintEmptyProject
 }
 
-func (o OctopusEmptyProjectCheck) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusEmptyProjectCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -40,19 +40,26 @@ func (o OctopusEmptyProjectCheck) Execute() (checks.OctopusCheckResult, error) {
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjects(o.config.MaxEmptyProjectCheckProjects, o.client, o.client.GetSpaceID())
+	projects, err := o.snapshot.GetProjects(ctx, o.config.MaxEmptyProjectCheckProjects)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	runbooks, err := o.client.Runbooks.GetAll()
+	runbooks, err := o.snapshot.GetRunbooks(ctx)
+
+	if err != nil {
+		if !o.errorHandler.ShouldContinue(err) {
+			return nil, err
+		}
+	}
 
 	emptyProjects := []string{}
+	findings := []checks.OctopusCheckFinding{}
 	for i, p := range projects {
 		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
 
-		stepCount, err := o.stepsInDeploymentProcess(p.DeploymentProcessID)
+		stepCount, err := o.stepsInDeploymentProcess(ctx, p.DeploymentProcessID)
 
 		if err != nil {
 			if !o.errorHandler.ShouldContinue(err) {
@@ -63,6 +70,15 @@ func (o OctopusEmptyProjectCheck) Execute() (checks.OctopusCheckResult, error) {
 
 		if runbooksInProject(p.ID, runbooks) == 0 && stepCount == 0 {
 			emptyProjects = append(emptyProjects, p.Name)
+			findings = append(findings, checks.OctopusCheckFinding{
+				ResourceType: checks.ProjectResource,
+				ResourceId:   p.ID,
+				ResourceName: p.Name,
+				ProjectId:    p.ID,
+				ProjectName:  p.Name,
+				SpaceId:      p.SpaceID,
+				Message:      "The project has no runbooks and no deployment process",
+			})
 		}
 	}
 
@@ -70,15 +86,16 @@ func (o OctopusEmptyProjectCheck) Execute() (checks.OctopusCheckResult, error) {
 		return checks.NewOctopusCheckResultImpl(
 			"The following projects have no runbooks and no deployment process:\n"+strings.Join(emptyProjects, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no empty projects",
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
@@ -93,12 +110,12 @@ func runbooksInProject(projectID string, runbooks []*runbooks.Runbook) int {
 	return count
 }
 
-func (o OctopusEmptyProjectCheck) stepsInDeploymentProcess(deploymentProcessID string) (int, error) {
+func (o OctopusEmptyProjectCheck) stepsInDeploymentProcess(ctx context.Context, deploymentProcessID string) (int, error) {
 	if deploymentProcessID == "" {
 		return 0, nil
 	}
 
-	resource, err := o.client.DeploymentProcesses.GetByID(deploymentProcessID)
+	resource, err := o.snapshot.GetDeploymentProcess(ctx, deploymentProcessID)
 
 	if err != nil {
 		return 0, err
@@ -1,10 +1,12 @@
 package organization
 
 import (
+	"context"
 	"errors"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/octoclient"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/test"
 	"path/filepath"
@@ -27,9 +29,9 @@ func TestPopulatedDeploymentProcess(t *testing.T) {
 			return err
 		}
 
-		check := NewOctopusEmptyProjectCheck(newSpaceClient, &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
+		check := NewOctopusEmptyProjectCheck(snapshot.NewOctopusClientSpaceSnapshot(newSpaceClient), &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute()
+		result, err := check.Execute(context.Background())
 
 		if err != nil {
 			return err
@@ -60,9 +62,9 @@ func TestEmptyDeploymentProcess(t *testing.T) {
 			return err
 		}
 
-		check := NewOctopusEmptyProjectCheck(newSpaceClient, &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
+		check := NewOctopusEmptyProjectCheck(snapshot.NewOctopusClientSpaceSnapshot(newSpaceClient), &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute()
+		result, err := check.Execute(context.Background())
 
 		if err != nil {
 			return err
@@ -1,35 +1,36 @@
 package organization
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
 )
 
 const OctopusEnvironmentCountCheckName = "OctoLintEnvironmentCount"
+const maxEnvironmentsQueried = 1000
 
 // OctopusEnvironmentCountCheck checks to see if too many environments have been created in a space.
 type OctopusEnvironmentCountCheck struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusEnvironmentCountCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusEnvironmentCountCheck {
-	return OctopusEnvironmentCountCheck{client: client, errorHandler: errorHandler, config: config}
+func NewOctopusEnvironmentCountCheck(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusEnvironmentCountCheck {
+	return OctopusEnvironmentCountCheck{snapshot: snapshot, errorHandler: errorHandler, config: config}
 }
 
 func (o OctopusEnvironmentCountCheck) Id() string {
 	return OctopusEnvironmentCountCheckName
 }
 
-func (o OctopusEnvironmentCountCheck) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusEnvironmentCountCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -38,24 +39,32 @@ func (o OctopusEnvironmentCountCheck) Execute() (checks.OctopusCheckResult, erro
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	query := environments.EnvironmentsQuery{
-		PartialName: "",
-		Skip:        0,
-		Take:        1000,
-	}
-	resources, err := o.client.Environments.Get(query)
+	resources, err := o.snapshot.GetEnvironments(ctx, maxEnvironmentsQueried)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	if len(resources.Items) > o.config.MaxEnvironments {
+	if len(resources) > o.config.MaxEnvironments {
+		// The number of environments is an issue with the space rather than any one environment, so a single finding
+		// is reported that does not change as environments are added or removed
+		spaceId := o.snapshot.GetSpaceID()
+		finding := checks.OctopusCheckFinding{
+			ResourceType: checks.SpaceResource,
+			ResourceId:   spaceId,
+			ResourceName: spaceId,
+			SpaceId:      spaceId,
+			Message:      "The space has " + fmt.Sprint(len(resources)) + " environments, more than the recommended maximum of " + fmt.Sprint(o.config.MaxEnvironments),
+			Evidence:     fmt.Sprint(len(resources)),
+		}
+
 		return checks.NewOctopusCheckResultImpl(
-			"The recommended maximum number of environments is "+fmt.Sprint(o.config.MaxEnvironments)+". You have at least "+fmt.Sprint(len(resources.Items)),
+			"The recommended maximum number of environments is "+fmt.Sprint(o.config.MaxEnvironments)+". You have at least "+fmt.Sprint(len(resources)),
 			o.Id(),
 			"https://octopus.com/docs/getting-started/best-practices/environments-and-deployment-targets-and-roles#environments",
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			finding), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
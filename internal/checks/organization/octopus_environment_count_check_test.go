@@ -1,10 +1,12 @@
 package organization
 
 import (
+	"context"
 	"errors"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/octoclient"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/test"
 	"path/filepath"
@@ -27,9 +29,9 @@ func TestNormalEnvironmentCount(t *testing.T) {
 			return err
 		}
 
-		check := NewOctopusEnvironmentCountCheck(newSpaceClient, &config.OctolintConfig{MaxEnvironments: 10}, checks.OctopusClientPermissiveErrorHandler{})
+		check := NewOctopusEnvironmentCountCheck(snapshot.NewOctopusClientSpaceSnapshot(newSpaceClient), &config.OctolintConfig{MaxEnvironments: 10}, checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute()
+		result, err := check.Execute(context.Background())
 
 		if err != nil {
 			return err
@@ -60,9 +62,9 @@ func TestExcessiveEnvironmentCount(t *testing.T) {
 			return err
 		}
 
-		check := NewOctopusEnvironmentCountCheck(newSpaceClient, &config.OctolintConfig{MaxEnvironments: 10}, checks.OctopusClientPermissiveErrorHandler{})
+		check := NewOctopusEnvironmentCountCheck(snapshot.NewOctopusClientSpaceSnapshot(newSpaceClient), &config.OctolintConfig{MaxEnvironments: 10}, checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute()
+		result, err := check.Execute(context.Background())
 
 		if err != nil {
 			return err
@@ -73,6 +75,10 @@ func TestExcessiveEnvironmentCount(t *testing.T) {
 			return errors.New("Check should have produced a warning")
 		}
 
+		if len(result.Findings()) != 1 || result.Findings()[0].ResourceType != checks.SpaceResource || result.Findings()[0].ResourceId != newSpaceId {
+			return errors.New("Check should have produced a single finding for the space")
+		}
+
 		return nil
 	})
 }
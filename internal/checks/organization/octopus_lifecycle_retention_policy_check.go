@@ -1,33 +1,36 @@
 package organization
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
 	"strings"
 )
 
+const OctoRecLifecycleRetention = "OctoRecLifecycleRetention"
+
 type OctopusLifecycleRetentionPolicyCheck struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusLifecycleRetentionPolicyCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusLifecycleRetentionPolicyCheck {
-	return OctopusLifecycleRetentionPolicyCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusLifecycleRetentionPolicyCheck(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusLifecycleRetentionPolicyCheck {
+	return OctopusLifecycleRetentionPolicyCheck{config: config, snapshot: snapshot, errorHandler: errorHandler}
 }
 
 func (o OctopusLifecycleRetentionPolicyCheck) Id() string {
-	return "OctoRecLifecycleRetention"
+	return OctoRecLifecycleRetention
 }
 
-func (o OctopusLifecycleRetentionPolicyCheck) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusLifecycleRetentionPolicyCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -36,13 +39,14 @@ func (o OctopusLifecycleRetentionPolicyCheck) Execute() (checks.OctopusCheckResu
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	lifecycles, err := o.client.Lifecycles.GetAll()
+	lifecycles, err := o.snapshot.GetLifecycles(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
 	keepsForever := []string{}
+	findings := []checks.OctopusCheckFinding{}
 	for i, l := range lifecycles {
 		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(lifecycles))*100) + "% complete")
 
@@ -59,6 +63,13 @@ func (o OctopusLifecycleRetentionPolicyCheck) Execute() (checks.OctopusCheckResu
 
 		if lifecycleKeepsForever || phaseKeepsForever {
 			keepsForever = append(keepsForever, l.Name)
+			findings = append(findings, checks.OctopusCheckFinding{
+				ResourceType: checks.LifecycleResource,
+				ResourceId:   l.ID,
+				ResourceName: l.Name,
+				SpaceId:      l.SpaceID,
+				Message:      "The lifecycle has a retention policy that keeps releases or files forever",
+			})
 		}
 	}
 
@@ -66,15 +77,16 @@ func (o OctopusLifecycleRetentionPolicyCheck) Execute() (checks.OctopusCheckResu
 		return checks.NewOctopusCheckResultImpl(
 			"The following lifecycles have retention policies that keep releases or files forever:\n"+strings.Join(keepsForever, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no lifecycles with retention policies that keep releases or files forever",
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
@@ -1,10 +1,12 @@
 package organization
 
 import (
+	"context"
 	"errors"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/octoclient"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/test"
 	"path/filepath"
@@ -47,9 +49,9 @@ func TestLifecyclesMeetRecommendations(t *testing.T) {
 			return err
 		}
 
-		check := NewOctopusLifecycleRetentionPolicyCheck(newSpaceClient, &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
+		check := NewOctopusLifecycleRetentionPolicyCheck(snapshot.NewOctopusClientSpaceSnapshot(newSpaceClient), &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute()
+		result, err := check.Execute(context.Background())
 
 		// Assert
 		if result.Severity() != checks.Ok {
@@ -81,9 +83,9 @@ func TestLifecycleKeepsReleasesForever(t *testing.T) {
 			return err
 		}
 
-		check := NewOctopusLifecycleRetentionPolicyCheck(newSpaceClient, &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
+		check := NewOctopusLifecycleRetentionPolicyCheck(snapshot.NewOctopusClientSpaceSnapshot(newSpaceClient), &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute()
+		result, err := check.Execute(context.Background())
 
 		if err != nil {
 			return err
@@ -119,9 +121,9 @@ func TestLifecycleKeepsFilesForever(t *testing.T) {
 			return err
 		}
 
-		check := NewOctopusLifecycleRetentionPolicyCheck(newSpaceClient, &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
+		check := NewOctopusLifecycleRetentionPolicyCheck(snapshot.NewOctopusClientSpaceSnapshot(newSpaceClient), &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute()
+		result, err := check.Execute(context.Background())
 
 		if err != nil {
 			return err
@@ -157,9 +159,9 @@ func TestLifecyclePhaseKeepsReleasesForever(t *testing.T) {
 			return err
 		}
 
-		check := NewOctopusLifecycleRetentionPolicyCheck(newSpaceClient, &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
+		check := NewOctopusLifecycleRetentionPolicyCheck(snapshot.NewOctopusClientSpaceSnapshot(newSpaceClient), &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute()
+		result, err := check.Execute(context.Background())
 
 		if err != nil {
 			return err
@@ -195,9 +197,9 @@ func TestLifecyclePhaseKeepsFilesForever(t *testing.T) {
 			return err
 		}
 
-		check := NewOctopusLifecycleRetentionPolicyCheck(newSpaceClient, &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
+		check := NewOctopusLifecycleRetentionPolicyCheck(snapshot.NewOctopusClientSpaceSnapshot(newSpaceClient), &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute()
+		result, err := check.Execute(context.Background())
 
 		if err != nil {
 			return err
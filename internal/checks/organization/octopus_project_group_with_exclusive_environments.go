@@ -1,13 +1,13 @@
 package organization
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
 	"strings"
@@ -17,22 +17,22 @@ const OctoLintProjectGroupsWithExclusiveEnvironments = "OctoLintProjectGroupsWit
 
 // OctopusProjectGroupsWithExclusiveEnvironmentsCheck checks to see if the project groups contain projects that have mutually exclusive environments.
 type OctopusProjectGroupsWithExclusiveEnvironmentsCheck struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusProjectGroupsWithExclusiveEnvironmentsCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusProjectGroupsWithExclusiveEnvironmentsCheck {
-	return OctopusProjectGroupsWithExclusiveEnvironmentsCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusProjectGroupsWithExclusiveEnvironmentsCheck(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusProjectGroupsWithExclusiveEnvironmentsCheck {
+	return OctopusProjectGroupsWithExclusiveEnvironmentsCheck{config: config, snapshot: snapshot, errorHandler: errorHandler}
 }
 
 func (o OctopusProjectGroupsWithExclusiveEnvironmentsCheck) Id() string {
 	return OctoLintProjectGroupsWithExclusiveEnvironments
 }
 
-func (o OctopusProjectGroupsWithExclusiveEnvironmentsCheck) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusProjectGroupsWithExclusiveEnvironmentsCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -41,25 +41,26 @@ func (o OctopusProjectGroupsWithExclusiveEnvironmentsCheck) Execute() (checks.Oc
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	allProjectGroups, err := o.client.ProjectGroups.GetAll()
+	allProjectGroups, err := o.snapshot.GetProjectGroups(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allProjects, err := client_wrapper.GetProjects(o.config.MaxExclusiveEnvironmentsProjects, o.client, o.client.GetSpaceID())
+	allProjects, err := o.snapshot.GetProjects(ctx, o.config.MaxExclusiveEnvironmentsProjects)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allLifecycles, err := o.client.Lifecycles.GetAll()
+	allLifecycles, err := o.snapshot.GetLifecycles(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
 	projectGroupsWithExclusiveEnvs := []string{}
+	findings := []checks.OctopusCheckFinding{}
 	for i, pg := range allProjectGroups {
 		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allProjectGroups))*100) + "% complete")
 
@@ -94,6 +95,13 @@ func (o OctopusProjectGroupsWithExclusiveEnvironmentsCheck) Execute() (checks.Oc
 			// if none of the environments from this lifecycle are found in any other lifecycles, we have an project with exclusive environments
 			if allExclusive && slices.Index(projectGroupsWithExclusiveEnvs, pg.Name) == -1 {
 				projectGroupsWithExclusiveEnvs = append(projectGroupsWithExclusiveEnvs, pg.Name)
+				findings = append(findings, checks.OctopusCheckFinding{
+					ResourceType: checks.ProjectGroupResource,
+					ResourceId:   pg.ID,
+					ResourceName: pg.Name,
+					SpaceId:      pg.SpaceID,
+					Message:      "The project group contains projects with mutually exclusive environments in their default lifecycle",
+				})
 			}
 		}
 	}
@@ -102,15 +110,16 @@ func (o OctopusProjectGroupsWithExclusiveEnvironmentsCheck) Execute() (checks.Oc
 		return checks.NewOctopusCheckResultImpl(
 			"The following project groups contain projects with mutually exclusive environments in their default lifecycle:\n"+strings.Join(projectGroupsWithExclusiveEnvs, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no project groups with mutually exclusive lifecycles",
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
@@ -1,14 +1,15 @@
 package organization
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	projects2 "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
 	"strings"
@@ -18,22 +19,22 @@ const OctoLintProjectSpecificEnvs = "OctoLintProjectSpecificEnvs"
 
 // OctopusProjectSpecificEnvironmentCheck checks to see if any project variables are unused.
 type OctopusProjectSpecificEnvironmentCheck struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusProjectSpecificEnvironmentCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusProjectSpecificEnvironmentCheck {
-	return OctopusProjectSpecificEnvironmentCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusProjectSpecificEnvironmentCheck(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusProjectSpecificEnvironmentCheck {
+	return OctopusProjectSpecificEnvironmentCheck{config: config, snapshot: snapshot, errorHandler: errorHandler}
 }
 
 func (o OctopusProjectSpecificEnvironmentCheck) Id() string {
 	return OctoLintProjectSpecificEnvs
 }
 
-func (o OctopusProjectSpecificEnvironmentCheck) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusProjectSpecificEnvironmentCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -42,32 +43,32 @@ func (o OctopusProjectSpecificEnvironmentCheck) Execute() (checks.OctopusCheckRe
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjects(o.config.MaxProjectSpecificEnvironmentProjects, o.client, o.client.GetSpaceID())
+	projects, err := o.snapshot.GetProjects(ctx, o.config.MaxProjectSpecificEnvironmentProjects)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allLifecycles, err := o.client.Lifecycles.GetAll()
+	allLifecycles, err := o.snapshot.GetLifecycles(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allEnvironments, err := client_wrapper.GetEnvironments(o.config.MaxProjectSpecificEnvironmentEnvironments, o.client, o.client.GetSpaceID())
+	allEnvironments, err := o.snapshot.GetEnvironments(ctx, o.config.MaxProjectSpecificEnvironmentEnvironments)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allChannels, err := o.client.Channels.GetAll()
+	allChannels, err := o.snapshot.GetChannels(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
 	// count the number of times an environment is referenced by a project
-	environmentCount := map[string][]string{}
+	environmentCount := map[string][]*projects2.Project{}
 	for i, p := range projects {
 		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
 
@@ -115,16 +116,16 @@ func (o OctopusProjectSpecificEnvironmentCheck) Execute() (checks.OctopusCheckRe
 			}
 
 			if _, ok := environmentCount[env]; !ok {
-				environmentCount[env] = []string{}
+				environmentCount[env] = []*projects2.Project{}
 			}
-			environmentCount[env] = append(environmentCount[env], p.Name)
+			environmentCount[env] = append(environmentCount[env], p)
 			processedEnvironments = append(processedEnvironments, env)
 		}
 
 	}
 
 	// filter down to allEnvironments that have one project
-	singleProjectEnvironments := map[string]string{}
+	singleProjectEnvironments := map[string]*projects2.Project{}
 	for env, envProjects := range environmentCount {
 		if len(envProjects) == 1 {
 			singleProjectEnvironments[env] = envProjects[0]
@@ -133,22 +134,38 @@ func (o OctopusProjectSpecificEnvironmentCheck) Execute() (checks.OctopusCheckRe
 
 	if len(singleProjectEnvironments) > 0 {
 		messages := []string{}
+		findings := []checks.OctopusCheckFinding{}
 		for env, envProject := range singleProjectEnvironments {
-			messages = append(messages, o.getEnvironmentById(allEnvironments, env).Name+" ("+envProject+")")
+			environmentName := env
+			if environment := o.getEnvironmentById(allEnvironments, env); environment != nil {
+				environmentName = environment.Name
+			}
+
+			messages = append(messages, environmentName+" ("+envProject.Name+")")
+			findings = append(findings, checks.OctopusCheckFinding{
+				ResourceType: checks.EnvironmentResource,
+				ResourceId:   env,
+				ResourceName: environmentName,
+				ProjectId:    envProject.ID,
+				ProjectName:  envProject.Name,
+				SpaceId:      envProject.SpaceID,
+				Message:      "The environment is only used by the project " + envProject.Name,
+			})
 		}
 
 		return checks.NewOctopusCheckResultImpl(
 			"The following environments are used by a single project:\n"+strings.Join(messages, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no single project environments",
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
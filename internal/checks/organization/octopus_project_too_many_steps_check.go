@@ -1,13 +1,13 @@
 package organization
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
 	"strings"
 )
@@ -17,22 +17,22 @@ const OctoLintTooManySteps = "OctoLintTooManySteps"
 
 // OctopusProjectTooManyStepsCheck checks to see if any project has too many steps.
 type OctopusProjectTooManyStepsCheck struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusProjectTooManyStepsCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusProjectTooManyStepsCheck {
-	return OctopusProjectTooManyStepsCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusProjectTooManyStepsCheck(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusProjectTooManyStepsCheck {
+	return OctopusProjectTooManyStepsCheck{config: config, snapshot: snapshot, errorHandler: errorHandler}
 }
 
 func (o OctopusProjectTooManyStepsCheck) Id() string {
 	return OctoLintTooManySteps
 }
 
-func (o OctopusProjectTooManyStepsCheck) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusProjectTooManyStepsCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -41,17 +41,18 @@ func (o OctopusProjectTooManyStepsCheck) Execute() (checks.OctopusCheckResult, e
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjects(o.config.MaxProjectStepsProjects, o.client, o.client.GetSpaceID())
+	projects, err := o.snapshot.GetProjects(ctx, o.config.MaxProjectStepsProjects)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
 	complexProjects := []string{}
+	findings := []checks.OctopusCheckFinding{}
 	for i, p := range projects {
 		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
 
-		stepCount, err := o.stepsInDeploymentProcess(p.DeploymentProcessID)
+		stepCount, err := o.stepsInDeploymentProcess(ctx, p.DeploymentProcessID)
 
 		if err != nil {
 			if !o.errorHandler.ShouldContinue(err) {
@@ -62,6 +63,16 @@ func (o OctopusProjectTooManyStepsCheck) Execute() (checks.OctopusCheckResult, e
 
 		if stepCount >= maxStepCount {
 			complexProjects = append(complexProjects, p.Name)
+			findings = append(findings, checks.OctopusCheckFinding{
+				ResourceType: checks.ProjectResource,
+				ResourceId:   p.ID,
+				ResourceName: p.Name,
+				ProjectId:    p.ID,
+				ProjectName:  p.Name,
+				SpaceId:      p.SpaceID,
+				Message:      "The project has " + fmt.Sprint(stepCount) + " steps",
+				Evidence:     fmt.Sprint(stepCount),
+			})
 		}
 	}
 
@@ -69,25 +80,26 @@ func (o OctopusProjectTooManyStepsCheck) Execute() (checks.OctopusCheckResult, e
 		return checks.NewOctopusCheckResultImpl(
 			"The following projects have 20 or more steps:\n"+strings.Join(complexProjects, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no projects with too many steps",
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
 
-func (o OctopusProjectTooManyStepsCheck) stepsInDeploymentProcess(deploymentProcessID string) (int, error) {
+func (o OctopusProjectTooManyStepsCheck) stepsInDeploymentProcess(ctx context.Context, deploymentProcessID string) (int, error) {
 	if deploymentProcessID == "" {
 		return 0, nil
 	}
 
-	resource, err := o.client.DeploymentProcesses.GetByID(deploymentProcessID)
+	resource, err := o.snapshot.GetDeploymentProcess(ctx, deploymentProcessID)
 
 	if err != nil {
 		// If we can't find the deployment process, assume zero steps
@@ -1,14 +1,14 @@
 package organization
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
 	"strings"
@@ -18,22 +18,22 @@ const OctoLintDirectTenantReferences = "OctoLintDirectTenantReferences"
 
 // OctopusTenantsInsteadOfTagsCheck checks to see if any common groups of tenants are found against common resources like accounts, targets etc
 type OctopusTenantsInsteadOfTagsCheck struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusTenantsInsteadOfTagsCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusTenantsInsteadOfTagsCheck {
-	return OctopusTenantsInsteadOfTagsCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusTenantsInsteadOfTagsCheck(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusTenantsInsteadOfTagsCheck {
+	return OctopusTenantsInsteadOfTagsCheck{config: config, snapshot: snapshot, errorHandler: errorHandler}
 }
 
 func (o OctopusTenantsInsteadOfTagsCheck) Id() string {
 	return OctoLintDirectTenantReferences
 }
 
-func (o OctopusTenantsInsteadOfTagsCheck) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusTenantsInsteadOfTagsCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -42,25 +42,25 @@ func (o OctopusTenantsInsteadOfTagsCheck) Execute() (checks.OctopusCheckResult,
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	allTenants, err := client_wrapper.GetTenants(o.config.MaxTenantTagsTenants, o.client, o.client.GetSpaceID())
+	allTenants, err := o.snapshot.GetTenants(ctx, o.config.MaxTenantTagsTenants)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allAccounts, err := o.client.Accounts.GetAll()
+	allAccounts, err := o.snapshot.GetAccounts(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allCertificates, err := o.client.Certificates.GetAll()
+	allCertificates, err := o.snapshot.GetCertificates(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allMachines, err := client_wrapper.GetMachines(o.config.MaxTenantTagsTargets, o.client, o.client.GetSpaceID())
+	allMachines, err := o.snapshot.GetMachines(ctx, o.config.MaxTenantTagsTargets)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
@@ -104,6 +104,7 @@ func (o OctopusTenantsInsteadOfTagsCheck) Execute() (checks.OctopusCheckResult,
 
 		// We have to convert the comma separated list of tenant IDs into a comma separated list of tenant names
 		groupedTenants := []string{}
+		findings := []checks.OctopusCheckFinding{}
 		for _, groupedTenant := range multipleTenantReferences {
 			splitTenants := strings.Split(groupedTenant, ",")
 			splitTenantNames := []string{}
@@ -111,20 +112,32 @@ func (o OctopusTenantsInsteadOfTagsCheck) Execute() (checks.OctopusCheckResult,
 				splitTenantNames = append(splitTenantNames, o.getTenantNameById(allTenants, splitTenant))
 			}
 			groupedTenants = append(groupedTenants, strings.Join(splitTenantNames, ", ")+" ("+strings.Join(tenantReferenceSources[groupedTenant], ", ")+")")
+
+			for i, splitTenant := range splitTenants {
+				findings = append(findings, checks.OctopusCheckFinding{
+					ResourceType: checks.TenantResource,
+					ResourceId:   splitTenant,
+					ResourceName: splitTenantNames[i],
+					SpaceId:      o.snapshot.GetSpaceID(),
+					Message:      "The tenant is directly referenced as part of a group of tenants more than once",
+					Evidence:     strings.Join(tenantReferenceSources[groupedTenant], ", "),
+				})
+			}
 		}
 
 		return checks.NewOctopusCheckResultImpl(
 			"The following groups of tenants have been directly referenced more than once, and may be better grouped as tenant tags:\n"+strings.Join(groupedTenants, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"No duplicate groups of tenants were found",
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
@@ -144,8 +157,10 @@ func (o OctopusTenantsInsteadOfTagsCheck) addTenants(tenantIds []string, source
 		return
 	}
 
-	slices.Sort(tenantIds)
-	tenants := strings.Join(tenantIds, ",")
+	// The IDs belong to the shared snapshot, so sort a copy rather than the original
+	sortedTenantIds := slices.Clone(tenantIds)
+	slices.Sort(sortedTenantIds)
+	tenants := strings.Join(sortedTenantIds, ",")
 
 	if _, ok := tenantReferences[tenants]; !ok {
 		tenantReferences[tenants] = 0
@@ -1,13 +1,12 @@
 package organization
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
 	"strings"
 	"time"
@@ -18,22 +17,22 @@ const OctoLintUnhealthyTargets = "OctoLintUnhealthyTargets"
 
 // OctopusUnhealthyTargetCheck find targets that have not been healthy in the last 30 days.
 type OctopusUnhealthyTargetCheck struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusUnhealthyTargetCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusUnhealthyTargetCheck {
-	return OctopusUnhealthyTargetCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusUnhealthyTargetCheck(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusUnhealthyTargetCheck {
+	return OctopusUnhealthyTargetCheck{config: config, snapshot: snapshot, errorHandler: errorHandler}
 }
 
 func (o OctopusUnhealthyTargetCheck) Id() string {
 	return OctoLintUnhealthyTargets
 }
 
-func (o OctopusUnhealthyTargetCheck) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusUnhealthyTargetCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -42,13 +41,14 @@ func (o OctopusUnhealthyTargetCheck) Execute() (checks.OctopusCheckResult, error
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	allMachines, err := client_wrapper.GetMachines(o.config.MaxUnhealthyTargets, o.client, o.client.GetSpaceID())
+	allMachines, err := o.snapshot.GetMachines(ctx, o.config.MaxUnhealthyTargets)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
 	unhealthyMachines := []string{}
+	findings := []checks.OctopusCheckFinding{}
 	for i, m := range allMachines {
 		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allMachines))*100) + "% complete")
 
@@ -56,9 +56,7 @@ func (o OctopusUnhealthyTargetCheck) Execute() (checks.OctopusCheckResult, error
 		if m.HealthStatus == "Unhealthy" {
 			wasEverHealthy = false
 
-			targetEvents, err := o.client.Events.Get(events.EventsQuery{
-				Regarding: m.ID,
-			})
+			targetEvents, err := o.snapshot.GetMachineEvents(ctx, m.ID)
 
 			if err != nil {
 				if !o.errorHandler.ShouldContinue(err) {
@@ -67,8 +65,8 @@ func (o OctopusUnhealthyTargetCheck) Execute() (checks.OctopusCheckResult, error
 				continue
 			}
 
-			for _, e := range targetEvents.Items {
-				if e.Category == "MachineHealthy" && time.Now().Sub(e.Occurred) < maxHealthCheckTime {
+			for _, e := range targetEvents {
+				if e.Category == "MachineHealthy" && o.snapshot.Now().Sub(e.Occurred) < maxHealthCheckTime {
 					wasEverHealthy = true
 					break
 				}
@@ -77,6 +75,14 @@ func (o OctopusUnhealthyTargetCheck) Execute() (checks.OctopusCheckResult, error
 
 		if !wasEverHealthy {
 			unhealthyMachines = append(unhealthyMachines, m.Name)
+			findings = append(findings, checks.OctopusCheckFinding{
+				ResourceType: checks.MachineResource,
+				ResourceId:   m.ID,
+				ResourceName: m.Name,
+				SpaceId:      m.SpaceID,
+				Message:      "The target has not been healthy in the last 30 days",
+				Evidence:     m.HealthStatus,
+			})
 		}
 	}
 
@@ -84,15 +90,16 @@ func (o OctopusUnhealthyTargetCheck) Execute() (checks.OctopusCheckResult, error
 		return checks.NewOctopusCheckResultImpl(
 			"The following targets have not been healthy in the last 30 days:\n"+strings.Join(unhealthyMachines, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no targets that were unhealthy for all of the last 30 days",
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
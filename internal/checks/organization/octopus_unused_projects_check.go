@@ -1,13 +1,12 @@
 package organization
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
 	"strings"
 	"time"
@@ -17,22 +16,22 @@ const OctopusUnusedProjectsCheckName = "OctoLintUnusedProjects"
 
 // OctopusUnusedProjectsCheck find projects that have not had a deployment in the last 30 days
 type OctopusUnusedProjectsCheck struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusUnusedProjectsCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusUnusedProjectsCheck {
-	return OctopusUnusedProjectsCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusUnusedProjectsCheck(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusUnusedProjectsCheck {
+	return OctopusUnusedProjectsCheck{config: config, snapshot: snapshot, errorHandler: errorHandler}
 }
 
 func (o OctopusUnusedProjectsCheck) Id() string {
 	return OctopusUnusedProjectsCheckName
 }
 
-func (o OctopusUnusedProjectsCheck) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusUnusedProjectsCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -41,13 +40,14 @@ func (o OctopusUnusedProjectsCheck) Execute() (checks.OctopusCheckResult, error)
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjects(o.config.MaxUnusedProjects, o.client, o.client.GetSpaceID())
+	projects, err := o.snapshot.GetProjects(ctx, o.config.MaxUnusedProjects)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
 	unusedProjects := []string{}
+	findings := []checks.OctopusCheckFinding{}
 	for i, project := range projects {
 		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
 
@@ -58,18 +58,14 @@ func (o OctopusUnusedProjectsCheck) Execute() (checks.OctopusCheckResult, error)
 
 		projectHasTask := false
 
-		tasks, err := o.client.Tasks.Get(tasks.TasksQuery{
-			Project: project.ID,
-			Skip:    0,
-			Take:    100,
-		})
+		tasks, err := o.snapshot.GetProjectTasks(ctx, project.ID)
 
 		if err != nil {
 			return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 		}
 
-		for _, task := range tasks.Items {
-			if task.StartTime != nil && task.StartTime.After(time.Now().Add(-time.Hour*24*time.Duration(o.config.MaxDaysSinceLastTask))) {
+		for _, task := range tasks {
+			if task.StartTime != nil && task.StartTime.After(o.snapshot.Now().Add(-time.Hour*24*time.Duration(o.config.MaxDaysSinceLastTask))) {
 				projectHasTask = true
 				break
 			}
@@ -77,6 +73,15 @@ func (o OctopusUnusedProjectsCheck) Execute() (checks.OctopusCheckResult, error)
 
 		if !projectHasTask {
 			unusedProjects = append(unusedProjects, project.Name)
+			findings = append(findings, checks.OctopusCheckFinding{
+				ResourceType: checks.ProjectResource,
+				ResourceId:   project.ID,
+				ResourceName: project.Name,
+				ProjectId:    project.ID,
+				ProjectName:  project.Name,
+				SpaceId:      project.SpaceID,
+				Message:      "The project has not had any tasks in " + fmt.Sprint(o.config.MaxDaysSinceLastTask) + " days",
+			})
 		}
 	}
 
@@ -86,15 +91,16 @@ func (o OctopusUnusedProjectsCheck) Execute() (checks.OctopusCheckResult, error)
 		return checks.NewOctopusCheckResultImpl(
 			"The following projects have not had any tasks "+daysString+" days:\n"+strings.Join(unusedProjects, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no projects that have not had any tasks in the last "+daysString+" days",
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
@@ -1,19 +1,15 @@
 package organization
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
-	"regexp"
 	"strings"
 	"time"
 )
@@ -23,22 +19,22 @@ const OctoLintUnusedTargets = "OctoLintUnusedTargets"
 
 // OctopusUnusedTargetsCheck checks to see if any targets have not been used in a month
 type OctopusUnusedTargetsCheck struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusUnusedTargetsCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusUnusedTargetsCheck {
-	return OctopusUnusedTargetsCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusUnusedTargetsCheck(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusUnusedTargetsCheck {
+	return OctopusUnusedTargetsCheck{config: config, snapshot: snapshot, errorHandler: errorHandler}
 }
 
 func (o OctopusUnusedTargetsCheck) Id() string {
 	return OctoLintUnusedTargets
 }
 
-func (o OctopusUnusedTargetsCheck) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusUnusedTargetsCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -47,19 +43,18 @@ func (o OctopusUnusedTargetsCheck) Execute() (checks.OctopusCheckResult, error)
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	targets, err := client_wrapper.GetMachines(o.config.MaxUnusedTargets, o.client, o.client.GetSpaceID())
+	targets, err := o.snapshot.GetMachines(ctx, o.config.MaxUnusedTargets)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
 	unusedMachines := []string{}
-	linksTemplate := regexp.MustCompile(`\{.+\}`)
+	findings := []checks.OctopusCheckFinding{}
 	for i, m := range targets {
 		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(targets))*100) + "% complete")
 
-		tasksLink := linksTemplate.ReplaceAllString(m.Links["TasksTemplate"], "")
-		tasks, err := newclient.Get[resources.Resources[tasks.Task]](o.client.HttpSession(), tasksLink+"?type=Deployment")
+		tasks, err := o.snapshot.GetMachineDeploymentTasks(ctx, m)
 
 		if err != nil {
 			if !o.errorHandler.ShouldContinue(err) {
@@ -69,8 +64,8 @@ func (o OctopusUnusedTargetsCheck) Execute() (checks.OctopusCheckResult, error)
 		}
 
 		recentTask := false
-		for _, t := range tasks.Items {
-			if t.CompletedTime != nil && time.Now().Sub(*t.CompletedTime) < maxTimeSinceLastMachineDeployment {
+		for _, t := range tasks {
+			if t.CompletedTime != nil && o.snapshot.Now().Sub(*t.CompletedTime) < maxTimeSinceLastMachineDeployment {
 				recentTask = true
 				break
 			}
@@ -78,6 +73,13 @@ func (o OctopusUnusedTargetsCheck) Execute() (checks.OctopusCheckResult, error)
 
 		if !recentTask {
 			unusedMachines = append(unusedMachines, m.Name)
+			findings = append(findings, checks.OctopusCheckFinding{
+				ResourceType: checks.MachineResource,
+				ResourceId:   m.ID,
+				ResourceName: m.Name,
+				SpaceId:      m.SpaceID,
+				Message:      "The target has not performed a deployment in 30 days",
+			})
 		}
 
 	}
@@ -86,15 +88,16 @@ func (o OctopusUnusedTargetsCheck) Execute() (checks.OctopusCheckResult, error)
 		return checks.NewOctopusCheckResultImpl(
 			"The following targets have not performed a deployment in 30 days:\n"+strings.Join(unusedMachines, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no unused targets",
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
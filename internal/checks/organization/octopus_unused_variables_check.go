@@ -1,45 +1,39 @@
 package organization
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
 	projects2 "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
-	"regexp"
 	"strings"
 )
 
 const OctoLintUnusedVariables = "OctoLintUnusedVariables"
 
-var linkOptions = regexp.MustCompile(`\{.*?}`)
-
 // OctopusUnusedVariablesCheck checks to see if any project variables are unused.
 type OctopusUnusedVariablesCheck struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusUnusedVariablesCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusUnusedVariablesCheck {
-	return OctopusUnusedVariablesCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusUnusedVariablesCheck(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusUnusedVariablesCheck {
+	return OctopusUnusedVariablesCheck{config: config, snapshot: snapshot, errorHandler: errorHandler}
 }
 
 func (o OctopusUnusedVariablesCheck) Id() string {
 	return OctoLintUnusedVariables
 }
 
-func (o OctopusUnusedVariablesCheck) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusUnusedVariablesCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -48,17 +42,20 @@ func (o OctopusUnusedVariablesCheck) Execute() (checks.OctopusCheckResult, error
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjects(o.config.MaxUnusedVariablesProjects, o.client, o.client.GetSpaceID())
+	projects, err := o.snapshot.GetProjects(ctx, o.config.MaxUnusedVariablesProjects)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	unusedVars := map[*projects2.Project][]*variables.Variable{}
+	// The findings are collected in the order of the projects and their variables, so repeated scans report them in the
+	// same order
+	messages := []string{}
+	findings := []checks.OctopusCheckFinding{}
 	for i, p := range projects {
 		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
 
-		variableSet, err := o.client.Variables.GetAll(p.ID)
+		variableSet, err := o.snapshot.GetVariableSet(ctx, p.ID)
 
 		if err != nil {
 			if !o.errorHandler.ShouldContinue(err) {
@@ -67,7 +64,7 @@ func (o OctopusUnusedVariablesCheck) Execute() (checks.OctopusCheckResult, error
 			continue
 		}
 
-		deploymentSteps, err := o.getDeploymentSteps(p)
+		deploymentSteps, err := o.getDeploymentSteps(ctx, p)
 
 		if err != nil {
 			if !o.errorHandler.ShouldContinue(err) {
@@ -84,43 +81,41 @@ func (o OctopusUnusedVariablesCheck) Execute() (checks.OctopusCheckResult, error
 			used := o.naiveStepVariableScan(deploymentSteps, v) || o.naiveVariableSetVariableScan(variableSet, v)
 
 			if !used {
-				if _, ok := unusedVars[p]; !ok {
-					unusedVars[p] = []*variables.Variable{}
-				}
-				unusedVars[p] = append(unusedVars[p], v)
+				messages = append(messages, p.Name+": "+v.Name)
+				findings = append(findings, checks.OctopusCheckFinding{
+					ResourceType: checks.VariableResource,
+					ResourceId:   v.ID,
+					ResourceName: v.Name,
+					ProjectId:    p.ID,
+					ProjectName:  p.Name,
+					SpaceId:      p.SpaceID,
+					Message:      "The variable may be unused",
+				})
 			}
 		}
 	}
 
-	if len(unusedVars) > 0 {
-		messages := []string{}
-		for p, variables := range unusedVars {
-			if len(variables) != 0 {
-				for _, variable := range variables {
-					messages = append(messages, p.Name+": "+variable.Name)
-				}
-			}
-		}
-
+	if len(findings) > 0 {
 		return checks.NewOctopusCheckResultImpl(
 			"The following variables may be unused (note there are edge cases octolint can't detect, so double check these before deleting them): \n"+strings.Join(messages, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no unused variables",
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
 
-func (o OctopusUnusedVariablesCheck) getDeploymentSteps(p *projects2.Project) ([]*deployments.DeploymentStep, error) {
+func (o OctopusUnusedVariablesCheck) getDeploymentSteps(ctx context.Context, p *projects2.Project) ([]*deployments.DeploymentStep, error) {
 	deploymentProcesses := []*deployments.DeploymentStep{}
-	deploymentProcess, err := o.client.DeploymentProcesses.GetByID(p.DeploymentProcessID)
+	deploymentProcess, err := o.snapshot.GetDeploymentProcess(ctx, p.DeploymentProcessID)
 
 	if err != nil {
 		if !o.errorHandler.ShouldContinue(err) {
@@ -132,27 +127,25 @@ func (o OctopusUnusedVariablesCheck) getDeploymentSteps(p *projects2.Project) ([
 		}
 	}
 
-	if link, ok := p.Links["Runbooks"]; ok {
-		runbooks, err := newclient.Get[resources.Resources[runbooks.Runbook]](o.client.HttpSession(), linkOptions.ReplaceAllString(link, ""))
+	runbooks, err := o.snapshot.GetProjectRunbooks(ctx, p)
+
+	if err != nil {
+		if !o.errorHandler.ShouldContinue(err) {
+			return nil, err
+		}
+	}
+
+	for _, runbook := range runbooks {
+		runbookProcess, err := o.snapshot.GetRunbookProcess(ctx, runbook.RunbookProcessID)
 
 		if err != nil {
 			if !o.errorHandler.ShouldContinue(err) {
 				return nil, err
 			}
-		}
-
-		for _, runbook := range runbooks.Items {
-			runbookProcess, err := o.client.RunbookProcesses.GetByID(runbook.RunbookProcessID)
-
-			if err != nil {
-				if !o.errorHandler.ShouldContinue(err) {
-					return nil, err
-				}
-				continue
-			} else {
-				if runbookProcess != nil && runbookProcess.Steps != nil {
-					deploymentProcesses = append(deploymentProcesses, runbookProcess.Steps...)
-				}
+			continue
+		} else {
+			if runbookProcess != nil && runbookProcess.Steps != nil {
+				deploymentProcesses = append(deploymentProcesses, runbookProcess.Steps...)
 			}
 		}
 	}
@@ -1,13 +1,13 @@
 package performance
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
-	"github.com/samber/lo"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
 	"math"
 	"strings"
@@ -35,24 +35,24 @@ func (d deploymentInfo) toFixed(precision int) float64 {
 
 // OctopusDeploymentQueuedTimeCheck checks to see if any deployments were queued for a long period of time
 type OctopusDeploymentQueuedTimeCheck struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	url          string
 	space        string
 	config       *config.OctolintConfig
 }
 
-func NewOctopusDeploymentQueuedTimeCheck(client *client.Client, config *config.OctolintConfig, url string, space string, errorHandler checks.OctopusClientErrorHandler) OctopusDeploymentQueuedTimeCheck {
-	return OctopusDeploymentQueuedTimeCheck{config: config, client: client, url: url, space: space, errorHandler: errorHandler}
+func NewOctopusDeploymentQueuedTimeCheck(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, url string, space string, errorHandler checks.OctopusClientErrorHandler) OctopusDeploymentQueuedTimeCheck {
+	return OctopusDeploymentQueuedTimeCheck{config: config, snapshot: snapshot, url: url, space: space, errorHandler: errorHandler}
 }
 
 func (o OctopusDeploymentQueuedTimeCheck) Id() string {
 	return OctoLintDeploymentQueuedTime
 }
 
-func (o OctopusDeploymentQueuedTimeCheck) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusDeploymentQueuedTimeCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -61,65 +61,77 @@ func (o OctopusDeploymentQueuedTimeCheck) Execute() (checks.OctopusCheckResult,
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	resource, err := o.client.Events.Get(events.EventsQuery{
-		EventCategories: []string{"DeploymentQueued", "DeploymentStarted"},
-		Skip:            0,
-		Take:            o.config.MaxDeploymentTasks,
-	})
+	resource, err := o.snapshot.GetDeploymentEvents(ctx, o.config.MaxDeploymentTasks)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Performance, err)
 	}
 
 	deployments := []deploymentInfo{}
-	if resource != nil {
-		for i, r := range resource.Items {
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(resource.Items))*100) + "% complete")
-
-			if r.Category == "DeploymentQueued" {
-				queuedDeploymentId := o.getDeploymentFromRelatedDocs(r)
-				for _, r2 := range resource.Items {
-					if r2.Category == "DeploymentStarted" && queuedDeploymentId == o.getDeploymentFromRelatedDocs(r2) {
-						queueTime := r2.Occurred.Sub(r.Occurred)
-						if queueTime.Minutes() > maxQueueTimeMinutes {
-							deployments = append(deployments, deploymentInfo{
-								deploymentId: queuedDeploymentId,
-								duration:     queueTime.Minutes(),
-								queuedAt:     r.Occurred,
-							})
-						}
+	for i, r := range resource {
+		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(resource))*100) + "% complete")
+
+		if r.Category == "DeploymentQueued" {
+			queuedDeploymentId := o.getDeploymentFromRelatedDocs(r)
+			for _, r2 := range resource {
+				if r2.Category == "DeploymentStarted" && queuedDeploymentId == o.getDeploymentFromRelatedDocs(r2) {
+					queueTime := r2.Occurred.Sub(r.Occurred)
+					if queueTime.Minutes() > maxQueueTimeMinutes {
+						deployments = append(deployments, deploymentInfo{
+							deploymentId: queuedDeploymentId,
+							duration:     queueTime.Minutes(),
+							queuedAt:     r.Occurred,
+						})
 					}
 				}
 			}
 		}
 	}
 
-	deploymentLinks := lo.Map(deployments, func(item deploymentInfo, index int) string {
-		deployment, err := o.client.Deployments.GetByID(item.deploymentId)
+	urlBuilder := checks.NewOctopusUrlBuilder(o.url, o.space)
+	deploymentLinks := []string{}
+	findings := []checks.OctopusCheckFinding{}
+	for _, item := range deployments {
+		queuedDetails := " (" + item.queuedAt.Format(time.RFC822) + " " + fmt.Sprint(item.toFixed(1)) + "m)"
+		finding := checks.OctopusCheckFinding{
+			ResourceType: checks.DeploymentResource,
+			ResourceId:   item.deploymentId,
+			ResourceName: item.deploymentId,
+			SpaceId:      o.space,
+			Message:      "The deployment was queued for " + fmt.Sprint(item.toFixed(1)) + " minutes",
+			Evidence:     item.queuedAt.Format(time.RFC3339),
+		}
+
+		deployment, err := o.snapshot.GetDeployment(ctx, item.deploymentId)
 
 		if err != nil {
-			return item.deploymentId + " (" + item.queuedAt.Format(time.RFC822) + " " + fmt.Sprint(item.toFixed(1)) + "m)"
+			deploymentLinks = append(deploymentLinks, item.deploymentId+queuedDetails)
+		} else {
+			finding.ResourceName = deployment.Name
+			finding.ProjectId = deployment.ProjectID
+			finding.Url = urlBuilder.DeploymentUrl(deployment.ProjectID, deployment.ReleaseID, item.deploymentId)
+			deploymentLinks = append(deploymentLinks, finding.Url+queuedDetails)
 		}
 
-		return o.url + "/app#/" + o.space + "/projects/" + deployment.ProjectID + "/deployments/releases/" + deployment.ReleaseID +
-			"/deployments/" + item.deploymentId + " (" + item.queuedAt.Format(time.RFC822) + " " + fmt.Sprint(item.toFixed(1)) + "m)"
-	})
+		findings = append(findings, finding)
+	}
 
 	if len(deployments) >= maxQueuedTasks {
 		return checks.NewOctopusCheckResultImpl(
 			fmt.Sprint("Found "+fmt.Sprint(len(deployments)))+" deployments that were queued for longer than "+fmt.Sprint(maxQueueTimeMinutes)+" minutes. Consider increasing the task cap or adding a HA node to reduce task queue times:\n"+
 				strings.Join(deploymentLinks, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Performance), nil
+			checks.Performance,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"Found "+fmt.Sprint(len(deployments))+" deployment tasks that were queued for longer than "+fmt.Sprint(maxQueueTimeMinutes)+" minutes:\n"+
 			strings.Join(deploymentLinks, ", "),
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Performance), nil
 }
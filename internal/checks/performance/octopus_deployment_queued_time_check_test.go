@@ -1,8 +1,10 @@
 package performance
 
 import (
+	"context"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/octoclient"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/test"
 	"net/http"
@@ -1748,9 +1750,9 @@ func TestLongTaskQueue(t *testing.T) {
 
 	// Act
 	newSpaceClient, err := octoclient.CreateClient(server.URL, "Spaces-1", test.ApiKey)
-	check := NewOctopusDeploymentQueuedTimeCheck(newSpaceClient, &config.OctolintConfig{}, "http://test.app", "Spaces-1", checks.OctopusClientPermissiveErrorHandler{})
+	check := NewOctopusDeploymentQueuedTimeCheck(snapshot.NewOctopusClientSpaceSnapshot(newSpaceClient), &config.OctolintConfig{}, "http://test.app", "Spaces-1", checks.OctopusClientPermissiveErrorHandler{})
 
-	result, err := check.Execute()
+	result, err := check.Execute(context.Background())
 
 	if err != nil {
 		t.Fatal("Check produced an error")
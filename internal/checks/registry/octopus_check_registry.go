@@ -0,0 +1,329 @@
+package registry
+
+import (
+	"errors"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/naming"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/organization"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/performance"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/security"
+	"github.com/samber/lo"
+	"strings"
+)
+
+// allChecks documents every check built by the factory. New checks must be added here, or the factory will refuse to
+// build them.
+var allChecks = []checks.OctopusCheckMetadata{
+	{
+		Id:              security.OctoLintUnrotatedAccounts,
+		Category:        checks.Security,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds accounts that have not been updated in the last 90 days.",
+		Rationale:       "Long lived credentials are more likely to have been leaked, and regular rotation limits the damage a leaked credential can do.",
+		Remediation:     "Rotate the credentials held by the account, or replace the account with an OpenID Connect account that has no long lived secrets.",
+		ConfigKeys:      []string{},
+	},
+	{
+		Id:              security.OctoLintDeploymentQueuedByAdmin,
+		Category:        checks.Security,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds projects whose recent deployments were queued by members of the administrator teams.",
+		Rationale:       "Administrators have far more permissions than are needed to deploy a project, so using their accounts for routine deployments increases the impact of a compromised account.",
+		Remediation:     "Create a user or service account with a role limited to deployments, and use it to perform deployments.",
+		ConfigKeys:      []string{"maxDeploymentsByAdminProjects"},
+	},
+	{
+		Id:              security.OctoLintPerpetualApiKeys,
+		Category:        checks.Security,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds API keys that do not have an expiry date.",
+		Rationale:       "API keys that never expire remain valid long after they are needed, and are a common source of leaked credentials.",
+		Remediation:     "Replace the API keys with new keys that have an expiry date.",
+		ConfigKeys:      []string{},
+		InstanceScoped:  true,
+	},
+	{
+		Id:              security.OctoLintSharedGitUsername,
+		Category:        checks.Security,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds Git usernames that are shared between version controlled projects.",
+		Rationale:       "Credentials copied between projects must be rotated in many places, and give every project the same level of access to the Git repositories.",
+		Remediation:     "Move the shared credentials into a Git credential managed by the library, or use a dedicated credential for each project.",
+		ConfigKeys:      []string{},
+	},
+	{
+		Id:              security.OctoLintInsecureK8sTargets,
+		Category:        checks.Security,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds Kubernetes targets that skip TLS validation or use an HTTP endpoint.",
+		Rationale:       "Connections that are unencrypted or not validated expose the cluster credentials and deployments to man in the middle attacks.",
+		Remediation:     "Use an HTTPS cluster URL and enable TLS validation, supplying the cluster certificate if it is self signed.",
+		ConfigKeys:      []string{"maxInsecureK8sTargets"},
+	},
+	{
+		Id:              security.OctoLintInsecureFeedsTargets,
+		Category:        checks.Security,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds feeds that use an HTTP endpoint.",
+		Rationale:       "Packages downloaded over an unencrypted connection can be intercepted or replaced, and feed credentials are sent in plain text.",
+		Remediation:     "Change the feed to use an HTTPS endpoint.",
+		ConfigKeys:      []string{},
+	},
+	{
+		Id:              security.OctoLintInsecureWebhookUrls,
+		Category:        checks.Security,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds subscriptions that send events to an HTTP webhook URL.",
+		Rationale:       "Events sent over an unencrypted connection can be read or modified by anyone on the network path.",
+		Remediation:     "Change the subscription to use an HTTPS webhook URL.",
+		ConfigKeys:      []string{},
+	},
+	{
+		Id:              organization.OctopusEnvironmentCountCheckName,
+		Category:        checks.Organization,
+		DefaultSeverity: checks.Warning,
+		Description:     "Reports spaces with more environments than the recommended maximum.",
+		Rationale:       "A large number of environments often indicates that environments are being used to model concepts like tenants or regions, which makes lifecycles and dashboards hard to manage.",
+		Remediation:     "Consolidate environments, and model tenants, regions, and similar concepts with tenants, tenant tags, or target tags.",
+		ConfigKeys:      []string{"maxEnvironments"},
+	},
+	{
+		Id:              organization.OctoLintDefaultProjectGroupChildCount,
+		Category:        checks.Organization,
+		DefaultSeverity: checks.Warning,
+		Description:     "Reports when the default project group contains a large number of projects.",
+		Rationale:       "Projects left in the default project group are hard to find, and can not have permissions scoped to a group of related projects.",
+		Remediation:     "Create project groups for related projects and move the projects out of the default project group.",
+		ConfigKeys:      []string{},
+	},
+	{
+		Id:              organization.OctoLintEmptyProject,
+		Category:        checks.Organization,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds projects with no deployment process and no runbooks.",
+		Rationale:       "Empty projects add clutter to the dashboard and are usually left over from testing or abandoned work.",
+		Remediation:     "Delete the empty projects, or add the steps they were created for.",
+		ConfigKeys:      []string{"maxEmptyProjectCheckProjects"},
+	},
+	{
+		Id:              organization.OctoLintUnusedVariables,
+		Category:        checks.Organization,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds project variables that are not referenced by any step or other variable.",
+		Rationale:       "Unused variables make projects harder to understand and may hold values, like credentials, that no longer need to be stored.",
+		Remediation:     "Confirm the variables are not used, as some references can not be detected, and then delete them.",
+		ConfigKeys:      []string{"maxUnusedVariablesProjects"},
+	},
+	{
+		Id:              organization.OctoLintDuplicatedVariables,
+		Category:        checks.Organization,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds variables with the same value in different projects.",
+		Rationale:       "Duplicated values must be updated in every project when they change, and are easily missed.",
+		Remediation:     "Move the shared values into a library variable set and include it in the projects.",
+		ConfigKeys:      []string{"maxDuplicateVariableProjects", "maxDuplicateVariables"},
+	},
+	{
+		Id:              organization.OctoLintTooManySteps,
+		Category:        checks.Organization,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds projects with 20 or more steps in their deployment process.",
+		Rationale:       "Large deployment processes are hard to understand and maintain, and often deploy unrelated components together.",
+		Remediation:     "Split the project into smaller projects, and coordinate them with the Deploy a Release step if required.",
+		ConfigKeys:      []string{"maxProjectStepsProjects"},
+	},
+	{
+		Id:              organization.OctoRecLifecycleRetention,
+		Category:        checks.Organization,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds lifecycles with retention policies that keep releases or files forever.",
+		Rationale:       "Keeping every release and package consumes disk space on the server and targets, and slows down the server over time.",
+		Remediation:     "Change the lifecycle retention policies to keep a limited number of releases or days.",
+		ConfigKeys:      []string{},
+	},
+	{
+		Id:              organization.OctoLintUnusedTargets,
+		Category:        checks.Organization,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds targets that have not performed a deployment in the last 30 days.",
+		Rationale:       "Unused targets consume licenses and health checks, and may be left over from decommissioned infrastructure.",
+		Remediation:     "Delete or disable the targets that are no longer needed.",
+		ConfigKeys:      []string{"maxUnusedTargets"},
+	},
+	{
+		Id:              organization.OctoLintProjectSpecificEnvs,
+		Category:        checks.Organization,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds environments that are only used by a single project.",
+		Rationale:       "Environments are intended to be shared by many projects, and environments created for a single project increase the number of environments everyone has to manage.",
+		Remediation:     "Deploy the project to the shared environments, using variables and target tags to customize the deployment.",
+		ConfigKeys:      []string{"maxProjectSpecificEnvironmentProjects", "maxProjectSpecificEnvironmentEnvironments"},
+	},
+	{
+		Id:              organization.OctoLintDirectTenantReferences,
+		Category:        checks.Organization,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds groups of tenants that are referenced directly by more than one account, certificate, or target.",
+		Rationale:       "Repeating the same list of tenants means every resource must be updated when a tenant is added or removed.",
+		Remediation:     "Create a tenant tag for the group of tenants and reference the tag instead.",
+		ConfigKeys:      []string{"maxTenantTagsTargets", "maxTenantTagsTenants"},
+	},
+	{
+		Id:              organization.OctoLintProjectGroupsWithExclusiveEnvironments,
+		Category:        checks.Organization,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds project groups containing projects whose default lifecycles share no environments.",
+		Rationale:       "Project groups work best when they group related projects, and projects that deploy to entirely different environments are rarely related.",
+		Remediation:     "Move the projects into project groups that reflect how they are deployed.",
+		ConfigKeys:      []string{"maxExclusiveEnvironmentsProjects"},
+	},
+	{
+		Id:              organization.OctoLintUnhealthyTargets,
+		Category:        checks.Organization,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds targets that have not been healthy at any time in the last 30 days.",
+		Rationale:       "Targets that are always unhealthy slow down health checks and deployments, and are usually no longer in use.",
+		Remediation:     "Fix the connection to the targets, or delete the targets if they are no longer needed.",
+		ConfigKeys:      []string{"maxUnhealthyTargets"},
+	},
+	{
+		Id:              organization.OctopusUnusedProjectsCheckName,
+		Category:        checks.Organization,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds projects that have not run any tasks recently.",
+		Rationale:       "Unused projects add clutter to the dashboard and are usually left over from retired applications.",
+		Remediation:     "Delete or disable the projects that are no longer needed.",
+		ConfigKeys:      []string{"maxDaysSinceLastTask", "maxUnusedProjects"},
+	},
+	{
+		Id:              performance.OctoLintDeploymentQueuedTime,
+		Category:        checks.Performance,
+		DefaultSeverity: checks.Warning,
+		Description:     "Reports when many recent deployments were queued for longer than a minute.",
+		Rationale:       "Deployments that wait in the task queue delay releases and indicate the server does not have enough capacity.",
+		Remediation:     "Increase the task cap, or add nodes to a high availability cluster.",
+		ConfigKeys:      []string{"maxDeploymentTasks"},
+	},
+	{
+		Id:              naming.OctoLintContainerImageName,
+		Category:        checks.Naming,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds steps that run in container images that do not match a regular expression.",
+		Rationale:       "Steps should use approved container images, for example images hosted in a trusted registry.",
+		Remediation:     "Update the steps to use container images that match the regular expression.",
+		ConfigKeys:      []string{"containerImageRegex", "maxInvalidContainerImageProjects"},
+	},
+	{
+		Id:              naming.OctoLintInvalidVariableNames,
+		Category:        checks.Naming,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds project variables with names that do not match a regular expression.",
+		Rationale:       "Consistent variable names make variables easier to find and reference.",
+		Remediation:     "Rename the variables to match the regular expression, and update any references to them.",
+		ConfigKeys:      []string{"variableNameRegex", "maxInvalidVariableProjects"},
+	},
+	{
+		Id:              naming.OctoLintInvalidTargetNames,
+		Category:        checks.Naming,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds targets with names that do not match a regular expression.",
+		Rationale:       "Consistent target names make targets easier to find and identify.",
+		Remediation:     "Rename the targets to match the regular expression.",
+		ConfigKeys:      []string{"targetNameRegex", "maxInvalidNameTargets"},
+	},
+	{
+		Id:              naming.OctoLintInvalidTargetRoles,
+		Category:        checks.Naming,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds targets with roles that do not match a regular expression.",
+		Rationale:       "Consistent target roles make it clear which steps will deploy to which targets.",
+		Remediation:     "Rename the target roles to match the regular expression, and update the steps that reference them.",
+		ConfigKeys:      []string{"targetRoleRegex", "maxInvalidRoleTargets"},
+	},
+	{
+		Id:              naming.OctoLintProjectReleaseTemplate,
+		Category:        checks.Naming,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds projects with release versioning templates that do not match a regular expression.",
+		Rationale:       "Consistent release versions make it easier to trace a release back to the code and packages it contains.",
+		Remediation:     "Update the release versioning template of the projects to match the regular expression.",
+		ConfigKeys:      []string{"projectReleaseTemplateRegex", "maxInvalidReleaseTemplateProjects"},
+	},
+	{
+		Id:              naming.OctoLintProjectWorkerPool,
+		Category:        checks.Naming,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds steps that run on worker pools with names that do not match a regular expression.",
+		Rationale:       "Steps should run on approved worker pools, for example pools with the required tools and network access.",
+		Remediation:     "Update the steps to use worker pools that match the regular expression.",
+		ConfigKeys:      []string{"projectStepWorkerPoolRegex", "maxInvalidWorkerPoolProjects"},
+	},
+	{
+		Id:              naming.OctoLintInvalidLifecycleNames,
+		Category:        checks.Naming,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds lifecycles with names that do not match a regular expression.",
+		Rationale:       "Consistent lifecycle names make it clear which lifecycle a project should use.",
+		Remediation:     "Rename the lifecycles to match the regular expression.",
+		ConfigKeys:      []string{"lifecycleNameRegex"},
+	},
+	{
+		Id:              naming.OctoLintProjectDefaultStepNames,
+		Category:        checks.Naming,
+		DefaultSeverity: checks.Warning,
+		Description:     "Finds steps that still use the default name of their step template.",
+		Rationale:       "Default step names like \"Run a Script\" do not describe what the step does, which makes deployment logs hard to follow.",
+		Remediation:     "Rename the steps to describe what they do.",
+		ConfigKeys:      []string{"maxDefaultStepNameProjects"},
+	},
+}
+
+// AllChecks returns the metadata of every check.
+func AllChecks() []checks.OctopusCheckMetadata {
+	return lo.Map(allChecks, func(item checks.OctopusCheckMetadata, index int) checks.OctopusCheckMetadata {
+		item.DocumentationUrl = checks.DocumentationLink(item.Id)
+		return item
+	})
+}
+
+// ChecksInCategory returns the metadata of the checks in the supplied category. The category is case insensitive.
+func ChecksInCategory(category string) []checks.OctopusCheckMetadata {
+	return lo.Filter(AllChecks(), func(item checks.OctopusCheckMetadata, index int) bool {
+		return strings.EqualFold(item.Category, category)
+	})
+}
+
+// GetCheck returns the metadata of the check with the supplied ID.
+func GetCheck(id string) (checks.OctopusCheckMetadata, bool) {
+	return lo.Find(AllChecks(), func(item checks.OctopusCheckMetadata) bool {
+		return item.Id == id
+	})
+}
+
+// IsInstanceScoped returns true if the check with the supplied ID only reads resources shared by every space.
+func IsInstanceScoped(id string) bool {
+	check, found := GetCheck(id)
+	return found && check.InstanceScoped
+}
+
+// Categories returns the categories that contain at least one check.
+func Categories() []string {
+	return lo.Uniq(lo.Map(allChecks, func(item checks.OctopusCheckMetadata, index int) string {
+		return item.Category
+	}))
+}
+
+// ValidateCheckIds returns an error listing any of the supplied IDs that do not match a registered check.
+func ValidateCheckIds(ids []string) error {
+	unknown := lo.Filter(ids, func(item string, index int) bool {
+		_, found := GetCheck(item)
+		return !found
+	})
+
+	if len(unknown) != 0 {
+		return errors.New("unknown checks: " + strings.Join(unknown, ", ") +
+			". Run \"octolint list-checks\" to see the available checks.")
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/samber/lo"
+	"sort"
+	"strings"
+)
+
+// ParseScoreWeights converts the weights in the score section of the config file into the weights used to calculate
+// the health score. Severities that are not configured keep their default weight.
+func ParseScoreWeights(scoreConfig config.ScoreWeights) (checks.OctopusScoreWeights, error) {
+	weights := checks.DefaultScoreWeights()
+
+	for name, weight := range scoreConfig.Severities {
+		severity, err := checks.ParseSeverity(name)
+
+		if err != nil {
+			return checks.OctopusScoreWeights{}, err
+		}
+
+		if severity != checks.Error && severity != checks.Warning && severity != checks.Info {
+			return checks.OctopusScoreWeights{}, errors.New("only the error, warning, and info severities have a weight")
+		}
+
+		if weight < 0 {
+			return checks.OctopusScoreWeights{}, fmt.Errorf("the weight of the %s severity must not be negative", name)
+		}
+
+		weights.Severities[severity] = weight
+	}
+
+	if lo.Max(lo.Values(weights.Severities)) == 0 {
+		return checks.OctopusScoreWeights{}, errors.New("at least one severity must have a weight greater than 0")
+	}
+
+	ids := lo.Keys(scoreConfig.Checks)
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		// The config file loader converts map keys to lower case, so the check IDs are matched case insensitively
+		check, found := lo.Find(AllChecks(), func(item checks.OctopusCheckMetadata) bool {
+			return strings.EqualFold(item.Id, id)
+		})
+
+		if !found {
+			return checks.OctopusScoreWeights{}, ValidateCheckIds([]string{id})
+		}
+
+		if scoreConfig.Checks[id] < 0 {
+			return checks.OctopusScoreWeights{}, fmt.Errorf("the weight of the check %s must not be negative", check.Id)
+		}
+
+		weights.Checks[check.Id] = scoreConfig.Checks[id]
+	}
+
+	return weights, nil
+}
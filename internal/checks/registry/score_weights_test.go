@@ -0,0 +1,45 @@
+package registry
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/security"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"strings"
+	"testing"
+)
+
+func TestParseScoreWeights(t *testing.T) {
+	weights, err := ParseScoreWeights(config.ScoreWeights{
+		Severities: map[string]float64{"warning": 2},
+		Checks:     map[string]float64{strings.ToLower(security.OctoLintPerpetualApiKeys): 3},
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if weights.Severities[checks.Warning] != 2 || weights.Severities[checks.Error] != checks.DefaultScoreWeights().Severities[checks.Error] {
+		t.Fatal("Should have replaced the weight of the configured severity only")
+	}
+
+	if weights.Checks[security.OctoLintPerpetualApiKeys] != 3 {
+		t.Fatal("Should have matched the check ID regardless of case")
+	}
+}
+
+func TestParseInvalidScoreWeights(t *testing.T) {
+	invalid := []config.ScoreWeights{
+		{Severities: map[string]float64{"critical": 1}},
+		{Severities: map[string]float64{"permission": 1}},
+		{Severities: map[string]float64{"warning": -1}},
+		{Severities: map[string]float64{"error": 0, "warning": 0, "info": 0}},
+		{Checks: map[string]float64{"OctoLintDoesNotExist": 1}},
+		{Checks: map[string]float64{security.OctoLintPerpetualApiKeys: -1}},
+	}
+
+	for _, weights := range invalid {
+		if _, err := ParseScoreWeights(weights); err == nil {
+			t.Fatalf("Should have returned an error for %+v", weights)
+		}
+	}
+}
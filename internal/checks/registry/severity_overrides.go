@@ -0,0 +1,85 @@
+package registry
+
+import (
+	"errors"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/samber/lo"
+	"strings"
+)
+
+// ParseSeverityOverrides converts entries like "OctoLintPerpetualApiKeys=error" into a map of check IDs to the
+// severity reported when the check finds an issue.
+func ParseSeverityOverrides(entries []string) (map[string]int, error) {
+	overrides := map[string]int{}
+
+	for _, entry := range entries {
+		id, name, found := strings.Cut(entry, "=")
+
+		if !found {
+			return nil, errors.New("the severity \"" + entry + "\" must be in the format CheckId=severity, for example " +
+				"OctoLintPerpetualApiKeys=error")
+		}
+
+		id = strings.TrimSpace(id)
+
+		if err := ValidateCheckIds([]string{id}); err != nil {
+			return nil, err
+		}
+
+		severity, err := checks.ParseSeverity(name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if severity != checks.Error && severity != checks.Warning && severity != checks.Info {
+			return nil, errors.New("the severity of " + id + " must be error, warning, or info")
+		}
+
+		overrides[id] = severity
+	}
+
+	return overrides, nil
+}
+
+// ApplySeverityOverrides replaces the severity of the results that report an issue with the severity configured for
+// the check. Passing results, permission errors, and checks that failed to run are left unchanged.
+func ApplySeverityOverrides(results []checks.OctopusCheckResult, overrides map[string]int) []checks.OctopusCheckResult {
+	return lo.Map(results, func(item checks.OctopusCheckResult, index int) checks.OctopusCheckResult {
+		severity, found := overrides[item.Code()]
+
+		if !found || item.Category() == checks.GeneralError {
+			return item
+		}
+
+		check, found := GetCheck(item.Code())
+
+		if !found || item.Severity() != check.DefaultSeverity {
+			return item
+		}
+
+		return checks.NewOctopusCheckResultImpl(
+			item.Description(),
+			item.Code(),
+			item.Link(),
+			severity,
+			item.Category(),
+			item.Findings()...)
+	})
+}
+
+// ConfiguredSeverity returns the severity the check reports when it finds an issue, which is the severity configured for
+// the check, or its default severity. The second value is false if the check is not registered.
+func ConfiguredSeverity(id string, overrides map[string]int) (int, bool) {
+	if severity, found := overrides[id]; found {
+		return severity, true
+	}
+
+	check, found := GetCheck(id)
+
+	if !found {
+		return checks.Ok, false
+	}
+
+	return check.DefaultSeverity, true
+}
@@ -0,0 +1,88 @@
+package registry
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/organization"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/security"
+	"testing"
+)
+
+func TestParseSeverityOverrides(t *testing.T) {
+	overrides, err := ParseSeverityOverrides([]string{
+		security.OctoLintPerpetualApiKeys + "=error",
+		organization.OctoLintEmptyProject + " = Info",
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if overrides[security.OctoLintPerpetualApiKeys] != checks.Error {
+		t.Fatal("Should have set the severity to error")
+	}
+
+	if overrides[organization.OctoLintEmptyProject] != checks.Info {
+		t.Fatal("Should have set the severity to info")
+	}
+}
+
+func TestParseInvalidSeverityOverrides(t *testing.T) {
+	invalidEntries := []string{
+		security.OctoLintPerpetualApiKeys,
+		security.OctoLintPerpetualApiKeys + "=critical",
+		security.OctoLintPerpetualApiKeys + "=ok",
+		"OctoLintDoesNotExist=error",
+	}
+
+	for _, entry := range invalidEntries {
+		if _, err := ParseSeverityOverrides([]string{entry}); err == nil {
+			t.Fatal("Should have returned an error for " + entry)
+		}
+	}
+}
+
+func TestApplySeverityOverrides(t *testing.T) {
+	overrides := map[string]int{
+		security.OctoLintPerpetualApiKeys: checks.Error,
+		organization.OctoLintEmptyProject: checks.Info,
+	}
+
+	results := ApplySeverityOverrides([]checks.OctopusCheckResult{
+		checks.NewOctopusCheckResultImpl("Found keys", security.OctoLintPerpetualApiKeys, "", checks.Warning, checks.Security),
+		checks.NewOctopusCheckResultImpl("No empty projects", organization.OctoLintEmptyProject, "", checks.Ok, checks.Organization),
+		checks.NewOctopusCheckResultImpl("Failed", organization.OctoLintEmptyProject, "", checks.Error, checks.GeneralError),
+		checks.NewOctopusCheckResultImpl("Found projects", organization.OctoLintUnusedVariables, "", checks.Warning, checks.Organization),
+	}, overrides)
+
+	if results[0].Severity() != checks.Error {
+		t.Fatal("Should have overridden the severity of the issue")
+	}
+
+	if results[1].Severity() != checks.Ok {
+		t.Fatal("Should not have overridden the severity of a passing check")
+	}
+
+	if results[2].Severity() != checks.Error {
+		t.Fatal("Should not have overridden the severity of a check that failed to run")
+	}
+
+	if results[3].Severity() != checks.Warning {
+		t.Fatal("Should not have overridden the severity of a check without an override")
+	}
+}
+
+func TestConfiguredSeverity(t *testing.T) {
+	overrides := map[string]int{security.OctoLintPerpetualApiKeys: checks.Error}
+
+	if severity, found := ConfiguredSeverity(security.OctoLintPerpetualApiKeys, overrides); !found || severity != checks.Error {
+		t.Fatal("Should have returned the configured severity")
+	}
+
+	if severity, found := ConfiguredSeverity(organization.OctoLintEmptyProject, overrides); !found || severity != checks.Warning {
+		t.Fatal("Should have returned the default severity")
+	}
+
+	if _, found := ConfiguredSeverity("OctoLintDoesNotExist", overrides); found {
+		t.Fatal("Should not have found an unregistered check")
+	}
+}
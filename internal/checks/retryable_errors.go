@@ -0,0 +1,39 @@
+package checks
+
+import (
+	"errors"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+)
+
+// IsTransientError determines if an error is likely to succeed if the request is retried. Server errors, rate
+// limiting, timeouts and dropped connections are considered transient. Permission errors and other client errors
+// are not, as retrying them will only produce the same result.
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var apiError *core.APIError
+	if errors.As(err, &apiError) {
+		return apiError.StatusCode >= http.StatusInternalServerError ||
+			apiError.StatusCode == http.StatusTooManyRequests
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netError net.Error
+	if errors.As(err, &netError) {
+		return netError.Timeout()
+	}
+
+	return false
+}
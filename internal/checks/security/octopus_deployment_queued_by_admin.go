@@ -1,19 +1,18 @@
 package security
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/teams"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/users"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
 	"strings"
-	"time"
 )
 
 const OctoLintDeploymentQueuedByAdmin = "OctoLintDeploymentQueuedByAdmin"
@@ -21,22 +20,22 @@ const OctoLintDeploymentQueuedByAdmin = "OctoLintDeploymentQueuedByAdmin"
 // OctopusDeploymentQueuedByAdminCheck checks to see if any deployments were initiated by someone from the admin teams.
 // This usually means that a more specific and limited user should be created to perform deployments.
 type OctopusDeploymentQueuedByAdminCheck struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusDeploymentQueuedByAdminCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusDeploymentQueuedByAdminCheck {
-	return OctopusDeploymentQueuedByAdminCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusDeploymentQueuedByAdminCheck(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusDeploymentQueuedByAdminCheck {
+	return OctopusDeploymentQueuedByAdminCheck{config: config, snapshot: snapshot, errorHandler: errorHandler}
 }
 
 func (o OctopusDeploymentQueuedByAdminCheck) Id() string {
 	return OctoLintDeploymentQueuedByAdmin
 }
 
-func (o OctopusDeploymentQueuedByAdminCheck) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusDeploymentQueuedByAdminCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -45,23 +44,29 @@ func (o OctopusDeploymentQueuedByAdminCheck) Execute() (checks.OctopusCheckResul
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjects(o.config.MaxDeploymentsByAdminProjects, o.client, o.client.GetSpaceID())
+	projects, err := o.snapshot.GetProjects(ctx, o.config.MaxDeploymentsByAdminProjects)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
 	}
 
-	teams, err := o.getAdminTeams()
+	teams, err := o.getAdminTeams(ctx)
+
+	if err != nil {
+		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
+	}
+
+	allUsers, err := o.snapshot.GetUsers(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
 	}
 
 	projectsDeployedByAdmins := []string{}
+	findings := []checks.OctopusCheckFinding{}
 
-	now := time.Now()
-	fromDate := now.AddDate(0, -3, 0)
-	from := fromDate.Format("2006-01-02")
+	now := o.snapshot.Now()
+	from := now.AddDate(0, -3, 0)
 
 	for i, p := range projects {
 		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
@@ -69,13 +74,7 @@ func (o OctopusDeploymentQueuedByAdminCheck) Execute() (checks.OctopusCheckResul
 		projectId := p.ID
 		usersWhoDeployedProject := []string{}
 
-		resource, err := o.client.Events.Get(events.EventsQuery{
-			EventCategories: []string{"DeploymentQueued"},
-			Projects:        []string{projectId},
-			Skip:            0,
-			Take:            100,
-			From:            from,
-		})
+		resource, err := o.snapshot.GetProjectDeploymentQueuedEvents(ctx, projectId, from)
 
 		if err != nil {
 			if !o.errorHandler.ShouldContinue(err) {
@@ -84,30 +83,19 @@ func (o OctopusDeploymentQueuedByAdminCheck) Execute() (checks.OctopusCheckResul
 			continue
 		}
 
-		if resource != nil {
-			for _, r := range resource.Items {
-				if r.Username == "system" {
-					continue
-				}
+		for _, r := range resource {
+			if r.Username == "system" {
+				continue
+			}
 
-				user, err := o.client.Users.Get(users.UsersQuery{
-					Filter: r.Username,
-					Skip:   0,
-					Take:   1,
-				})
+			user := lo.Filter(allUsers, func(item *users.User, index int) bool {
+				return item.Username == r.Username
+			})
 
-				if err != nil {
-					if !o.errorHandler.ShouldContinue(err) {
-						return nil, err
-					}
-					continue
-				}
-
-				for _, u := range user.Items {
-					for _, t := range teams {
-						if slices.Index(t.MemberUserIDs, u.ID) != -1 && slices.Index(usersWhoDeployedProject, u.Username) == -1 {
-							usersWhoDeployedProject = append(usersWhoDeployedProject, u.Username)
-						}
+			for _, u := range user {
+				for _, t := range teams {
+					if slices.Index(t.MemberUserIDs, u.ID) != -1 && slices.Index(usersWhoDeployedProject, u.Username) == -1 {
+						usersWhoDeployedProject = append(usersWhoDeployedProject, u.Username)
 					}
 				}
 			}
@@ -115,6 +103,16 @@ func (o OctopusDeploymentQueuedByAdminCheck) Execute() (checks.OctopusCheckResul
 
 		if len(usersWhoDeployedProject) != 0 {
 			projectsDeployedByAdmins = append(projectsDeployedByAdmins, p.Name+" ("+strings.Join(usersWhoDeployedProject, ",")+")")
+			findings = append(findings, checks.OctopusCheckFinding{
+				ResourceType: checks.ProjectResource,
+				ResourceId:   p.ID,
+				ResourceName: p.Name,
+				ProjectId:    p.ID,
+				ProjectName:  p.Name,
+				SpaceId:      p.SpaceID,
+				Message:      "The project was deployed by members of an administrator team",
+				Evidence:     strings.Join(usersWhoDeployedProject, ","),
+			})
 		}
 	}
 
@@ -122,37 +120,38 @@ func (o OctopusDeploymentQueuedByAdminCheck) Execute() (checks.OctopusCheckResul
 		return checks.NewOctopusCheckResultImpl(
 			"The following projects were deployed by admins. Consider creating a limited user account to perform deployments:\n"+strings.Join(projectsDeployedByAdmins, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Security), nil
+			checks.Security,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"No deployments were found",
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Security), nil
 }
 
-func (o OctopusDeploymentQueuedByAdminCheck) getAdminTeams() ([]*teams.Team, error) {
+func (o OctopusDeploymentQueuedByAdminCheck) getAdminTeams(ctx context.Context) ([]*teams.Team, error) {
 	adminTeams := []string{"Octopus Administrators", "Space Managers", "Octopus Managers"}
 
+	allTeams, err := o.snapshot.GetTeams(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
 	teamResources := []*teams.Team{}
 	for _, adminTeam := range adminTeams {
-		team, err := o.client.Teams.Get(teams.TeamsQuery{
-			IDs:           nil,
-			IncludeSystem: true,
-			PartialName:   adminTeam,
-			Skip:          0,
-			Take:          1,
+		team, found := lo.Find(allTeams, func(item *teams.Team) bool {
+			return strings.Contains(strings.ToLower(item.Name), strings.ToLower(adminTeam))
 		})
 
-		if err != nil {
-			return nil, err
+		if found {
+			teamResources = append(teamResources, team)
 		}
-
-		teamResources = append(teamResources, team.Items...)
 	}
 
 	return teamResources, nil
@@ -1,51 +1,36 @@
 package security
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
 	"strings"
 )
 
-// CustomProject is the simplest representation of a project and its version controlled settings
-type CustomProject struct {
-	PersistenceSettings CustomPersistenceSettings `json:"PersistenceSettings"`
-	Name                string                    `json:"Name"`
-}
-
-type CustomPersistenceSettings struct {
-	Type        string             `json:"Type"`
-	Credentials *CustomCredentials `json:"Credentials"`
-}
-
-type CustomCredentials struct {
-	Type     string  `json:"Type"`
-	Username *string `json:"Username"`
-}
+const OctoLintSharedGitUsername = "OctoLintSharedGitUsername"
 
 // OctopusDuplicatedGitCredentialsCheck reports on any perpetual api keys
 type OctopusDuplicatedGitCredentialsCheck struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusDuplicatedGitCredentialsCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusDuplicatedGitCredentialsCheck {
-	return OctopusDuplicatedGitCredentialsCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusDuplicatedGitCredentialsCheck(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusDuplicatedGitCredentialsCheck {
+	return OctopusDuplicatedGitCredentialsCheck{config: config, snapshot: snapshot, errorHandler: errorHandler}
 }
 
 func (o OctopusDuplicatedGitCredentialsCheck) Id() string {
-	return "OctoLintSharedGitUsername"
+	return OctoLintSharedGitUsername
 }
 
-func (o OctopusDuplicatedGitCredentialsCheck) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusDuplicatedGitCredentialsCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -54,8 +39,7 @@ func (o OctopusDuplicatedGitCredentialsCheck) Execute() (checks.OctopusCheckResu
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	url := o.client.HttpSession().BaseURL.String() + "/api/" + o.client.GetSpaceID() + "/Projects?take=1000"
-	allProjects, err := newclient.Get[resources.Resources[CustomProject]](o.client.HttpSession(), url)
+	allProjects, err := o.snapshot.GetProjectPersistenceSettings(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
@@ -63,8 +47,9 @@ func (o OctopusDuplicatedGitCredentialsCheck) Execute() (checks.OctopusCheckResu
 
 	gitUsernameCounts := map[string]int{}
 	gitUsernameProjects := map[string][]string{}
-	for i, p := range allProjects.Items {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allProjects.Items))*100) + "% complete")
+	gitUsernameProjectRefs := map[string][]snapshot.CustomProject{}
+	for i, p := range allProjects {
+		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allProjects))*100) + "% complete")
 
 		if p.PersistenceSettings.Type == "VersionControlled" &&
 			p.PersistenceSettings.Credentials.Type == "UsernamePassword" &&
@@ -78,6 +63,7 @@ func (o OctopusDuplicatedGitCredentialsCheck) Execute() (checks.OctopusCheckResu
 				gitUsernameProjects[*p.PersistenceSettings.Credentials.Username] = []string{}
 			}
 			gitUsernameProjects[*p.PersistenceSettings.Credentials.Username] = append(gitUsernameProjects[*p.PersistenceSettings.Credentials.Username], p.Name)
+			gitUsernameProjectRefs[*p.PersistenceSettings.Credentials.Username] = append(gitUsernameProjectRefs[*p.PersistenceSettings.Credentials.Username], p)
 		}
 	}
 
@@ -90,22 +76,37 @@ func (o OctopusDuplicatedGitCredentialsCheck) Execute() (checks.OctopusCheckResu
 
 	if len(duplicatedGitCredentials) != 0 {
 		message := []string{}
+		findings := []checks.OctopusCheckFinding{}
 		for u, p := range duplicatedGitCredentials {
 			message = append(message, u+" ("+strings.Join(p, ", ")+")")
+
+			for _, project := range gitUsernameProjectRefs[u] {
+				findings = append(findings, checks.OctopusCheckFinding{
+					ResourceType: checks.ProjectResource,
+					ResourceId:   project.Id,
+					ResourceName: project.Name,
+					ProjectId:    project.Id,
+					ProjectName:  project.Name,
+					SpaceId:      o.snapshot.GetSpaceID(),
+					Message:      "The project shares a Git username with other projects",
+					Evidence:     u,
+				})
+			}
 		}
 
 		return checks.NewOctopusCheckResultImpl(
 			"The following Git usernames have been reused across the following projects:\n"+strings.Join(message, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Security), nil
+			checks.Security,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"No Git usernames have been resued",
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Security), nil
 }
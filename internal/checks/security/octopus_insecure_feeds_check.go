@@ -1,34 +1,37 @@
 package security
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/feeds"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
 	"strings"
 )
 
+const OctoLintInsecureFeedsTargets = "OctoLintInsecureFeedsTargets"
+
 // OctopusInsecureFeedsCheck checks to see if any targets have not been used in a month
 type OctopusInsecureFeedsCheck struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusInsecureFeedsCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusInsecureFeedsCheck {
-	return OctopusInsecureFeedsCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusInsecureFeedsCheck(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusInsecureFeedsCheck {
+	return OctopusInsecureFeedsCheck{config: config, snapshot: snapshot, errorHandler: errorHandler}
 }
 
 func (o OctopusInsecureFeedsCheck) Id() string {
-	return "OctoLintInsecureFeedsTargets"
+	return OctoLintInsecureFeedsTargets
 }
 
-func (o OctopusInsecureFeedsCheck) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusInsecureFeedsCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -37,73 +40,65 @@ func (o OctopusInsecureFeedsCheck) Execute() (checks.OctopusCheckResult, error)
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	targets, err := o.client.Feeds.GetAll()
+	targets, err := o.snapshot.GetFeeds(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
 	}
 
 	insecureFeeds := []string{}
+	findings := []checks.OctopusCheckFinding{}
 	for i, m := range targets {
 		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(targets))*100) + "% complete")
 
-		if m.GetFeedType() == "ArtifactoryGeneric" {
-			typedFeed := m.(*feeds.ArtifactoryGenericFeed)
-			if strings.HasPrefix(typedFeed.FeedURI, "http://") {
-				insecureFeeds = append(insecureFeeds, m.GetName())
-			}
-		}
-
-		if m.GetFeedType() == "NuGet" {
-			typedFeed := m.(*feeds.NuGetFeed)
-			if strings.HasPrefix(typedFeed.FeedURI, "http://") {
-				insecureFeeds = append(insecureFeeds, m.GetName())
-			}
-		}
-
-		if m.GetFeedType() == "Maven" {
-			typedFeed := m.(*feeds.MavenFeed)
-			if strings.HasPrefix(typedFeed.FeedURI, "http://") {
-				insecureFeeds = append(insecureFeeds, m.GetName())
-			}
-		}
-
-		if m.GetFeedType() == "Helm" {
-			typedFeed := m.(*feeds.HelmFeed)
-			if strings.HasPrefix(typedFeed.FeedURI, "http://") {
-				insecureFeeds = append(insecureFeeds, m.GetName())
-			}
-		}
-
-		if m.GetFeedType() == "GitHub" {
-			typedFeed := m.(*feeds.GitHubRepositoryFeed)
-			if strings.HasPrefix(typedFeed.FeedURI, "http://") {
-				insecureFeeds = append(insecureFeeds, m.GetName())
-			}
-		}
-
-		if m.GetFeedType() == "Docker" {
-			typedFeed := m.(*feeds.DockerContainerRegistry)
-			if strings.HasPrefix(typedFeed.FeedURI, "http://") {
-				insecureFeeds = append(insecureFeeds, m.GetName())
-			}
+		feedUri := o.getFeedUri(m)
+		if strings.HasPrefix(feedUri, "http://") {
+			insecureFeeds = append(insecureFeeds, m.GetName())
+			findings = append(findings, checks.OctopusCheckFinding{
+				ResourceType: checks.FeedResource,
+				ResourceId:   m.GetID(),
+				ResourceName: m.GetName(),
+				SpaceId:      m.GetSpaceID(),
+				Message:      "The feed uses an insecure HTTP endpoint",
+				Evidence:     feedUri,
+			})
 		}
-
 	}
 
 	if len(insecureFeeds) > 0 {
 		return checks.NewOctopusCheckResultImpl(
 			"The following feeds use an insecure HTTP endpoint:\n"+strings.Join(insecureFeeds, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Security), nil
+			checks.Security,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no insecure feeds",
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Security), nil
 }
+
+// getFeedUri returns the URI of the feeds that expose one, or an empty string for feeds like the built-in feed
+func (o OctopusInsecureFeedsCheck) getFeedUri(feed feeds.IFeed) string {
+	switch typedFeed := feed.(type) {
+	case *feeds.ArtifactoryGenericFeed:
+		return typedFeed.FeedURI
+	case *feeds.NuGetFeed:
+		return typedFeed.FeedURI
+	case *feeds.MavenFeed:
+		return typedFeed.FeedURI
+	case *feeds.HelmFeed:
+		return typedFeed.FeedURI
+	case *feeds.GitHubRepositoryFeed:
+		return typedFeed.FeedURI
+	case *feeds.DockerContainerRegistry:
+		return typedFeed.FeedURI
+	}
+
+	return ""
+}
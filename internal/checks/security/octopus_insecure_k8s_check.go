@@ -1,13 +1,13 @@
 package security
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
 	"strings"
@@ -17,22 +17,22 @@ const OctoLintInsecureK8sTargets = "OctoLintInsecureK8sTargets"
 
 // OctopusInsecureK8sCheck checks to see if any targets have not been used in a month
 type OctopusInsecureK8sCheck struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusInsecureK8sCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusInsecureK8sCheck {
-	return OctopusInsecureK8sCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusInsecureK8sCheck(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusInsecureK8sCheck {
+	return OctopusInsecureK8sCheck{config: config, snapshot: snapshot, errorHandler: errorHandler}
 }
 
 func (o OctopusInsecureK8sCheck) Id() string {
 	return OctoLintInsecureK8sTargets
 }
 
-func (o OctopusInsecureK8sCheck) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusInsecureK8sCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -41,7 +41,7 @@ func (o OctopusInsecureK8sCheck) Execute() (checks.OctopusCheckResult, error) {
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	targets, err := client_wrapper.GetMachines(o.config.MaxInsecureK8sTargets, o.client, o.client.GetSpaceID())
+	targets, err := o.snapshot.GetMachines(ctx, o.config.MaxInsecureK8sTargets)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
@@ -52,12 +52,27 @@ func (o OctopusInsecureK8sCheck) Execute() (checks.OctopusCheckResult, error) {
 	})
 
 	insecureMachines := []string{}
+	findings := []checks.OctopusCheckFinding{}
 	for i, m := range k8sTargets {
 		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(k8sTargets))*100) + "% complete")
 
 		k8sEndpoint := m.Endpoint.(*machines.KubernetesEndpoint)
 		if k8sEndpoint.SkipTLSVerification || (k8sEndpoint.ClusterURL != nil && strings.HasPrefix(k8sEndpoint.ClusterURL.String(), "http://")) {
 			insecureMachines = append(insecureMachines, m.Name)
+
+			evidence := "SkipTLSVerification"
+			if k8sEndpoint.ClusterURL != nil && strings.HasPrefix(k8sEndpoint.ClusterURL.String(), "http://") {
+				evidence = k8sEndpoint.ClusterURL.String()
+			}
+
+			findings = append(findings, checks.OctopusCheckFinding{
+				ResourceType: checks.MachineResource,
+				ResourceId:   m.ID,
+				ResourceName: m.Name,
+				SpaceId:      m.SpaceID,
+				Message:      "The Kubernetes target skips TLS validation or uses an insecure HTTP endpoint",
+				Evidence:     evidence,
+			})
 		}
 
 	}
@@ -66,15 +81,16 @@ func (o OctopusInsecureK8sCheck) Execute() (checks.OctopusCheckResult, error) {
 		return checks.NewOctopusCheckResultImpl(
 			"The following Kubernetes targets skip TLS validation or use an insecure HTTP endpoint:\n"+strings.Join(insecureMachines, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Security), nil
+			checks.Security,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no insecure Kubernetes targets",
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Security), nil
 }
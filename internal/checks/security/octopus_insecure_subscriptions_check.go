@@ -1,35 +1,36 @@
 package security
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/services/api"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
 	"strings"
 )
 
+const OctoLintInsecureWebhookUrls = "OctoLintInsecureWebhookUrls"
+
 // OctopusInsecureSubscriptionsCheck checks to see if any targets have not been used in a month
 type OctopusInsecureSubscriptionsCheck struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusInsecureSubscriptionsCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusInsecureSubscriptionsCheck {
-	return OctopusInsecureSubscriptionsCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusInsecureSubscriptionsCheck(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusInsecureSubscriptionsCheck {
+	return OctopusInsecureSubscriptionsCheck{config: config, snapshot: snapshot, errorHandler: errorHandler}
 }
 
 func (o OctopusInsecureSubscriptionsCheck) Id() string {
-	return "OctoLintInsecureWebhookUrls"
+	return OctoLintInsecureWebhookUrls
 }
 
-func (o OctopusInsecureSubscriptionsCheck) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusInsecureSubscriptionsCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -38,19 +39,27 @@ func (o OctopusInsecureSubscriptionsCheck) Execute() (checks.OctopusCheckResult,
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	collection := resources.Resources[*OctopusSubscription]{}
-	_, err := api.ApiGet(o.client.Subscriptions.GetClient(), &collection, o.client.Subscriptions.BasePath+"?skip=0&take=2147483647")
+	subscriptions, err := o.snapshot.GetSubscriptions(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
 	}
 
 	insecureItems := []string{}
-	for i, m := range collection.Items {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(collection.Items))*100) + "% complete")
+	findings := []checks.OctopusCheckFinding{}
+	for i, m := range subscriptions {
+		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(subscriptions))*100) + "% complete")
 
 		if m.EventNotificationSubscription != nil && strings.HasPrefix(m.EventNotificationSubscription.WebhookURI, "http://") {
 			insecureItems = append(insecureItems, m.Name)
+			findings = append(findings, checks.OctopusCheckFinding{
+				ResourceType: checks.SubscriptionResource,
+				ResourceId:   m.Id,
+				ResourceName: m.Name,
+				SpaceId:      o.snapshot.GetSpaceID(),
+				Message:      "The subscription uses an insecure HTTP webhook URL",
+				Evidence:     m.EventNotificationSubscription.WebhookURI,
+			})
 		}
 
 	}
@@ -59,24 +68,16 @@ func (o OctopusInsecureSubscriptionsCheck) Execute() (checks.OctopusCheckResult,
 		return checks.NewOctopusCheckResultImpl(
 			"The following subscriptions use an insecure HTTP webhook URL:\n"+strings.Join(insecureItems, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Security), nil
+			checks.Security,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no insecure subscriptions",
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Security), nil
 }
-
-type OctopusSubscription struct {
-	Name                          string
-	EventNotificationSubscription *OctopusEventNotificationSubscription
-}
-
-type OctopusEventNotificationSubscription struct {
-	WebhookURI string
-}
@@ -1,47 +1,36 @@
 package security
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
-	"regexp"
 	"strings"
-	"time"
 )
 
-type APIKeyKey struct {
-	Hint *string
-}
-
-// APIKey is used because the go client_wrapper has an invalid APIKey value that prevents the usual functions for querying users keys
-type APIKey struct {
-	APIKey  APIKeyKey  `json:"ApiKey,omitempty"`
-	Expires *time.Time `json:"Expires,omitempty"`
-}
+const OctoLintPerpetualApiKeys = "OctoLintPerpetualApiKeys"
 
 // OctopusPerpetualApiKeysCheck reports on any perpetual api keys
 type OctopusPerpetualApiKeysCheck struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusPerpetualApiKeysCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusPerpetualApiKeysCheck {
-	return OctopusPerpetualApiKeysCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusPerpetualApiKeysCheck(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusPerpetualApiKeysCheck {
+	return OctopusPerpetualApiKeysCheck{config: config, snapshot: snapshot, errorHandler: errorHandler}
 }
 
 func (o OctopusPerpetualApiKeysCheck) Id() string {
-	return "OctoLintPerpetualApiKeys"
+	return OctoLintPerpetualApiKeys
 }
 
-func (o OctopusPerpetualApiKeysCheck) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusPerpetualApiKeysCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -50,19 +39,18 @@ func (o OctopusPerpetualApiKeysCheck) Execute() (checks.OctopusCheckResult, erro
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	users, err := o.client.Users.GetAll()
+	users, err := o.snapshot.GetUsers(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
 	}
 
-	linksTemplate := regexp.MustCompile(`\{.+\}`)
 	perpetualApiKeys := []string{}
+	findings := []checks.OctopusCheckFinding{}
 	for i, u := range users {
 		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(users))*100) + "% complete")
 
-		apiKeysLink := linksTemplate.ReplaceAllString(u.Links["ApiKeys"], "")
-		keys, err := newclient.Get[resources.Resources[APIKey]](o.client.HttpSession(), apiKeysLink)
+		keys, err := o.snapshot.GetUserApiKeys(ctx, u)
 
 		if err != nil {
 			if !o.errorHandler.ShouldContinue(err) {
@@ -71,9 +59,17 @@ func (o OctopusPerpetualApiKeysCheck) Execute() (checks.OctopusCheckResult, erro
 			continue
 		}
 
-		for _, k := range keys.Items {
+		for _, k := range keys {
 			if k.Expires == nil && k.APIKey.Hint != nil && u.Username != "guest" {
 				perpetualApiKeys = append(perpetualApiKeys, *k.APIKey.Hint+"... ("+u.Username+")")
+				findings = append(findings, checks.OctopusCheckFinding{
+					ResourceType: checks.ApiKeyResource,
+					ResourceId:   k.Id,
+					ResourceName: *k.APIKey.Hint + "...",
+					UserId:       u.ID,
+					Message:      "The API key belonging to user " + u.Username + " does not expire",
+					Evidence:     u.Username,
+				})
 			}
 		}
 	}
@@ -82,15 +78,16 @@ func (o OctopusPerpetualApiKeysCheck) Execute() (checks.OctopusCheckResult, erro
 		return checks.NewOctopusCheckResultImpl(
 			"The following API keys do not expire:\n"+strings.Join(perpetualApiKeys, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Security), nil
+			checks.Security,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"No perpetual API keys found",
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Security), nil
 }
@@ -1,41 +1,40 @@
 package security
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/snapshot"
 	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
-	"net/url"
 	"strings"
 	"time"
 )
 
+const OctoLintUnrotatedAccounts = "OctoLintUnrotatedAccounts"
+
 const maxTimeSinceAccountEdit = time.Hour * 24 * 90
 
 // OctopusUnrotatedAccountsCheck checks to see if any targets have not been used in a month
 type OctopusUnrotatedAccountsCheck struct {
-	client       *client.Client
+	snapshot     snapshot.OctopusSpaceSnapshot
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
 }
 
-func NewOctopusUnrotatedAccountsCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusUnrotatedAccountsCheck {
-	return OctopusUnrotatedAccountsCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusUnrotatedAccountsCheck(snapshot snapshot.OctopusSpaceSnapshot, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusUnrotatedAccountsCheck {
+	return OctopusUnrotatedAccountsCheck{config: config, snapshot: snapshot, errorHandler: errorHandler}
 }
 
 func (o OctopusUnrotatedAccountsCheck) Id() string {
-	return "OctoLintUnrotatedAccounts"
+	return OctoLintUnrotatedAccounts
 }
 
-func (o OctopusUnrotatedAccountsCheck) Execute() (checks.OctopusCheckResult, error) {
-	if o.client == nil {
-		return nil, errors.New("octoclient is nil")
+func (o OctopusUnrotatedAccountsCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	if o.snapshot == nil {
+		return nil, errors.New("snapshot is nil")
 	}
 
 	zap.L().Debug("Starting check " + o.Id())
@@ -44,17 +43,18 @@ func (o OctopusUnrotatedAccountsCheck) Execute() (checks.OctopusCheckResult, err
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	now := time.Now()
+	now := o.snapshot.Now()
 	start := now.Add(maxTimeSinceAccountEdit * -1)
 	end := now
 
-	allAccounts, err := o.GetAll(o.client, o.client.GetSpaceID())
+	allAccounts, err := o.snapshot.GetAccounts(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
 	}
 
 	uneditedAccounts := []string{}
+	findings := []checks.OctopusCheckFinding{}
 	for i, m := range allAccounts {
 
 		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allAccounts))*100) + "% complete")
@@ -68,7 +68,7 @@ func (o OctopusUnrotatedAccountsCheck) Execute() (checks.OctopusCheckResult, err
 			continue
 		}
 
-		audits, err := newclient.Get[resources.Resources[OctopusAudit]](o.client.HttpSession(), "/api/events?regardingAny="+m.GetID()+"&from="+url.QueryEscape(start.Format("2006-01-02T15:04:05-0700"))+"&to="+url.QueryEscape(end.Format("2006-01-02T15:04:05-0700")))
+		audits, err := o.snapshot.GetAccountAudits(ctx, m.GetID(), start, end)
 
 		if err != nil {
 			if !o.errorHandler.ShouldContinue(err) {
@@ -78,7 +78,7 @@ func (o OctopusUnrotatedAccountsCheck) Execute() (checks.OctopusCheckResult, err
 		}
 
 		recentEdit := false
-		for _, t := range audits.Items {
+		for _, t := range audits {
 			if t.Category == "Modified" && slices.Index(t.RelatedDocumentIds, m.GetID()) != -1 {
 				recentEdit = true
 			}
@@ -86,6 +86,14 @@ func (o OctopusUnrotatedAccountsCheck) Execute() (checks.OctopusCheckResult, err
 
 		if !recentEdit {
 			uneditedAccounts = append(uneditedAccounts, m.GetName())
+			findings = append(findings, checks.OctopusCheckFinding{
+				ResourceType: checks.AccountResource,
+				ResourceId:   m.GetID(),
+				ResourceName: m.GetName(),
+				SpaceId:      m.GetSpaceID(),
+				Message:      "The account has not been updated in 90 days",
+				Evidence:     string(m.GetAccountType()),
+			})
 		}
 
 	}
@@ -94,25 +102,16 @@ func (o OctopusUnrotatedAccountsCheck) Execute() (checks.OctopusCheckResult, err
 		return checks.NewOctopusCheckResultImpl(
 			"The following accounts have not been updated in 90 days:\n"+strings.Join(uneditedAccounts, "\n"),
 			o.Id(),
-			"",
+			checks.DocumentationLink(o.Id()),
 			checks.Warning,
-			checks.Security), nil
+			checks.Security,
+			findings...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no unedited accounts",
 		o.Id(),
-		"",
+		checks.DocumentationLink(o.Id()),
 		checks.Ok,
 		checks.Security), nil
 }
-
-type OctopusAudit struct {
-	Category           string
-	RelatedDocumentIds []string
-}
-
-func (o OctopusUnrotatedAccountsCheck) GetAll(client newclient.Client, spaceID string) ([]*accounts.AccountResource, error) {
-	items, err := newclient.GetAll[accounts.AccountResource](client, "/api/{spaceId}/accounts", spaceID)
-	return items, err
-}
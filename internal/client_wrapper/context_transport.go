@@ -0,0 +1,21 @@
+package client_wrapper
+
+import (
+	"context"
+	"net/http"
+)
+
+// ContextTransport sends every request with the supplied context. The Octopus client does not accept a context, so
+// this is how the requests that are in flight when a scan is cancelled are stopped.
+type ContextTransport struct {
+	ctx  context.Context
+	base http.RoundTripper
+}
+
+func NewContextTransport(ctx context.Context, base http.RoundTripper) ContextTransport {
+	return ContextTransport{ctx: ctx, base: base}
+}
+
+func (o ContextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return o.base.RoundTrip(req.WithContext(o.ctx))
+}
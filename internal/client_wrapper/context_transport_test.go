@@ -0,0 +1,33 @@
+package client_wrapper
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestContextTransportCancelsRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	httpClient := &http.Client{Transport: NewContextTransport(ctx, http.DefaultTransport)}
+
+	response, err := httpClient.Get(server.URL)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	response.Body.Close()
+
+	cancel()
+
+	if _, err := httpClient.Get(server.URL); !errors.Is(err, context.Canceled) {
+		t.Fatal("Should have cancelled the request")
+	}
+}
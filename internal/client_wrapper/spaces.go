@@ -0,0 +1,115 @@
+package client_wrapper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/spaces"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// AllSpaces is the space filter that matches every space in the instance.
+const AllSpaces = "all"
+
+// GetSpaces returns all the spaces in the instance.
+func GetSpaces(httpClient *http.Client, octopusUrl string, apiKey string) ([]*spaces.Space, error) {
+	requestURL := fmt.Sprintf("%s/api/Spaces?take=2147483647", strings.TrimSuffix(octopusUrl, "/"))
+
+	req, err := http.NewRequest(http.MethodGet, requestURL, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if apiKey != "" {
+		req.Header.Set("X-Octopus-ApiKey", apiKey)
+	}
+
+	res, err := httpClient.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.New("the spaces could not be listed. The server returned status code " + fmt.Sprint(res.StatusCode))
+	}
+
+	collection := resources.Resources[*spaces.Space]{}
+	err = json.NewDecoder(res.Body).Decode(&collection)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return collection.Items, nil
+}
+
+// IsSingleSpaceId returns true if the filter references exactly one space by ID, in which case the spaces do not need
+// to be listed.
+func IsSingleSpaceId(filter string) bool {
+	filter = strings.TrimSpace(filter)
+	return strings.HasPrefix(filter, "Spaces-") && !strings.ContainsAny(filter, ",*?[")
+}
+
+// FilterSpaces returns the spaces matched by a comma separated list of space names, space IDs, or glob patterns like
+// "Team *". The filter "all" matches every space. The spaces are returned in the order they are matched, without
+// duplicates, and an error is returned if an entry matches no spaces.
+func FilterSpaces(allSpaces []*spaces.Space, filter string) ([]*spaces.Space, error) {
+	matched := []*spaces.Space{}
+	found := map[string]bool{}
+
+	for _, entry := range strings.Split(filter, ",") {
+		entry = strings.TrimSpace(entry)
+
+		if entry == "" {
+			continue
+		}
+
+		entryMatched := false
+		for _, space := range allSpaces {
+			isMatch, err := matchSpace(space, entry)
+
+			if err != nil {
+				return nil, errors.New("the space pattern \"" + entry + "\" is invalid: " + err.Error())
+			}
+
+			if !isMatch {
+				continue
+			}
+
+			entryMatched = true
+
+			if !found[space.ID] {
+				found[space.ID] = true
+				matched = append(matched, space)
+			}
+		}
+
+		if !entryMatched {
+			return nil, errors.New("did not find a space matching " + entry)
+		}
+	}
+
+	if len(matched) == 0 {
+		return nil, errors.New("space can not be empty")
+	}
+
+	return matched, nil
+}
+
+func matchSpace(space *spaces.Space, entry string) (bool, error) {
+	if strings.EqualFold(entry, AllSpaces) {
+		return true, nil
+	}
+
+	if strings.ContainsAny(entry, "*?[") {
+		return path.Match(entry, space.Name)
+	}
+
+	return space.ID == entry || space.Name == entry, nil
+}
@@ -0,0 +1,72 @@
+package client_wrapper
+
+import (
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/spaces"
+	"testing"
+)
+
+func newSpace(id string, name string) *spaces.Space {
+	space := spaces.NewSpace(name)
+	space.ID = id
+	return space
+}
+
+func testSpaces() []*spaces.Space {
+	return []*spaces.Space{
+		newSpace("Spaces-1", "Default"),
+		newSpace("Spaces-2", "Team Alpha"),
+		newSpace("Spaces-3", "Team Beta"),
+	}
+}
+
+func TestFilterSpacesByNameAndId(t *testing.T) {
+	matched, err := FilterSpaces(testSpaces(), "Spaces-3, Default")
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if len(matched) != 2 || matched[0].ID != "Spaces-3" || matched[1].ID != "Spaces-1" {
+		t.Fatal("Should have matched the spaces in the order they were listed")
+	}
+}
+
+func TestFilterSpacesByGlob(t *testing.T) {
+	matched, err := FilterSpaces(testSpaces(), "Team *,Team Alpha")
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if len(matched) != 2 || matched[0].Name != "Team Alpha" || matched[1].Name != "Team Beta" {
+		t.Fatal("Should have matched the team spaces once each")
+	}
+}
+
+func TestFilterAllSpaces(t *testing.T) {
+	matched, err := FilterSpaces(testSpaces(), "all")
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if len(matched) != 3 {
+		t.Fatal("Should have matched every space")
+	}
+}
+
+func TestFilterUnknownSpace(t *testing.T) {
+	if _, err := FilterSpaces(testSpaces(), "Default,Team Gamma"); err == nil {
+		t.Fatal("Should have returned an error for the unknown space")
+	}
+
+	if _, err := FilterSpaces(testSpaces(), ""); err == nil {
+		t.Fatal("Should have returned an error for an empty filter")
+	}
+}
+
+func TestIsSingleSpaceId(t *testing.T) {
+	if !IsSingleSpaceId("Spaces-1") || IsSingleSpaceId("Spaces-1,Spaces-2") || IsSingleSpaceId("Default") || IsSingleSpaceId("Spaces-*") {
+		t.Fatal("Should have only identified a single space ID")
+	}
+}
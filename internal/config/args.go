@@ -6,12 +6,55 @@ type OctolintConfig struct {
 	ApiKey        string
 	SkipTests     string
 	OnlyTests     string
+	Severities    StringSliceFlag
+	MinSeverity   string
+	FailOn        string
+	Baseline      string
+	WriteBaseline string
+	Suppressions  []Suppression
+	Webhooks      []Webhook
+	Score         ScoreWeights
 	VerboseErrors bool
 	Version       bool
 	Spinner       bool
 	ConfigFile    string
 	ConfigPath    string
 	Verbose       bool
+	CheckTimeout  int
+	CheckRetries  int
+	Snapshot      string
+	ExportFile    string
+	Category      string
+	Format        string
+	Output        string
+
+	// These values are used to configure the markdown report
+	MarkdownMaxFindings int
+	MarkdownMaxLength   int
+	MarkdownCollapse    bool
+
+	// ArtifactDirectory is where the octopus report saves the reports it attaches as artifacts
+	ArtifactDirectory string
+
+	// These values are used to serve the metrics endpoint
+	MetricsAddress  string
+	MetricsInterval int
+
+	// These values are used to configure the HTTP API served by the serve command
+	ServeAddress   string
+	ServeWorkers   int
+	ServeQueueSize int
+	ServeHistory   int
+	ServeToken     string
+
+	// These values are used to configure the scheduled scans of the daemon command and the trend command
+	Schedule       string
+	HistoryFile    string
+	HistoryMaxRuns int
+	TrendRuns      int
+
+	// WebhookState is the file recording the issues last sent to the webhooks with onChange enabled
+	WebhookState string
 
 	// These values are used to configure individual checks
 	MaxEnvironments                           int
@@ -0,0 +1,269 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"gopkg.in/yaml.v3"
+	"sort"
+	"strings"
+)
+
+// maxCommentLength is the length comments in the generated config file are wrapped at
+const maxCommentLength = 118
+
+// ConfigKey documents an argument that can be set in the config file.
+type ConfigKey struct {
+	Name string
+	// Default is the value used when the argument is not set
+	Default any
+	Usage   string
+	// Checks are the IDs of the checks affected by the argument
+	Checks []string
+}
+
+// structuredKey is a section of the config file that can not be set as a command line argument
+type structuredKey struct {
+	name    string
+	usage   string
+	example string
+}
+
+var structuredKeys = []structuredKey{
+	{
+		name: "suppressions",
+		usage: "Accepts the issues found by a check in the resources matched by the resourceId, or a regular expression " +
+			"matching the resourceName. The optional spaceId limits a suppression to a single space, and the optional " +
+			"expires date, in the format YYYY-MM-DD, is the last day it applies.",
+		example: `suppressions:
+  - checkId: OctoLintTooManySteps
+    resourceId: Projects-101
+    justification: The monolith is being split up this quarter
+    expires: 2024-12-31`,
+	},
+	{
+		name: "webhooks",
+		usage: "Posts the results to each url when a scan finishes. The template can be json, slack, teams, or discord. " +
+			"Environment variables like ${SLACK_WEBHOOK_PATH} are expanded in the url, headers, and secret.",
+		example: `webhooks:
+  - url: https://hooks.slack.com/services/${SLACK_WEBHOOK_PATH}
+    template: slack
+    minSeverity: warning
+    onChange: true`,
+	},
+	{
+		name: "score",
+		usage: "The weight of the issues with each severity, and the multiplier applied to the issues found by " +
+			"individual checks, when calculating the health score. A check with a weight of 0 is not scored.",
+		example: `score:
+  severities:
+    error: 10
+    warning: 5
+    info: 1
+  checks:
+    OctoLintPerpetualApiKeys: 3`,
+	},
+}
+
+// policyValue is a key set by the policy the config file is seeded from, along with the comment that preceded it
+type policyValue struct {
+	comment string
+	yaml    string
+}
+
+// GenerateConfigFile writes a config file that documents every key, along with the sections that can only be set in
+// the config file. Keys are commented out, showing their default value, unless they are set by the policy. The policy
+// is an existing config file, and the comments that precede its keys are kept. An error is returned if the policy sets
+// a key that is not supported.
+func GenerateConfigFile(keys []ConfigKey, policyName string, policy []byte) (string, error) {
+	policyValues, err := parsePolicy(keys, policy)
+
+	if err != nil {
+		return "", err
+	}
+
+	generalKeys := []ConfigKey{}
+	checkKeys := []ConfigKey{}
+	for _, key := range keys {
+		if len(key.Checks) == 0 {
+			generalKeys = append(generalKeys, key)
+		} else {
+			checkKeys = append(checkKeys, key)
+		}
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(comment("The octolint config file. Every command line argument can be set in this file with the same name. "+
+		"Keys that are commented out use their default value. Environment variables prefixed with OCTOLINT_, like "+
+		"OCTOLINT_MAXENVIRONMENTS, take precedence over this file, and command line arguments take precedence over both.", ""))
+
+	if policyName != "" {
+		builder.WriteString(comment("The keys that are not commented out were copied from "+policyName+".", ""))
+	}
+
+	for _, section := range []struct {
+		title string
+		keys  []ConfigKey
+	}{{"General settings", generalKeys}, {"Check settings", checkKeys}} {
+		builder.WriteString("\n" + comment(section.title, "") + "\n")
+
+		for _, key := range section.keys {
+			builder.WriteString(comment(key.Usage, ""))
+
+			details := []string{}
+			if len(key.Checks) != 0 {
+				details = append(details, "Affects: "+strings.Join(key.Checks, ", ")+".")
+			}
+
+			defaultValue, err := keyYaml(key.Name, key.Default)
+
+			if err != nil {
+				return "", err
+			}
+
+			details = append(details, "Default: "+strings.TrimSpace(strings.TrimPrefix(defaultValue, key.Name+":")))
+			builder.WriteString(comment(strings.Join(details, " "), ""))
+
+			if value, found := policyValues[strings.ToLower(key.Name)]; found {
+				builder.WriteString(comment(value.comment, "From the policy: "))
+				builder.WriteString(value.yaml)
+			} else {
+				builder.WriteString(commentOut(defaultValue))
+			}
+
+			builder.WriteString("\n")
+		}
+	}
+
+	builder.WriteString(comment("Sections that can only be set in the config file", "") + "\n")
+
+	for _, key := range structuredKeys {
+		builder.WriteString(comment(key.usage, ""))
+
+		if value, found := policyValues[strings.ToLower(key.name)]; found {
+			builder.WriteString(comment(value.comment, "From the policy: "))
+			builder.WriteString(value.yaml)
+		} else {
+			builder.WriteString(commentOut(key.example + "\n"))
+		}
+
+		builder.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// parsePolicy returns the keys set by the policy, keyed by their lower case name
+func parsePolicy(keys []ConfigKey, policy []byte) (map[string]policyValue, error) {
+	values := map[string]policyValue{}
+
+	if len(bytes.TrimSpace(policy)) == 0 {
+		return values, nil
+	}
+
+	document := yaml.Node{}
+	if err := yaml.Unmarshal(policy, &document); err != nil {
+		return nil, err
+	}
+
+	if len(document.Content) == 0 {
+		return values, nil
+	}
+
+	mapping := document.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return nil, errors.New("the policy must be a YAML mapping of keys to values")
+	}
+
+	names := map[string]string{}
+	for _, key := range keys {
+		names[strings.ToLower(key.Name)] = key.Name
+	}
+	for _, key := range structuredKeys {
+		names[strings.ToLower(key.name)] = key.name
+	}
+
+	unknown := []string{}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		keyNode, valueNode := mapping.Content[i], mapping.Content[i+1]
+
+		name, found := names[strings.ToLower(keyNode.Value)]
+		if !found {
+			unknown = append(unknown, keyNode.Value)
+			continue
+		}
+
+		value, err := keyYaml(name, &yaml.Node{Kind: valueNode.Kind, Style: valueNode.Style, Tag: valueNode.Tag, Value: valueNode.Value, Content: valueNode.Content})
+
+		if err != nil {
+			return nil, err
+		}
+
+		values[strings.ToLower(name)] = policyValue{comment: uncomment(keyNode.HeadComment), yaml: value}
+	}
+
+	if len(unknown) != 0 {
+		sort.Strings(unknown)
+		return nil, errors.New("the policy sets the unsupported keys " + strings.Join(unknown, ", "))
+	}
+
+	return values, nil
+}
+
+// keyYaml formats a key and its value as YAML
+func keyYaml(name string, value any) (string, error) {
+	buffer := bytes.Buffer{}
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(map[string]any{name: value}); err != nil {
+		return "", err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+// comment wraps the text in YAML comments, with the prefix before the first line
+func comment(text string, prefix string) string {
+	text = strings.TrimSpace(text)
+
+	if text == "" {
+		return ""
+	}
+
+	lines := []string{}
+	for _, paragraph := range strings.Split(prefix+text, "\n") {
+		line := "#"
+		for _, word := range strings.Fields(paragraph) {
+			if len(line)+1+len(word) > maxCommentLength && line != "#" {
+				lines = append(lines, line)
+				line = "#"
+			}
+			line += " " + word
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// commentOut prefixes each line of the YAML with a comment
+func commentOut(value string) string {
+	lines := strings.Split(strings.TrimSuffix(value, "\n"), "\n")
+	for index, line := range lines {
+		lines[index] = "# " + line
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// uncomment removes the # from each line of a YAML comment
+func uncomment(text string) string {
+	lines := strings.Split(text, "\n")
+	for index, line := range lines {
+		lines[index] = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "#"))
+	}
+	return strings.Join(lines, "\n")
+}
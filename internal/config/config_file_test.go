@@ -0,0 +1,63 @@
+package config
+
+import (
+	"gopkg.in/yaml.v3"
+	"strings"
+	"testing"
+)
+
+var testKeys = []ConfigKey{
+	{Name: "verbose", Default: false, Usage: "Print verbose logs", Checks: []string{}},
+	{Name: "maxEnvironments", Default: 20, Usage: "Maximum number of environments for the OctoLintEnvironmentCount check", Checks: []string{"OctoLintEnvironmentCount"}},
+	{Name: "skipTests", Default: "", Usage: "A comma separated list of tests to skip", Checks: []string{}},
+}
+
+func TestGenerateConfigFileCommentsOutDefaults(t *testing.T) {
+	content, err := GenerateConfigFile(testKeys, "", nil)
+
+	if err != nil {
+		t.Fatal("Should not have failed to generate the config file: " + err.Error())
+	}
+
+	if !strings.Contains(content, "# maxEnvironments: 20") || !strings.Contains(content, "# Affects: OctoLintEnvironmentCount. Default: 20") {
+		t.Fatal("Should have commented out the default value and listed the affected check")
+	}
+
+	if !strings.Contains(content, "# suppressions:") {
+		t.Fatal("Should have included an example of the suppressions section")
+	}
+
+	values := map[string]any{}
+	if err := yaml.Unmarshal([]byte(content), &values); err != nil || len(values) != 0 {
+		t.Fatal("Should have generated a config file that sets no keys")
+	}
+}
+
+func TestGenerateConfigFileFromPolicy(t *testing.T) {
+	policy := "# header\n\n# The samples have few environments\nMaxEnvironments: 5\nsuppressions:\n  - checkId: OctoLintEmptyProject\n    resourceId: Projects-1\n"
+
+	content, err := GenerateConfigFile(testKeys, "policy.yaml", []byte(policy))
+
+	if err != nil {
+		t.Fatal("Should not have failed to generate the config file: " + err.Error())
+	}
+
+	if !strings.Contains(content, "# From the policy: The samples have few environments\nmaxEnvironments: 5\n") {
+		t.Fatal("Should have copied the key and its comment from the policy")
+	}
+
+	values := map[string]any{}
+	if err := yaml.Unmarshal([]byte(content), &values); err != nil {
+		t.Fatal("Should have generated valid YAML: " + err.Error())
+	}
+
+	if values["maxEnvironments"] != 5 || len(values["suppressions"].([]any)) != 1 || len(values) != 2 {
+		t.Fatal("Should have set only the keys from the policy")
+	}
+}
+
+func TestGenerateConfigFileRejectsUnknownKeys(t *testing.T) {
+	if _, err := GenerateConfigFile(testKeys, "policy.yaml", []byte("maxEnvironments: 5\nmaxEnvironmnets: 6\n")); err == nil || !strings.Contains(err.Error(), "maxEnvironmnets") {
+		t.Fatal("Should have reported the unsupported key")
+	}
+}
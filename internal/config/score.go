@@ -0,0 +1,11 @@
+package config
+
+// ScoreWeights sets how much the issues found by the checks lower the health score. The weights are defined in the
+// score section of the config file.
+type ScoreWeights struct {
+	// Severities maps the error, warning, and info severities to the weight of an issue with that severity
+	Severities map[string]float64 `mapstructure:"severities"`
+	// Checks maps check IDs to a multiplier applied to the weight of their issues. A multiplier of 0 leaves the check
+	// out of the score.
+	Checks map[string]float64 `mapstructure:"checks"`
+}
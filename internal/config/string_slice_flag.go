@@ -0,0 +1,22 @@
+package config
+
+import "strings"
+
+// StringSliceFlag is a flag that collects every value it is set to. Each value can also be a comma separated list, so
+// the flag can be passed many times on the command line, or defined as a string or a list in the config file.
+type StringSliceFlag []string
+
+func (s *StringSliceFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *StringSliceFlag) Set(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		itemTrimmed := strings.TrimSpace(item)
+		if len(itemTrimmed) != 0 {
+			*s = append(*s, itemTrimmed)
+		}
+	}
+
+	return nil
+}
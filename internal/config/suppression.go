@@ -0,0 +1,18 @@
+package config
+
+// Suppression silences the findings of a check for the resources it matches. Suppressions are defined in the
+// suppressions section of the config file.
+type Suppression struct {
+	// CheckId is the ID of the check whose findings are suppressed
+	CheckId string `mapstructure:"checkId"`
+	// SpaceId optionally limits the suppression to a single space, as resource IDs are only unique within a space
+	SpaceId string `mapstructure:"spaceId"`
+	// ResourceId matches the ID of the resource in a finding
+	ResourceId string `mapstructure:"resourceId"`
+	// ResourceName is a regular expression that must match the whole name of the resource in a finding
+	ResourceName string `mapstructure:"resourceName"`
+	// Justification explains why the finding is accepted. It is required.
+	Justification string `mapstructure:"justification"`
+	// Expires is an optional date in the format YYYY-MM-DD after which the suppression no longer applies
+	Expires string `mapstructure:"expires"`
+}
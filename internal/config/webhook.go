@@ -0,0 +1,20 @@
+package config
+
+// Webhook is a URL the results are posted to when a scan finishes. Webhooks are defined in the webhooks section of the
+// config file. Environment variables like ${SLACK_WEBHOOK} in the url, headers, and secret are expanded, so secrets do
+// not have to be saved in the config file.
+type Webhook struct {
+	// Url is the address the results are posted to
+	Url string `mapstructure:"url"`
+	// Template is the format of the payload. It can be json, slack, teams, or discord, and defaults to json.
+	Template string `mapstructure:"template"`
+	// Headers are additional HTTP headers sent with the request, like an authorization header
+	Headers map[string]string `mapstructure:"headers"`
+	// Secret is used to sign the payload with HMAC SHA256. The signature is sent in the X-Octolint-Signature header.
+	Secret string `mapstructure:"secret"`
+	// MinSeverity only sends the results when there are issues at or above this severity. The results are always sent
+	// when it is empty.
+	MinSeverity string `mapstructure:"minSeverity"`
+	// OnChange only sends the results when the issues are different to the last results sent to the webhook
+	OnChange bool `mapstructure:"onChange"`
+}
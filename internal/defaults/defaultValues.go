@@ -25,3 +25,27 @@ const MaxInvalidNameTargets = 100
 const MaxInsecureK8sTargets = 100
 const MaxDeploymentTasks = 100
 const MaxDefaultStepNameProjects = 100
+const CheckTimeout = 600
+const CheckRetries = 3
+const MarkdownMaxFindings = 20
+const MetricsInterval = 60
+const WebhookAttempts = 3
+
+// MarkdownMaxLength keeps the markdown report under the 65536 character limit of GitHub pull request comments
+const MarkdownMaxLength = 65000
+
+// These are the weights of an issue with each severity when calculating the health score
+const ScoreErrorWeight = 10
+const ScoreWarningWeight = 5
+const ScoreInfoWeight = 1
+
+// These values configure the HTTP API served by the serve command. The API only accepts local connections by default,
+// as it runs scans with the credentials of the server.
+const ServeAddress = "127.0.0.1:8080"
+const ServeWorkers = 1
+const ServeQueueSize = 10
+const ServeHistory = 100
+
+// These values configure the history recorded by the daemon command and reported by the trend command
+const HistoryMaxRuns = 100
+const TrendRuns = 10
@@ -0,0 +1,260 @@
+package diff
+
+import (
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/samber/lo"
+	"sort"
+	"strings"
+)
+
+// OctopusFindingDiff is an issue found by one or both of the scans being compared.
+type OctopusFindingDiff struct {
+	Fingerprint  string `json:"fingerprint"`
+	SpaceId      string `json:"spaceId,omitempty"`
+	SpaceName    string `json:"spaceName,omitempty"`
+	Severity     string `json:"severity"`
+	ResourceType string `json:"resourceType,omitempty"`
+	ResourceId   string `json:"resourceId,omitempty"`
+	ResourceName string `json:"resourceName,omitempty"`
+	ProjectName  string `json:"projectName,omitempty"`
+	Message      string `json:"message,omitempty"`
+}
+
+// OctopusCheckDiff lists the issues of a single check that were found by the later scan only, by the earlier scan only,
+// and by both scans.
+type OctopusCheckDiff struct {
+	CheckId   string               `json:"checkId"`
+	New       []OctopusFindingDiff `json:"new"`
+	Resolved  []OctopusFindingDiff `json:"resolved"`
+	Unchanged []OctopusFindingDiff `json:"unchanged"`
+}
+
+// OctopusNotComparedCheck is a check whose issues could not be compared, because it failed to run or did not have
+// permission to read the space in one of the scans.
+type OctopusNotComparedCheck struct {
+	CheckId string `json:"checkId"`
+	SpaceId string `json:"spaceId,omitempty"`
+	Reason  string `json:"reason"`
+}
+
+// OctopusDiffSummary counts the issues in each state.
+type OctopusDiffSummary struct {
+	New       int `json:"new"`
+	Resolved  int `json:"resolved"`
+	Unchanged int `json:"unchanged"`
+}
+
+// OctopusReportDiff is the difference between the issues found by two scans.
+type OctopusReportDiff struct {
+	Summary     OctopusDiffSummary        `json:"summary"`
+	Checks      []OctopusCheckDiff        `json:"checks"`
+	NotCompared []OctopusNotComparedCheck `json:"notCompared"`
+}
+
+// Compare finds the issues that are new, resolved, or unchanged in the after scan. Issues are matched by their
+// fingerprint, so a finding whose message or severity changed is unchanged. Only issues at or above the minSeverity
+// are compared. Results that don't list resources are treated as a single issue for the check and space.
+func Compare(before []checks.OctopusSpaceResults, after []checks.OctopusSpaceResults, minSeverity int) OctopusReportDiff {
+	notCompared := map[string]OctopusNotComparedCheck{}
+	beforeFindings := collectFindings(before, minSeverity, notCompared, "the earlier scan")
+	afterFindings := collectFindings(after, minSeverity, notCompared, "the later scan")
+
+	diff := OctopusReportDiff{Checks: []OctopusCheckDiff{}, NotCompared: []OctopusNotComparedCheck{}}
+	checkDiffs := map[string]*OctopusCheckDiff{}
+
+	checkDiff := func(checkId string) *OctopusCheckDiff {
+		if _, found := checkDiffs[checkId]; !found {
+			checkDiffs[checkId] = &OctopusCheckDiff{
+				CheckId:   checkId,
+				New:       []OctopusFindingDiff{},
+				Resolved:  []OctopusFindingDiff{},
+				Unchanged: []OctopusFindingDiff{},
+			}
+		}
+		return checkDiffs[checkId]
+	}
+
+	for key, finding := range afterFindings {
+		if _, skipped := notCompared[comparisonKey(finding.checkId, finding.SpaceId)]; skipped {
+			continue
+		}
+
+		if _, found := beforeFindings[key]; found {
+			checkDiff(finding.checkId).Unchanged = append(checkDiff(finding.checkId).Unchanged, finding.OctopusFindingDiff)
+			diff.Summary.Unchanged++
+		} else {
+			checkDiff(finding.checkId).New = append(checkDiff(finding.checkId).New, finding.OctopusFindingDiff)
+			diff.Summary.New++
+		}
+	}
+
+	for key, finding := range beforeFindings {
+		if _, skipped := notCompared[comparisonKey(finding.checkId, finding.SpaceId)]; skipped {
+			continue
+		}
+
+		if _, found := afterFindings[key]; !found {
+			checkDiff(finding.checkId).Resolved = append(checkDiff(finding.checkId).Resolved, finding.OctopusFindingDiff)
+			diff.Summary.Resolved++
+		}
+	}
+
+	for _, checkId := range sortedKeys(checkDiffs) {
+		check := checkDiffs[checkId]
+		sortFindings(check.New)
+		sortFindings(check.Resolved)
+		sortFindings(check.Unchanged)
+		diff.Checks = append(diff.Checks, *check)
+	}
+
+	for _, key := range sortedKeys(notCompared) {
+		diff.NotCompared = append(diff.NotCompared, notCompared[key])
+	}
+
+	return diff
+}
+
+// Regressed returns true if the later scan found issues that the earlier scan did not.
+func (o OctopusReportDiff) Regressed() bool {
+	return o.Summary.New != 0
+}
+
+// String describes the differences in a human readable format. New issues are prefixed with a +, resolved issues with
+// a -, and unchanged issues with a space.
+func (o OctopusReportDiff) String() string {
+	lines := []string{}
+
+	for _, check := range o.Checks {
+		lines = append(lines, check.CheckId)
+
+		for _, f := range check.New {
+			lines = append(lines, "  + "+describeFinding(f))
+		}
+
+		for _, f := range check.Resolved {
+			lines = append(lines, "  - "+describeFinding(f))
+		}
+
+		for _, f := range check.Unchanged {
+			lines = append(lines, "    "+describeFinding(f))
+		}
+
+		lines = append(lines, "")
+	}
+
+	if len(o.NotCompared) != 0 {
+		lines = append(lines, "The following checks were not compared:")
+		for _, check := range o.NotCompared {
+			description := check.CheckId
+			if check.SpaceId != "" {
+				description += " in " + check.SpaceId
+			}
+			lines = append(lines, "  "+description+": "+check.Reason)
+		}
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, fmt.Sprintf("Summary: %d new, %d resolved, %d unchanged", o.Summary.New, o.Summary.Resolved, o.Summary.Unchanged))
+
+	return strings.Join(lines, "\n")
+}
+
+func describeFinding(finding OctopusFindingDiff) string {
+	description := "[" + finding.Severity + "] "
+
+	if finding.ResourceType == "" {
+		description += finding.Message
+	} else {
+		description += finding.ResourceType + " \"" + finding.ResourceName + "\""
+
+		if finding.ProjectName != "" && !(finding.ResourceType == checks.ProjectResource && finding.ResourceName == finding.ProjectName) {
+			description += " in " + finding.ProjectName
+		}
+
+		if finding.Message != "" {
+			description += ": " + finding.Message
+		}
+	}
+
+	if finding.SpaceName != "" {
+		description += " (" + finding.SpaceName + ")"
+	}
+
+	return description
+}
+
+// collectedFinding is a finding and the check that reported it
+type collectedFinding struct {
+	OctopusFindingDiff
+	checkId string
+}
+
+// collectFindings returns the findings of the issues at or above the minSeverity, keyed by their fingerprint. The checks
+// that could not be compared are added to notCompared.
+func collectFindings(spaces []checks.OctopusSpaceResults, minSeverity int, notCompared map[string]OctopusNotComparedCheck, scan string) map[string]collectedFinding {
+	findings := map[string]collectedFinding{}
+
+	for _, space := range spaces {
+		for _, r := range space.Results {
+			switch {
+			case r.Category() == checks.GeneralError:
+				notCompared[comparisonKey(r.Code(), space.SpaceId)] = OctopusNotComparedCheck{CheckId: r.Code(), SpaceId: space.SpaceId, Reason: "the check failed to run in " + scan}
+				continue
+			case r.Severity() == checks.Permission:
+				notCompared[comparisonKey(r.Code(), space.SpaceId)] = OctopusNotComparedCheck{CheckId: r.Code(), SpaceId: space.SpaceId, Reason: "the check did not have permission to read the space in " + scan}
+				continue
+			case r.Severity() < max(minSeverity, checks.Info):
+				continue
+			}
+
+			resultFindings := r.Findings()
+			if len(resultFindings) == 0 {
+				// The message is the first line of the description, which summarises the issue
+				resultFindings = []checks.OctopusCheckFinding{{Message: strings.SplitN(r.Description(), "\n", 2)[0]}}
+			}
+
+			for _, f := range resultFindings {
+				if f.SpaceId == "" {
+					f.SpaceId = space.SpaceId
+				}
+
+				fingerprint := checks.Fingerprint(r.Code(), f)
+
+				findings[fingerprint] = collectedFinding{
+					checkId: r.Code(),
+					OctopusFindingDiff: OctopusFindingDiff{
+						Fingerprint:  fingerprint,
+						SpaceId:      f.SpaceId,
+						SpaceName:    space.SpaceName,
+						Severity:     checks.SeverityName(r.Severity()),
+						ResourceType: f.ResourceType,
+						ResourceId:   f.ResourceId,
+						ResourceName: f.ResourceName,
+						ProjectName:  f.ProjectName,
+						Message:      f.Message,
+					},
+				}
+			}
+		}
+	}
+
+	return findings
+}
+
+func comparisonKey(checkId string, spaceId string) string {
+	return checkId + "/" + spaceId
+}
+
+func sortFindings(findings []OctopusFindingDiff) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		return strings.Join([]string{findings[i].SpaceName, findings[i].ProjectName, findings[i].ResourceType, findings[i].ResourceName, findings[i].Fingerprint}, "\x00") <
+			strings.Join([]string{findings[j].SpaceName, findings[j].ProjectName, findings[j].ResourceType, findings[j].ResourceName, findings[j].Fingerprint}, "\x00")
+	})
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := lo.Keys(values)
+	sort.Strings(keys)
+	return keys
+}
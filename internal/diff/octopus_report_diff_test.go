@@ -0,0 +1,77 @@
+package diff
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"strings"
+	"testing"
+)
+
+func emptyProjects(projects ...string) checks.OctopusCheckResult {
+	findings := []checks.OctopusCheckFinding{}
+	for _, project := range projects {
+		findings = append(findings, checks.OctopusCheckFinding{ResourceType: checks.ProjectResource, ResourceId: project, ResourceName: project, ProjectName: project})
+	}
+
+	severity := checks.Ok
+	if len(findings) != 0 {
+		severity = checks.Warning
+	}
+
+	return checks.NewOctopusCheckResultImpl("The following projects are empty", "OctoLintEmptyProject", "", severity, checks.Organization, findings...)
+}
+
+func space(results ...checks.OctopusCheckResult) []checks.OctopusSpaceResults {
+	return []checks.OctopusSpaceResults{{SpaceId: "Spaces-1", SpaceName: "Default", Results: results}}
+}
+
+func TestCompare(t *testing.T) {
+	tooManyEnvironments := checks.NewOctopusCheckResultImpl("There are 30 environments\nReduce the number of environments", "OctoLintEnvironmentCount", "", checks.Warning, checks.Organization)
+
+	diff := Compare(
+		space(emptyProjects("Projects-1", "Projects-2"), tooManyEnvironments),
+		space(emptyProjects("Projects-2", "Projects-3"), tooManyEnvironments),
+		checks.Warning)
+
+	if diff.Summary.New != 1 || diff.Summary.Resolved != 1 || diff.Summary.Unchanged != 2 {
+		t.Fatalf("Unexpected summary %v", diff.Summary)
+	}
+
+	if len(diff.Checks) != 2 || diff.Checks[0].CheckId != "OctoLintEmptyProject" {
+		t.Fatal("Should have grouped the findings by check")
+	}
+
+	if diff.Checks[0].New[0].ResourceId != "Projects-3" || diff.Checks[0].Resolved[0].ResourceId != "Projects-1" {
+		t.Fatal("Should have found the new and resolved projects")
+	}
+
+	if diff.Checks[1].Unchanged[0].Message != "There are 30 environments" {
+		t.Fatal("Should have compared the result without findings as a single issue")
+	}
+
+	if !diff.Regressed() {
+		t.Fatal("Should have regressed")
+	}
+
+	text := diff.String()
+	if !strings.Contains(text, "  + [Warning] Project \"Projects-3\" (Default)") || !strings.Contains(text, "Summary: 1 new, 1 resolved, 2 unchanged") {
+		t.Fatal("Unexpected text " + text)
+	}
+}
+
+func TestCompareResolved(t *testing.T) {
+	diff := Compare(space(emptyProjects("Projects-1")), space(emptyProjects()), checks.Warning)
+
+	if diff.Regressed() || diff.Summary.Resolved != 1 {
+		t.Fatal("Should have resolved the finding without regressing")
+	}
+}
+
+func TestChecksThatFailedAreNotCompared(t *testing.T) {
+	failed := checks.NewOctopusCheckResultImpl("The check failed to run: timeout", "OctoLintEmptyProject", "", checks.Error, checks.GeneralError)
+
+	diff := Compare(space(emptyProjects("Projects-1")), space(failed), checks.Warning)
+
+	if diff.Summary.Resolved != 0 || len(diff.NotCompared) != 1 || diff.NotCompared[0].CheckId != "OctoLintEmptyProject" {
+		t.Fatal("Should not have reported the findings of a check that failed to run as resolved")
+	}
+}
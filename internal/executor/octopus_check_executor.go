@@ -2,72 +2,182 @@ package executor
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/avast/retry-go/v4"
+	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+	"time"
 )
 
 const ParallelTasks = 15
 
+// ErrCheckTimeout is returned when a check did not complete within the configured timeout.
+var ErrCheckTimeout = errors.New("the check timed out")
+
 // OctopusCheckExecutor is responsible for running each lint check and returning the results. It deals with things
-// like retries and error handling.
+// like timeouts, retries and error handling.
 type OctopusCheckExecutor struct {
+	// timeout is the maximum time each check can run for. A value of 0 disables the timeout.
+	timeout time.Duration
+	// attempts is the number of times a check that failed with a transient error is run.
+	attempts uint
+	// delay is the initial delay between attempts, which is increased with each retry.
+	delay time.Duration
+}
+
+func NewOctopusCheckExecutor(timeout time.Duration, attempts uint) OctopusCheckExecutor {
+	return OctopusCheckExecutor{timeout: timeout, attempts: attempts, delay: time.Second}
 }
 
-func NewOctopusCheckExecutor() OctopusCheckExecutor {
-	return OctopusCheckExecutor{}
+// ExecuteChecks executes each check and collects the results. The results are returned in the same order as the
+// checks were supplied. If the context is cancelled, the results of the checks that completed are returned along with
+// the context error, allowing a partial report to be generated.
+func (o OctopusCheckExecutor) ExecuteChecks(ctx context.Context, checkCollection []checks.OctopusCheck, handleError func(checks.OctopusCheck, error) error) ([]checks.OctopusCheckResult, error) {
+	results, _, err := o.ExecuteTimedChecks(ctx, checkCollection, handleError)
+	return results, err
 }
 
-// ExecuteChecks executes each check and collects the results.
-func (o OctopusCheckExecutor) ExecuteChecks(checkCollection []checks.OctopusCheck, handleError func(checks.OctopusCheck, error) error) ([]checks.OctopusCheckResult, error) {
+// ExecuteTimedChecks executes each check like ExecuteChecks, and also returns the time each check took to run, keyed by
+// the check ID. The time includes any retries.
+func (o OctopusCheckExecutor) ExecuteTimedChecks(ctx context.Context, checkCollection []checks.OctopusCheck, handleError func(checks.OctopusCheck, error) error) ([]checks.OctopusCheckResult, map[string]time.Duration, error) {
 	if checkCollection == nil || len(checkCollection) == 0 {
-		return []checks.OctopusCheckResult{}, nil
+		return []checks.OctopusCheckResult{}, map[string]time.Duration{}, nil
 	}
 
-	checkResults := []checks.OctopusCheckResult{}
+	// Each goroutine writes to its own index, so no lock is required, and the results retain the order of the checks
+	checkResults := make([]checks.OctopusCheckResult, len(checkCollection))
+	checkDurations := make([]time.Duration, len(checkCollection))
 
-	g, _ := errgroup.WithContext(context.Background())
+	g, groupCtx := errgroup.WithContext(ctx)
 	g.SetLimit(ParallelTasks)
 
-	for _, c := range checkCollection {
+	for i, c := range checkCollection {
+		if groupCtx.Err() != nil {
+			break
+		}
+
+		i := i
 		c := c
 		g.Go(func() error {
-			err := retry.Do(
-				func() error {
-					result, err := c.Execute()
-
-					if err != nil {
-						checkResults = append(
-							checkResults,
-							checks.NewOctopusCheckResultImpl(
-								"The check failed to run: "+err.Error(),
-								c.Id(),
-								"",
-								checks.Error,
-								checks.GeneralError))
-					}
-
-					if result != nil {
-						checkResults = append(checkResults, result)
-					}
+			// Don't start new checks once the scan has been cancelled
+			if groupCtx.Err() != nil {
+				return nil
+			}
 
-					return nil
-				}, retry.Attempts(3))
+			start := time.Now()
+			result, err := o.executeCheck(groupCtx, c)
+			checkDurations[i] = time.Since(start)
 
 			if err != nil {
-				err := handleError(c, err)
-				if err != nil {
-					return err
+				// Checks that were interrupted by a cancellation are simply left out of the report
+				if groupCtx.Err() != nil && !errors.Is(err, ErrCheckTimeout) {
+					return nil
 				}
+
+				checkResults[i] = checks.NewOctopusCheckResultImpl(
+					"The check failed to run: "+err.Error(),
+					c.Id(),
+					checks.DocumentationLink(c.Id()),
+					checks.Error,
+					checks.GeneralError)
+
+				return handleError(c, err)
 			}
 
+			checkResults[i] = result
+
 			return nil
 		})
 	}
 
-	if err := g.Wait(); err != nil {
-		return nil, err
+	waitErr := g.Wait()
+
+	completedResults := []checks.OctopusCheckResult{}
+	durations := map[string]time.Duration{}
+	for i, r := range checkResults {
+		if r != nil {
+			completedResults = append(completedResults, r)
+			durations[r.Code()] = checkDurations[i]
+		}
+	}
+
+	if waitErr != nil {
+		return nil, nil, waitErr
+	}
+
+	if ctx.Err() != nil {
+		return completedResults, durations, ctx.Err()
+	}
+
+	return completedResults, durations, nil
+}
+
+// executeCheck runs a check, retrying it if it failed with a transient error.
+func (o OctopusCheckExecutor) executeCheck(ctx context.Context, check checks.OctopusCheck) (checks.OctopusCheckResult, error) {
+	attempts := o.attempts
+	if attempts < 1 {
+		// retry-go treats 0 attempts as "retry forever"
+		attempts = 1
+	}
+
+	return retry.DoWithData(
+		func() (checks.OctopusCheckResult, error) {
+			return o.executeCheckWithTimeout(ctx, check)
+		},
+		retry.Context(ctx),
+		retry.Attempts(attempts),
+		retry.Delay(o.delay),
+		retry.DelayType(retry.BackOffDelay),
+		retry.LastErrorOnly(true),
+		retry.RetryIf(checks.IsTransientError),
+		retry.OnRetry(func(n uint, err error) {
+			zap.L().Debug("Retrying check " + check.Id() + " after a transient error: " + err.Error())
+		}))
+}
+
+// executeCheckWithTimeout runs a check with a context that is done when the timeout is exceeded or the scan is
+// cancelled, which stops the check from reading any more of the space. The check runs in a goroutine so the executor
+// also stops waiting for a check that does not return promptly, in which case its result is ignored.
+func (o OctopusCheckExecutor) executeCheckWithTimeout(ctx context.Context, check checks.OctopusCheck) (checks.OctopusCheckResult, error) {
+	checkCtx := ctx
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		checkCtx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	type checkOutcome struct {
+		result checks.OctopusCheckResult
+		err    error
+	}
+
+	// The channel is buffered so an abandoned check does not block forever when it eventually completes
+	outcome := make(chan checkOutcome, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				outcome <- checkOutcome{err: fmt.Errorf("the check panicked: %v", r)}
+			}
+		}()
+
+		result, err := check.Execute(checkCtx)
+		outcome <- checkOutcome{result: result, err: err}
+	}()
+
+	select {
+	case out := <-outcome:
+		// A check that returned an error after its context was done was stopped by the timeout or cancellation
+		if out.err == nil || checkCtx.Err() == nil {
+			return out.result, out.err
+		}
+	case <-checkCtx.Done():
 	}
 
-	return checkResults, nil
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return nil, fmt.Errorf("%w after %s", ErrCheckTimeout, o.timeout)
 }
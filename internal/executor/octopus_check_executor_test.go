@@ -1,14 +1,24 @@
 package executor
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"net"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"syscall"
 	"testing"
+	"time"
 )
 
 type alwaysFailCheck struct {
 }
 
-func (o alwaysFailCheck) Execute() (checks.OctopusCheckResult, error) {
+func (o alwaysFailCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
 	return checks.NewOctopusCheckResultImpl("This check always fails", o.Id(), "", checks.Error, ""), nil
 }
 
@@ -19,7 +29,7 @@ func (o alwaysFailCheck) Id() string {
 type alwaysPassCheck struct {
 }
 
-func (o alwaysPassCheck) Execute() (checks.OctopusCheckResult, error) {
+func (o alwaysPassCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
 	return checks.NewOctopusCheckResultImpl("This check passed ok", o.Id(), "", checks.Ok, ""), nil
 }
 
@@ -27,10 +37,80 @@ func (o alwaysPassCheck) Id() string {
 	return "OctoRecAlwaysPass"
 }
 
+// erroringCheck returns the supplied error for the first failures attempts, and then passes
+type erroringCheck struct {
+	err      error
+	failures int32
+	attempts *int32
+}
+
+func (o erroringCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	attempt := atomic.AddInt32(o.attempts, 1)
+	if attempt <= o.failures {
+		return nil, o.err
+	}
+	return checks.NewOctopusCheckResultImpl("This check passed ok", o.Id(), "", checks.Ok, ""), nil
+}
+
+func (o erroringCheck) Id() string {
+	return "OctoRecErroring"
+}
+
+// handledErrorCheck passes the error returned by the first failures attempts to the permissive error handler, like the
+// checks that query the Octopus API, and then passes
+type handledErrorCheck struct {
+	erroringCheck
+}
+
+func (o handledErrorCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	result, err := o.erroringCheck.Execute(ctx)
+	if err != nil {
+		return checks.OctopusClientPermissiveErrorHandler{}.HandleError(o.Id(), checks.Organization, err)
+	}
+	return result, nil
+}
+
+// sleepingCheck takes the supplied duration to complete, or stops when the context is done
+type sleepingCheck struct {
+	id    string
+	sleep time.Duration
+}
+
+func (o sleepingCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	select {
+	case <-time.After(o.sleep):
+		return checks.NewOctopusCheckResultImpl("This check passed ok", o.Id(), "", checks.Ok, ""), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (o sleepingCheck) Id() string {
+	return o.id
+}
+
+// waitingCheck waits for its context to be done, and passes the context error to the permissive error handler like the
+// checks that query the Octopus API
+type waitingCheck struct {
+	stopped chan struct{}
+}
+
+func (o waitingCheck) Execute(ctx context.Context) (checks.OctopusCheckResult, error) {
+	<-ctx.Done()
+	close(o.stopped)
+	return checks.OctopusClientPermissiveErrorHandler{}.HandleError(o.Id(), checks.Organization, ctx.Err())
+}
+
+func (o waitingCheck) Id() string {
+	return "OctoRecWaiting"
+}
+
+func ignoreErrors(check checks.OctopusCheck, err error) error {
+	return nil
+}
+
 func TestNoChecks(t *testing.T) {
-	results, err := OctopusCheckExecutor{}.ExecuteChecks(nil, func(check checks.OctopusCheck, err error) error {
-		return nil
-	})
+	results, err := OctopusCheckExecutor{}.ExecuteChecks(context.Background(), nil, ignoreErrors)
 
 	if err != nil {
 		t.Fatal("Should not have returned an error")
@@ -42,9 +122,7 @@ func TestNoChecks(t *testing.T) {
 }
 
 func TestFailChecks(t *testing.T) {
-	results, err := OctopusCheckExecutor{}.ExecuteChecks([]checks.OctopusCheck{alwaysFailCheck{}}, func(check checks.OctopusCheck, err error) error {
-		return nil
-	})
+	results, err := OctopusCheckExecutor{}.ExecuteChecks(context.Background(), []checks.OctopusCheck{alwaysFailCheck{}}, ignoreErrors)
 
 	if err != nil {
 		t.Fatal("Should not have returned an error")
@@ -56,9 +134,7 @@ func TestFailChecks(t *testing.T) {
 }
 
 func TestFailAndPassChecks(t *testing.T) {
-	results, err := OctopusCheckExecutor{}.ExecuteChecks([]checks.OctopusCheck{alwaysFailCheck{}, alwaysPassCheck{}}, func(check checks.OctopusCheck, err error) error {
-		return nil
-	})
+	results, err := OctopusCheckExecutor{}.ExecuteChecks(context.Background(), []checks.OctopusCheck{alwaysFailCheck{}, alwaysPassCheck{}}, ignoreErrors)
 
 	if err != nil {
 		t.Fatal("Should not have returned an error")
@@ -68,3 +144,192 @@ func TestFailAndPassChecks(t *testing.T) {
 		t.Fatal("Should have returned 2 results")
 	}
 }
+
+func TestResultsAreOrdered(t *testing.T) {
+	checkCollection := []checks.OctopusCheck{}
+	for i := 0; i < ParallelTasks*2; i++ {
+		checkCollection = append(checkCollection, sleepingCheck{
+			id:    fmt.Sprint(i),
+			sleep: time.Duration((ParallelTasks*2-i)%7) * time.Millisecond,
+		})
+	}
+
+	results, err := OctopusCheckExecutor{}.ExecuteChecks(context.Background(), checkCollection, ignoreErrors)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	for i, r := range results {
+		if r.Code() != fmt.Sprint(i) {
+			t.Fatal("Results should have been returned in the same order as the checks")
+		}
+	}
+}
+
+func TestTransientErrorsAreRetried(t *testing.T) {
+	attempts := int32(0)
+	check := erroringCheck{
+		err:      &core.APIError{StatusCode: 503},
+		failures: 2,
+		attempts: &attempts,
+	}
+
+	results, err := OctopusCheckExecutor{attempts: 3}.ExecuteChecks(context.Background(), []checks.OctopusCheck{check}, ignoreErrors)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if attempts != 3 {
+		t.Fatalf("Should have attempted the check 3 times, but attempted %d times", attempts)
+	}
+
+	if len(results) != 1 || results[0].Severity() != checks.Ok {
+		t.Fatal("Should have returned the passing result")
+	}
+}
+
+func TestConnectionResetsAreRetried(t *testing.T) {
+	attempts := int32(0)
+	check := handledErrorCheck{erroringCheck{
+		err:      &url.Error{Op: "Get", URL: "https://example.octopus.app/api", Err: &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}},
+		failures: 1,
+		attempts: &attempts,
+	}}
+
+	results, err := OctopusCheckExecutor{attempts: 3}.ExecuteChecks(context.Background(), []checks.OctopusCheck{check}, ignoreErrors)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if attempts != 2 {
+		t.Fatalf("Should have attempted the check 2 times, but attempted %d times", attempts)
+	}
+
+	if len(results) != 1 || results[0].Severity() != checks.Ok {
+		t.Fatal("Should have returned the passing result rather than a permission error")
+	}
+}
+
+func TestPermissionErrorsAreNotRetried(t *testing.T) {
+	attempts := int32(0)
+	check := erroringCheck{
+		err:      &core.APIError{StatusCode: 403},
+		failures: 2,
+		attempts: &attempts,
+	}
+
+	handledErrors := 0
+	results, err := OctopusCheckExecutor{attempts: 3}.ExecuteChecks(context.Background(), []checks.OctopusCheck{check}, func(check checks.OctopusCheck, err error) error {
+		handledErrors++
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if attempts != 1 {
+		t.Fatalf("Should have attempted the check once, but attempted %d times", attempts)
+	}
+
+	if handledErrors != 1 {
+		t.Fatal("Should have passed the error to the error handler")
+	}
+
+	if len(results) != 1 || results[0].Category() != checks.GeneralError {
+		t.Fatal("Should have returned a general error result")
+	}
+}
+
+func TestCheckTimeout(t *testing.T) {
+	checkCollection := []checks.OctopusCheck{
+		sleepingCheck{id: "slow", sleep: time.Second},
+		alwaysPassCheck{},
+	}
+
+	var handledError error
+	results, err := OctopusCheckExecutor{timeout: 50 * time.Millisecond, attempts: 3}.ExecuteChecks(context.Background(), checkCollection, func(check checks.OctopusCheck, err error) error {
+		handledError = err
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if !errors.Is(handledError, ErrCheckTimeout) {
+		t.Fatal("Should have reported the timeout to the error handler")
+	}
+
+	if len(results) != 2 || results[0].Category() != checks.GeneralError || results[1].Severity() != checks.Ok {
+		t.Fatal("Should have returned the timed out check as an error and the passing check")
+	}
+}
+
+func TestCheckContextIsDoneAfterTimeout(t *testing.T) {
+	check := waitingCheck{stopped: make(chan struct{})}
+
+	var handledError error
+	results, err := OctopusCheckExecutor{timeout: 50 * time.Millisecond, attempts: 1}.ExecuteChecks(context.Background(), []checks.OctopusCheck{check}, func(check checks.OctopusCheck, err error) error {
+		handledError = err
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	select {
+	case <-check.stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Should have stopped the check when it timed out")
+	}
+
+	if !errors.Is(handledError, ErrCheckTimeout) || len(results) != 1 || results[0].Category() != checks.GeneralError {
+		t.Fatal("Should have reported the timeout rather than a permission error")
+	}
+}
+
+func TestCancellationReturnsPartialResults(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	checkCollection := []checks.OctopusCheck{
+		alwaysPassCheck{},
+		sleepingCheck{id: "slow", sleep: 5 * time.Second},
+	}
+
+	results, err := OctopusCheckExecutor{}.ExecuteChecks(ctx, checkCollection, ignoreErrors)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("Should have returned the context error")
+	}
+
+	if len(results) != 1 || results[0].Code() != "OctoRecAlwaysPass" {
+		t.Fatal("Should have returned the result of the completed check")
+	}
+}
+
+func TestCheckDurations(t *testing.T) {
+	checkCollection := []checks.OctopusCheck{
+		sleepingCheck{id: "Fast"},
+		sleepingCheck{id: "Slow", sleep: 50 * time.Millisecond},
+	}
+
+	results, durations, err := OctopusCheckExecutor{}.ExecuteTimedChecks(context.Background(), checkCollection, ignoreErrors)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if len(results) != 2 || len(durations) != 2 {
+		t.Fatal("Should have timed each check")
+	}
+
+	if durations["Slow"] < 50*time.Millisecond || durations["Fast"] >= durations["Slow"] {
+		t.Fatal("Should have recorded how long each check took")
+	}
+}
@@ -0,0 +1,242 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"os"
+	"strings"
+	"time"
+)
+
+// HistoryVersion is the version of the history file format. It is incremented when a change to the format means older
+// versions of octolint can no longer read the file.
+const HistoryVersion = 1
+
+// OctopusHistory records the results of scheduled scans, so the findings of each check can be compared over time. The
+// latest runs are kept in full, while the findings record when each issue was first found and when it was resolved.
+type OctopusHistory struct {
+	Version  int                     `json:"version"`
+	Runs     []OctopusHistoryRun     `json:"runs"`
+	Findings []OctopusFindingHistory `json:"findings"`
+}
+
+// OctopusHistoryRun is a single scan.
+type OctopusHistoryRun struct {
+	Id        int                        `json:"id"`
+	StartTime time.Time                  `json:"startTime"`
+	EndTime   time.Time                  `json:"endTime"`
+	Summary   checks.OctopusCheckSummary `json:"summary"`
+	Checks    []OctopusHistoryCheck      `json:"checks"`
+}
+
+// OctopusHistoryCheck is the result of a check in a single space.
+type OctopusHistoryCheck struct {
+	CheckId   string `json:"checkId"`
+	SpaceId   string `json:"spaceId,omitempty"`
+	SpaceName string `json:"spaceName,omitempty"`
+	Severity  string `json:"severity"`
+	// Findings is the number of issues found by the check. A result that does not list resources counts as one.
+	Findings int `json:"findings"`
+	// Failed is true if the check failed to run or did not have permission to read the space, in which case the issues
+	// it found in previous runs are neither found nor resolved
+	Failed bool `json:"failed,omitempty"`
+}
+
+// OctopusFindingHistory is an issue found by one or more runs.
+type OctopusFindingHistory struct {
+	Fingerprint  string    `json:"fingerprint"`
+	CheckId      string    `json:"checkId"`
+	SpaceId      string    `json:"spaceId,omitempty"`
+	SpaceName    string    `json:"spaceName,omitempty"`
+	Severity     string    `json:"severity"`
+	ResourceType string    `json:"resourceType,omitempty"`
+	ResourceId   string    `json:"resourceId,omitempty"`
+	ResourceName string    `json:"resourceName,omitempty"`
+	ProjectName  string    `json:"projectName,omitempty"`
+	Message      string    `json:"message,omitempty"`
+	FirstSeen    time.Time `json:"firstSeen"`
+	FirstRun     int       `json:"firstRun"`
+	LastSeen     time.Time `json:"lastSeen"`
+	LastRun      int       `json:"lastRun"`
+	// Resolved is the time of the first run that did not find an issue that was found by the run before it. An issue
+	// that is found again is open, and keeps the time it was first seen.
+	Resolved    *time.Time `json:"resolved,omitempty"`
+	ResolvedRun int        `json:"resolvedRun,omitempty"`
+}
+
+// NewOctopusHistory creates a history with no runs.
+func NewOctopusHistory() OctopusHistory {
+	return OctopusHistory{
+		Version:  HistoryVersion,
+		Runs:     []OctopusHistoryRun{},
+		Findings: []OctopusFindingHistory{},
+	}
+}
+
+// Record adds a run to the history, recording the issues at or above the info severity regardless of the minSeverity
+// of the report. Issues that were open and not found by a check that ran successfully in the same space are resolved.
+// Issues are timestamped with the time the run started. Only the latest maxRuns runs are kept, along with the issues
+// that are open or were resolved by the runs that are kept.
+func (o *OctopusHistory) Record(spaces []checks.OctopusSpaceResults, startTime time.Time, endTime time.Time, maxRuns int) OctopusHistoryRun {
+	run := OctopusHistoryRun{
+		Id:        1,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Summary:   checks.NewOctopusCheckSummary(checks.AllResults(spaces)),
+		Checks:    []OctopusHistoryCheck{},
+	}
+
+	if len(o.Runs) != 0 {
+		run.Id = o.Runs[len(o.Runs)-1].Id + 1
+	}
+
+	findings := map[string]int{}
+	for index, finding := range o.Findings {
+		findings[finding.Fingerprint] = index
+	}
+
+	found := map[string]bool{}
+	completed := map[string]bool{}
+
+	for _, space := range spaces {
+		for _, r := range space.Results {
+			check := OctopusHistoryCheck{
+				CheckId:   r.Code(),
+				SpaceId:   space.SpaceId,
+				SpaceName: space.SpaceName,
+				Severity:  checks.SeverityName(r.Severity()),
+				Failed:    r.Category() == checks.GeneralError || r.Severity() == checks.Permission,
+			}
+
+			if !check.Failed {
+				completed[checkKey(r.Code(), space.SpaceId)] = true
+			}
+
+			if check.Failed || r.Severity() < checks.Info {
+				run.Checks = append(run.Checks, check)
+				continue
+			}
+
+			resultFindings := r.Findings()
+			if len(resultFindings) == 0 {
+				// The message is the first line of the description, which summarises the issue
+				resultFindings = []checks.OctopusCheckFinding{{Message: strings.SplitN(r.Description(), "\n", 2)[0]}}
+			}
+
+			for _, f := range resultFindings {
+				if f.SpaceId == "" {
+					f.SpaceId = space.SpaceId
+				}
+
+				fingerprint := checks.Fingerprint(r.Code(), f)
+
+				// Some checks report the same resource more than once, like a variable duplicated in many projects
+				if found[fingerprint] {
+					continue
+				}
+				found[fingerprint] = true
+				check.Findings++
+
+				finding := OctopusFindingHistory{
+					Fingerprint:  fingerprint,
+					CheckId:      r.Code(),
+					SpaceId:      f.SpaceId,
+					SpaceName:    space.SpaceName,
+					Severity:     check.Severity,
+					ResourceType: f.ResourceType,
+					ResourceId:   f.ResourceId,
+					ResourceName: f.ResourceName,
+					ProjectName:  f.ProjectName,
+					Message:      f.Message,
+					FirstSeen:    startTime,
+					FirstRun:     run.Id,
+				}
+
+				if index, existing := findings[fingerprint]; existing {
+					finding.FirstSeen = o.Findings[index].FirstSeen
+					finding.FirstRun = o.Findings[index].FirstRun
+				} else {
+					findings[fingerprint] = len(o.Findings)
+					o.Findings = append(o.Findings, finding)
+				}
+
+				finding.LastSeen = startTime
+				finding.LastRun = run.Id
+				o.Findings[findings[fingerprint]] = finding
+			}
+
+			run.Checks = append(run.Checks, check)
+		}
+	}
+
+	for index, finding := range o.Findings {
+		if finding.Resolved == nil && !found[finding.Fingerprint] && completed[checkKey(finding.CheckId, finding.SpaceId)] {
+			resolved := startTime
+			o.Findings[index].Resolved = &resolved
+			o.Findings[index].ResolvedRun = run.Id
+		}
+	}
+
+	o.Runs = append(o.Runs, run)
+
+	if maxRuns > 0 && len(o.Runs) > maxRuns {
+		o.Runs = o.Runs[len(o.Runs)-maxRuns:]
+	}
+
+	// Issues resolved before the oldest run that is kept are forgotten
+	oldestRun := o.Runs[0].Id
+	kept := []OctopusFindingHistory{}
+	for _, finding := range o.Findings {
+		if finding.Resolved == nil || finding.ResolvedRun >= oldestRun {
+			kept = append(kept, finding)
+		}
+	}
+	o.Findings = kept
+
+	return run
+}
+
+// ReadHistory loads a history saved by WriteHistory.
+func ReadHistory(path string) (OctopusHistory, error) {
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return OctopusHistory{}, err
+	}
+
+	history := OctopusHistory{}
+	if err := json.Unmarshal(content, &history); err != nil {
+		return OctopusHistory{}, err
+	}
+
+	if history.Version < 1 || history.Version > HistoryVersion {
+		return OctopusHistory{}, errors.New("the history version " + fmt.Sprint(history.Version) +
+			" is not supported. This version of octolint supports history versions up to " + fmt.Sprint(HistoryVersion))
+	}
+
+	return history, nil
+}
+
+// WriteHistory saves the history to a JSON file. The history is written to a temporary file and then renamed, so a
+// scan that is stopped while the file is written does not lose the previous runs.
+func WriteHistory(history OctopusHistory, path string) error {
+	content, err := json.MarshalIndent(history, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	temporaryFile := path + ".tmp"
+
+	if err := os.WriteFile(temporaryFile, content, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(temporaryFile, path)
+}
+
+func checkKey(checkId string, spaceId string) string {
+	return checkId + "/" + spaceId
+}
@@ -0,0 +1,158 @@
+package history
+
+import (
+	"encoding/json"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var firstRun = time.Date(2026, 10, 1, 2, 0, 0, 0, time.UTC)
+
+func emptyProjects(projects ...string) checks.OctopusCheckResult {
+	findings := []checks.OctopusCheckFinding{}
+	for _, project := range projects {
+		findings = append(findings, checks.OctopusCheckFinding{ResourceType: checks.ProjectResource, ResourceId: project, ResourceName: project, ProjectName: project})
+	}
+
+	severity := checks.Ok
+	if len(findings) != 0 {
+		severity = checks.Warning
+	}
+
+	return checks.NewOctopusCheckResultImpl("The following projects are empty", "OctoLintEmptyProject", "", severity, checks.Organization, findings...)
+}
+
+func space(results ...checks.OctopusCheckResult) []checks.OctopusSpaceResults {
+	return []checks.OctopusSpaceResults{{SpaceId: "Spaces-1", SpaceName: "Default", Results: results}}
+}
+
+// record adds each scan to a new history, one day apart
+func record(scans ...[]checks.OctopusSpaceResults) OctopusHistory {
+	history := NewOctopusHistory()
+	for index, scan := range scans {
+		startTime := firstRun.AddDate(0, 0, index)
+		history.Record(scan, startTime, startTime.Add(time.Minute), 10)
+	}
+	return history
+}
+
+func findingFor(history OctopusHistory, project string) *OctopusFindingHistory {
+	for _, finding := range history.Findings {
+		if finding.ResourceId == project {
+			return &finding
+		}
+	}
+	return nil
+}
+
+func TestFindingsAreFirstSeenAndResolved(t *testing.T) {
+	history := record(
+		space(emptyProjects("Projects-1")),
+		space(emptyProjects("Projects-1", "Projects-2")),
+		space(emptyProjects("Projects-2")),
+	)
+
+	first := findingFor(history, "Projects-1")
+	if first == nil || first.FirstRun != 1 || first.ResolvedRun != 3 || !first.Resolved.Equal(firstRun.AddDate(0, 0, 2)) {
+		t.Fatal("Should have recorded that Projects-1 was found by the first run and resolved by the third run")
+	}
+
+	second := findingFor(history, "Projects-2")
+	if second == nil || second.FirstRun != 2 || second.Resolved != nil || second.LastRun != 3 {
+		t.Fatal("Should have recorded that Projects-2 was found by the second run and is still open")
+	}
+}
+
+func TestFailedChecksDoNotResolveFindings(t *testing.T) {
+	failed := checks.NewOctopusCheckResultImpl("The check failed", "OctoLintEmptyProject", "", checks.Error, checks.GeneralError)
+
+	history := record(space(emptyProjects("Projects-1")), space(failed))
+
+	if finding := findingFor(history, "Projects-1"); finding == nil || finding.Resolved != nil {
+		t.Fatal("Should not have resolved a finding when the check failed")
+	}
+
+	if !history.Runs[1].Checks[0].Failed {
+		t.Fatal("Should have recorded that the check failed")
+	}
+}
+
+func TestFindingsThatReappearAreOpen(t *testing.T) {
+	history := record(space(emptyProjects("Projects-1")), space(emptyProjects()), space(emptyProjects("Projects-1")))
+
+	if finding := findingFor(history, "Projects-1"); finding == nil || finding.Resolved != nil || finding.FirstRun != 1 {
+		t.Fatal("Should have reopened the finding, keeping the time it was first seen")
+	}
+}
+
+func TestHistoryKeepsMaxRuns(t *testing.T) {
+	history := NewOctopusHistory()
+	history.Record(space(emptyProjects("Projects-1")), firstRun, firstRun, 2)
+	history.Record(space(emptyProjects()), firstRun.AddDate(0, 0, 1), firstRun.AddDate(0, 0, 1), 2)
+	history.Record(space(emptyProjects()), firstRun.AddDate(0, 0, 2), firstRun.AddDate(0, 0, 2), 2)
+	history.Record(space(emptyProjects()), firstRun.AddDate(0, 0, 3), firstRun.AddDate(0, 0, 3), 2)
+
+	if len(history.Runs) != 2 || history.Runs[0].Id != 3 || history.Runs[1].Id != 4 {
+		t.Fatal("Should have kept the latest two runs")
+	}
+
+	if len(history.Findings) != 0 {
+		t.Fatal("Should have forgotten the findings resolved before the runs that were kept")
+	}
+}
+
+func TestReadAndWriteHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+
+	if err := WriteHistory(record(space(emptyProjects("Projects-1"))), path); err != nil {
+		t.Fatal("Should not have failed to write the history: " + err.Error())
+	}
+
+	history, err := ReadHistory(path)
+
+	if err != nil || len(history.Runs) != 1 || len(history.Findings) != 1 {
+		t.Fatal("Should have read the history")
+	}
+}
+
+func TestTrend(t *testing.T) {
+	unusedVariables := checks.NewOctopusCheckResultImpl("The following variables are unused", "OctoLintUnusedVariables", "", checks.Warning, checks.Organization,
+		checks.OctopusCheckFinding{ResourceType: checks.VariableResource, ResourceId: "Variables-1", ResourceName: "Unused"})
+
+	history := record(
+		space(emptyProjects("Projects-1"), unusedVariables),
+		space(emptyProjects("Projects-1", "Projects-2"), unusedVariables),
+		space(emptyProjects("Projects-2"), unusedVariables),
+	)
+
+	trend := NewOctopusTrend(history, 2, []string{"octolintemptyproject"})
+
+	if len(trend.Runs) != 2 || trend.Runs[0].Id != 2 || trend.Runs[1].Findings != 1 {
+		t.Fatal("Should have included the last two runs, counting the findings of the included checks")
+	}
+
+	if len(trend.Checks) != 1 || *trend.Checks[0].Findings[0] != 2 || *trend.Checks[0].Findings[1] != 1 {
+		t.Fatal("Should have counted the findings of the check in each run")
+	}
+
+	if len(trend.Open) != 1 || trend.Open[0].ResourceId != "Projects-2" || len(trend.Resolved) != 1 || trend.Resolved[0].ResourceId != "Projects-1" {
+		t.Fatal("Should have listed the open and resolved findings")
+	}
+
+	if !strings.Contains(trend.String(), "resolved by run #3") {
+		t.Fatal("Should have printed when the finding was resolved")
+	}
+
+	html, err := trend.Html("development")
+
+	if err != nil || !strings.Contains(html, "OctoLintEmptyProject") {
+		t.Fatal("Should have generated the HTML trend")
+	}
+
+	if content, err := json.Marshal(trend); err != nil || !json.Valid(content) {
+		t.Fatal("Should have serialized the trend")
+	}
+}
@@ -0,0 +1,218 @@
+package history
+
+import (
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/samber/lo"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// TimeFormat is the format of the times printed by the trend reports
+const TimeFormat = "2006-01-02 15:04"
+
+// OctopusTrend describes how the issues found by the latest runs changed over time.
+type OctopusTrend struct {
+	Runs   []OctopusTrendRun   `json:"runs"`
+	Checks []OctopusCheckTrend `json:"checks"`
+	// Open lists the issues found by the latest run, starting with the oldest
+	Open []OctopusFindingHistory `json:"open"`
+	// Resolved lists the issues resolved by the runs in the trend, starting with the most recently resolved
+	Resolved []OctopusFindingHistory `json:"resolved"`
+}
+
+// OctopusTrendRun summarises a single run.
+type OctopusTrendRun struct {
+	Id        int       `json:"id"`
+	StartTime time.Time `json:"startTime"`
+	// Findings is the number of issues found by the checks in the trend
+	Findings int                        `json:"findings"`
+	Summary  checks.OctopusCheckSummary `json:"summary"`
+}
+
+// OctopusCheckTrend is the number of issues found by a check in each run.
+type OctopusCheckTrend struct {
+	CheckId string `json:"checkId"`
+	// Findings is the number of issues found in all spaces by each run, in the same order as the runs. The number is
+	// null if the check failed or did not run in any space.
+	Findings []*int `json:"findings"`
+}
+
+// NewOctopusTrend summarises the latest runs in the history. The trend is limited to the checks listed in checkIds,
+// or includes every check if no IDs are supplied. Checks that found no issues in any of the runs are left out.
+func NewOctopusTrend(history OctopusHistory, runs int, checkIds []string) OctopusTrend {
+	included := func(checkId string) bool {
+		return len(checkIds) == 0 || lo.ContainsBy(checkIds, func(id string) bool { return strings.EqualFold(id, checkId) })
+	}
+
+	historyRuns := history.Runs
+	if runs > 0 && len(historyRuns) > runs {
+		historyRuns = historyRuns[len(historyRuns)-runs:]
+	}
+
+	trend := OctopusTrend{
+		Runs:     []OctopusTrendRun{},
+		Checks:   []OctopusCheckTrend{},
+		Open:     []OctopusFindingHistory{},
+		Resolved: []OctopusFindingHistory{},
+	}
+
+	checkTrends := map[string]*OctopusCheckTrend{}
+
+	for index, run := range historyRuns {
+		trendRun := OctopusTrendRun{Id: run.Id, StartTime: run.StartTime, Summary: run.Summary}
+
+		for _, check := range run.Checks {
+			if !included(check.CheckId) {
+				continue
+			}
+
+			if _, found := checkTrends[check.CheckId]; !found {
+				checkTrends[check.CheckId] = &OctopusCheckTrend{CheckId: check.CheckId, Findings: make([]*int, len(historyRuns))}
+			}
+
+			if check.Failed {
+				continue
+			}
+
+			findings := checkTrends[check.CheckId].Findings
+			if findings[index] == nil {
+				findings[index] = lo.ToPtr(0)
+			}
+
+			*findings[index] += check.Findings
+			trendRun.Findings += check.Findings
+		}
+
+		trend.Runs = append(trend.Runs, trendRun)
+	}
+
+	for _, checkId := range lo.Keys(checkTrends) {
+		if lo.SomeBy(checkTrends[checkId].Findings, func(findings *int) bool { return findings != nil && *findings != 0 }) {
+			trend.Checks = append(trend.Checks, *checkTrends[checkId])
+		}
+	}
+
+	sort.SliceStable(trend.Checks, func(i, j int) bool {
+		return trend.Checks[i].CheckId < trend.Checks[j].CheckId
+	})
+
+	if len(historyRuns) == 0 {
+		return trend
+	}
+
+	for _, finding := range history.Findings {
+		switch {
+		case !included(finding.CheckId):
+			continue
+		case finding.Resolved == nil:
+			trend.Open = append(trend.Open, finding)
+		case finding.ResolvedRun >= historyRuns[0].Id:
+			trend.Resolved = append(trend.Resolved, finding)
+		}
+	}
+
+	sort.SliceStable(trend.Open, func(i, j int) bool {
+		if !trend.Open[i].FirstSeen.Equal(trend.Open[j].FirstSeen) {
+			return trend.Open[i].FirstSeen.Before(trend.Open[j].FirstSeen)
+		}
+		return describeFinding(trend.Open[i]) < describeFinding(trend.Open[j])
+	})
+
+	sort.SliceStable(trend.Resolved, func(i, j int) bool {
+		if !trend.Resolved[i].Resolved.Equal(*trend.Resolved[j].Resolved) {
+			return trend.Resolved[i].Resolved.After(*trend.Resolved[j].Resolved)
+		}
+		return describeFinding(trend.Resolved[i]) < describeFinding(trend.Resolved[j])
+	})
+
+	return trend
+}
+
+// String prints the runs, a table of the issues found by each check in each run, and the issues that are open or
+// were resolved. Checks that failed or did not run are shown with a dash.
+func (o OctopusTrend) String() string {
+	if len(o.Runs) == 0 {
+		return "The history has no runs"
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString("Runs\n")
+	for _, run := range o.Runs {
+		builder.WriteString(fmt.Sprintf("  #%d %s: %d issue(s). %s\n", run.Id, run.StartTime.Local().Format(TimeFormat), run.Findings, run.Summary.Counts()))
+	}
+
+	builder.WriteString("\nIssues found by each check, from the oldest to the latest run\n")
+	if len(o.Checks) == 0 {
+		builder.WriteString("  No issues were found\n")
+	} else {
+		table := tabwriter.NewWriter(&builder, 0, 4, 2, ' ', 0)
+		header := []string{"  Check"}
+		for _, run := range o.Runs {
+			header = append(header, "#"+fmt.Sprint(run.Id))
+		}
+		fmt.Fprintln(table, strings.Join(header, "\t"))
+
+		for _, check := range o.Checks {
+			row := []string{"  " + check.CheckId}
+			for _, findings := range check.Findings {
+				row = append(row, FormatFindings(findings))
+			}
+			fmt.Fprintln(table, strings.Join(row, "\t"))
+		}
+		_ = table.Flush()
+	}
+
+	builder.WriteString("\nOpen issues\n")
+	if len(o.Open) == 0 {
+		builder.WriteString("  None\n")
+	}
+	for _, finding := range o.Open {
+		builder.WriteString("  " + finding.CheckId + " " + describeFinding(finding) + ": first found by run #" +
+			fmt.Sprint(finding.FirstRun) + " at " + finding.FirstSeen.Local().Format(TimeFormat) + "\n")
+	}
+
+	builder.WriteString("\nResolved issues\n")
+	if len(o.Resolved) == 0 {
+		builder.WriteString("  None\n")
+	}
+	for _, finding := range o.Resolved {
+		builder.WriteString("  " + finding.CheckId + " " + describeFinding(finding) + ": first found by run #" +
+			fmt.Sprint(finding.FirstRun) + " at " + finding.FirstSeen.Local().Format(TimeFormat) + ", resolved by run #" +
+			fmt.Sprint(finding.ResolvedRun) + " at " + finding.Resolved.Local().Format(TimeFormat) + "\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+// FormatFindings prints the number of issues found by a check in a run, or a dash if the check did not run.
+func FormatFindings(findings *int) string {
+	if findings == nil {
+		return "-"
+	}
+
+	return fmt.Sprint(*findings)
+}
+
+func describeFinding(finding OctopusFindingHistory) string {
+	description := "[" + finding.Severity + "] "
+
+	if finding.ResourceType == "" {
+		description += finding.Message
+	} else {
+		description += finding.ResourceType + " \"" + finding.ResourceName + "\""
+
+		if finding.ProjectName != "" && !(finding.ResourceType == checks.ProjectResource && finding.ResourceName == finding.ProjectName) {
+			description += " in " + finding.ProjectName
+		}
+	}
+
+	if finding.SpaceName != "" {
+		description += " (" + finding.SpaceName + ")"
+	}
+
+	return description
+}
@@ -0,0 +1,131 @@
+package history
+
+import (
+	"bytes"
+	_ "embed"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"html/template"
+	"strings"
+)
+
+//go:embed templates/trend.html
+var trendTemplate string
+
+type htmlTrend struct {
+	OctolintVersion  string
+	DocumentationUrl string
+	Latest           int
+	Runs             []htmlRun
+	Checks           []htmlCheckTrend
+	Open             []htmlFinding
+	Resolved         []htmlFinding
+}
+
+type htmlRun struct {
+	Id        int
+	StartTime string
+	Findings  int
+	BarWidth  int
+	Summary   checks.OctopusCheckSummary
+}
+
+type htmlCheckTrend struct {
+	CheckId  string
+	Link     string
+	Findings []string
+}
+
+type htmlFinding struct {
+	CheckId       string
+	Severity      string
+	SeverityClass string
+	Description   string
+	FirstRun      int
+	FirstSeen     string
+	ResolvedRun   int
+	Resolved      string
+}
+
+// Html prints the trend as a single HTML page with no external dependencies. The page charts the number of issues
+// found by each run, and has tables of the issues found by each check, and of the issues that are open or resolved.
+func (o OctopusTrend) Html(octolintVersion string) (string, error) {
+	tmpl, err := template.New("trend").Funcs(template.FuncMap{
+		"last": func(runs []htmlRun) int { return len(runs) - 1 },
+	}).Parse(trendTemplate)
+
+	if err != nil {
+		return "", err
+	}
+
+	report := htmlTrend{
+		OctolintVersion:  octolintVersion,
+		DocumentationUrl: checks.DocumentationUrl,
+		Runs:             []htmlRun{},
+		Checks:           []htmlCheckTrend{},
+		Open:             []htmlFinding{},
+		Resolved:         []htmlFinding{},
+	}
+
+	maxFindings := 0
+	for _, run := range o.Runs {
+		maxFindings = max(maxFindings, run.Findings)
+	}
+
+	for _, run := range o.Runs {
+		htmlRun := htmlRun{
+			Id:        run.Id,
+			StartTime: run.StartTime.Local().Format(TimeFormat),
+			Findings:  run.Findings,
+			Summary:   run.Summary,
+		}
+
+		if maxFindings != 0 {
+			htmlRun.BarWidth = 100 * run.Findings / maxFindings
+		}
+
+		report.Runs = append(report.Runs, htmlRun)
+		report.Latest = run.Findings
+	}
+
+	for _, check := range o.Checks {
+		checkTrend := htmlCheckTrend{CheckId: check.CheckId, Link: checks.DocumentationLink(check.CheckId), Findings: []string{}}
+		for _, findings := range check.Findings {
+			checkTrend.Findings = append(checkTrend.Findings, FormatFindings(findings))
+		}
+		report.Checks = append(report.Checks, checkTrend)
+	}
+
+	for _, finding := range o.Open {
+		report.Open = append(report.Open, newHtmlFinding(finding))
+	}
+
+	for _, finding := range o.Resolved {
+		report.Resolved = append(report.Resolved, newHtmlFinding(finding))
+	}
+
+	var content bytes.Buffer
+	if err := tmpl.Execute(&content, report); err != nil {
+		return "", err
+	}
+
+	return content.String(), nil
+}
+
+func newHtmlFinding(finding OctopusFindingHistory) htmlFinding {
+	htmlFinding := htmlFinding{
+		CheckId:       finding.CheckId,
+		Severity:      finding.Severity,
+		SeverityClass: strings.ToLower(finding.Severity),
+		// The severity is displayed in its own column
+		Description: strings.TrimPrefix(describeFinding(finding), "["+finding.Severity+"] "),
+		FirstRun:    finding.FirstRun,
+		FirstSeen:   finding.FirstSeen.Local().Format(TimeFormat),
+	}
+
+	if finding.Resolved != nil {
+		htmlFinding.ResolvedRun = finding.ResolvedRun
+		htmlFinding.Resolved = finding.Resolved.Local().Format(TimeFormat)
+	}
+
+	return htmlFinding
+}
@@ -0,0 +1,234 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
+	"github.com/avast/retry-go/v4"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// SignatureHeader holds the HMAC SHA256 signature of the payload, in the format sha256=<hex digest>, when the webhook
+// has a secret.
+const SignatureHeader = "X-Octolint-Signature"
+
+// OctopusWebhookOutcome describes what happened when the results were sent to a webhook.
+type OctopusWebhookOutcome struct {
+	// Host is the host of the webhook URL. The full URL is not reported, as chat tools include a secret in the URL.
+	Host string
+	// Sent is true if the webhook accepted the results
+	Sent bool
+	// Skipped explains why the results were not sent, or is empty if they were sent or failed to send
+	Skipped string
+	// Err is the error returned by the last attempt to send the results
+	Err error
+}
+
+// OctopusWebhookNotifier posts the results of a scan to the webhooks defined in the config file. Failed requests are
+// retried with a backoff when the server could not be reached, or responded with a 429 or 5xx status code.
+type OctopusWebhookNotifier struct {
+	client *http.Client
+	// attempts is the number of times a request is sent before giving up
+	attempts uint
+	// delay is the initial delay between attempts, which is increased with each retry
+	delay time.Duration
+	// statePath is the file recording the issues last sent to the webhooks with onChange enabled
+	statePath string
+}
+
+func NewOctopusWebhookNotifier(attempts uint, statePath string) OctopusWebhookNotifier {
+	return OctopusWebhookNotifier{
+		client:    &http.Client{Timeout: 30 * time.Second},
+		attempts:  attempts,
+		delay:     time.Second,
+		statePath: statePath,
+	}
+}
+
+// ValidateWebhooks returns an error if a webhook has an invalid URL, template, or minimum severity.
+func ValidateWebhooks(webhooks []config.Webhook) error {
+	for i, webhook := range webhooks {
+		webhookUrl, err := url.Parse(os.ExpandEnv(webhook.Url))
+
+		if err != nil || (webhookUrl.Scheme != "http" && webhookUrl.Scheme != "https") || webhookUrl.Host == "" {
+			return fmt.Errorf("webhook %d must have an http or https url", i+1)
+		}
+
+		if _, err := templatePayload(webhook.Template, nil, reporters.OctopusReportMetadata{}, checks.Ok); err != nil {
+			return fmt.Errorf("webhook %d is invalid: %w", i+1, err)
+		}
+
+		if webhook.MinSeverity != "" {
+			if _, err := checks.ParseSeverity(webhook.MinSeverity); err != nil {
+				return fmt.Errorf("webhook %d has an invalid minSeverity: %w", i+1, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Notify sends the results to each webhook, returning the outcome for each webhook in the order they were defined. The
+// minSeverity is the minimum severity of the results included in the payload.
+func (o OctopusWebhookNotifier) Notify(ctx context.Context, webhooks []config.Webhook, spaces []checks.OctopusSpaceResults, metadata reporters.OctopusReportMetadata, minSeverity int) []OctopusWebhookOutcome {
+	outcomes := []OctopusWebhookOutcome{}
+
+	if len(webhooks) == 0 {
+		return outcomes
+	}
+
+	state, err := readWebhookState(o.statePath)
+
+	if err != nil {
+		zap.L().Debug("Failed to read the webhook state " + o.statePath + ", so all webhooks will be sent: " + err.Error())
+	}
+
+	issues := issuesHash(spaces)
+	sentHashes := map[string]string{}
+
+	for _, webhook := range webhooks {
+		webhookUrl := os.ExpandEnv(webhook.Url)
+		outcome := OctopusWebhookOutcome{Host: webhookHost(webhookUrl)}
+
+		if skipped := o.skipReason(webhook, state, spaces, issues); skipped != "" {
+			outcome.Skipped = skipped
+			outcomes = append(outcomes, outcome)
+			continue
+		}
+
+		payload, err := templatePayload(webhook.Template, spaces, metadata, minSeverity)
+
+		if err == nil {
+			err = o.send(ctx, webhook, webhookUrl, payload)
+		}
+
+		outcome.Err = err
+		outcome.Sent = err == nil
+
+		if outcome.Sent && webhook.OnChange {
+			sentHashes[webhookKey(webhookUrl, spaces)] = issues
+		}
+
+		outcomes = append(outcomes, outcome)
+	}
+
+	if len(sentHashes) != 0 {
+		if err := updateWebhookState(o.statePath, sentHashes); err != nil {
+			zap.L().Error("Failed to save the webhook state to " + o.statePath + ": " + err.Error())
+		}
+	}
+
+	return outcomes
+}
+
+// skipReason returns why the results should not be sent to the webhook, or an empty string if they should be sent.
+func (o OctopusWebhookNotifier) skipReason(webhook config.Webhook, state webhookState, spaces []checks.OctopusSpaceResults, issues string) string {
+	if webhook.MinSeverity != "" {
+		// The severity was validated when the config was read
+		minSeverity, _ := checks.ParseSeverity(webhook.MinSeverity)
+
+		if checks.NewOctopusCheckSummary(checks.AllResults(spaces)).IssuesAtOrAbove(max(minSeverity, checks.Info)) == 0 {
+			return "there are no issues at or above the " + webhook.MinSeverity + " severity"
+		}
+	}
+
+	if webhook.OnChange && state.Webhooks[webhookKey(os.ExpandEnv(webhook.Url), spaces)] == issues {
+		return "the issues have not changed since the results were last sent"
+	}
+
+	return ""
+}
+
+// send posts the payload to the webhook, retrying transient failures.
+func (o OctopusWebhookNotifier) send(ctx context.Context, webhook config.Webhook, webhookUrl string, payload []byte) error {
+	attempts := o.attempts
+	if attempts < 1 {
+		// retry-go treats 0 attempts as "retry forever"
+		attempts = 1
+	}
+
+	return retry.Do(
+		func() error {
+			return o.post(ctx, webhook, webhookUrl, payload)
+		},
+		retry.Context(ctx),
+		retry.Attempts(attempts),
+		retry.Delay(o.delay),
+		retry.DelayType(retry.BackOffDelay),
+		retry.LastErrorOnly(true),
+		retry.OnRetry(func(n uint, err error) {
+			zap.L().Debug("Retrying the webhook " + webhookHost(webhookUrl) + " after an error: " + err.Error())
+		}))
+}
+
+func (o OctopusWebhookNotifier) post(ctx context.Context, webhook config.Webhook, webhookUrl string, payload []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookUrl, bytes.NewReader(payload))
+
+	if err != nil {
+		return retry.Unrecoverable(err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "octolint")
+
+	for name, value := range webhook.Headers {
+		request.Header.Set(name, os.ExpandEnv(value))
+	}
+
+	if webhook.Secret != "" {
+		request.Header.Set(SignatureHeader, Sign(payload, os.ExpandEnv(webhook.Secret)))
+	}
+
+	response, err := o.client.Do(request)
+
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	// Read the body so the connection can be reused
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return nil
+	}
+
+	statusErr := fmt.Errorf("the webhook responded with %s: %s", response.Status, strings.TrimSpace(string(body)))
+
+	if response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500 {
+		return statusErr
+	}
+
+	return retry.Unrecoverable(statusErr)
+}
+
+// Sign returns the value of the signature header for a payload. Receivers verify the payload by calculating the HMAC
+// SHA256 of the request body with the shared secret and comparing it to the header.
+func Sign(payload []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func webhookHost(webhookUrl string) string {
+	parsed, err := url.Parse(webhookUrl)
+
+	if err != nil || parsed.Host == "" {
+		return "an invalid url"
+	}
+
+	return parsed.Host
+}
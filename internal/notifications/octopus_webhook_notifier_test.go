@@ -0,0 +1,225 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// webhookStandIn records the requests sent to it, and responds with each of the status codes in turn before
+// responding with 200.
+type webhookStandIn struct {
+	mutex    sync.Mutex
+	statuses []int
+	bodies   [][]byte
+	headers  []http.Header
+}
+
+func (o *webhookStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	o.bodies = append(o.bodies, body)
+	o.headers = append(o.headers, r.Header)
+
+	if len(o.statuses) != 0 {
+		w.WriteHeader(o.statuses[0])
+		o.statuses = o.statuses[1:]
+	}
+}
+
+func startStandIn(t *testing.T, statuses ...int) (*webhookStandIn, *httptest.Server) {
+	standIn := &webhookStandIn{statuses: statuses}
+	server := httptest.NewServer(standIn)
+	t.Cleanup(server.Close)
+	return standIn, server
+}
+
+func testNotifier(t *testing.T, server *httptest.Server) OctopusWebhookNotifier {
+	return OctopusWebhookNotifier{
+		client:    server.Client(),
+		attempts:  3,
+		delay:     time.Millisecond,
+		statePath: filepath.Join(t.TempDir(), "webhooks.json"),
+	}
+}
+
+func testResults(projects ...string) []checks.OctopusSpaceResults {
+	findings := []checks.OctopusCheckFinding{}
+	for _, project := range projects {
+		findings = append(findings, checks.OctopusCheckFinding{ResourceType: checks.ProjectResource, ResourceId: project, ResourceName: project})
+	}
+
+	severity := checks.Ok
+	if len(findings) != 0 {
+		severity = checks.Warning
+	}
+
+	return []checks.OctopusSpaceResults{{
+		SpaceId: "Spaces-1",
+		Results: []checks.OctopusCheckResult{
+			checks.NewOctopusCheckResultImpl("The following projects are empty", "OctoLintEmptyProject", "", severity, checks.Organization, findings...),
+		},
+	}}
+}
+
+func TestWebhookPayloadIsSigned(t *testing.T) {
+	standIn, server := startStandIn(t)
+
+	webhooks := []config.Webhook{{Url: server.URL, Secret: "secret", Headers: map[string]string{"Authorization": "Bearer token"}}}
+	outcomes := testNotifier(t, server).Notify(context.Background(), webhooks, testResults("Projects-1"), reporters.OctopusReportMetadata{}, checks.Warning)
+
+	if len(outcomes) != 1 || !outcomes[0].Sent {
+		t.Fatal("Should have sent the results")
+	}
+
+	if standIn.headers[0].Get(SignatureHeader) != Sign(standIn.bodies[0], "secret") {
+		t.Fatal("Should have signed the payload with the secret")
+	}
+
+	if standIn.headers[0].Get("Authorization") != "Bearer token" {
+		t.Fatal("Should have sent the custom headers")
+	}
+
+	report := reporters.JsonReport{}
+	if err := json.Unmarshal(standIn.bodies[0], &report); err != nil || len(report.Spaces) != 1 {
+		t.Fatal("Should have sent the JSON report")
+	}
+}
+
+func TestWebhookRetriesTransientErrors(t *testing.T) {
+	standIn, server := startStandIn(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+
+	outcomes := testNotifier(t, server).Notify(context.Background(), []config.Webhook{{Url: server.URL}}, testResults("Projects-1"), reporters.OctopusReportMetadata{}, checks.Warning)
+
+	if !outcomes[0].Sent || len(standIn.bodies) != 3 {
+		t.Fatalf("Should have sent the results on the third attempt, but made %d attempts", len(standIn.bodies))
+	}
+}
+
+func TestWebhookDoesNotRetryClientErrors(t *testing.T) {
+	standIn, server := startStandIn(t, http.StatusBadRequest)
+
+	outcomes := testNotifier(t, server).Notify(context.Background(), []config.Webhook{{Url: server.URL}}, testResults("Projects-1"), reporters.OctopusReportMetadata{}, checks.Warning)
+
+	if outcomes[0].Sent || outcomes[0].Err == nil || len(standIn.bodies) != 1 {
+		t.Fatal("Should have failed without retrying the request")
+	}
+}
+
+func TestWebhookOnChange(t *testing.T) {
+	standIn, server := startStandIn(t)
+	notifier := testNotifier(t, server)
+	webhooks := []config.Webhook{{Url: server.URL, OnChange: true}}
+
+	notifier.Notify(context.Background(), webhooks, testResults("Projects-1"), reporters.OctopusReportMetadata{}, checks.Warning)
+	unchanged := notifier.Notify(context.Background(), webhooks, testResults("Projects-1"), reporters.OctopusReportMetadata{}, checks.Warning)
+
+	if unchanged[0].Sent || unchanged[0].Skipped == "" {
+		t.Fatal("Should not have sent results that have not changed")
+	}
+
+	changed := notifier.Notify(context.Background(), webhooks, testResults("Projects-1", "Projects-2"), reporters.OctopusReportMetadata{}, checks.Warning)
+
+	if !changed[0].Sent || len(standIn.bodies) != 2 {
+		t.Fatal("Should have sent the results when a new issue was found")
+	}
+}
+
+func TestWebhookOnChangeIsTrackedForEachSetOfSpaces(t *testing.T) {
+	standIn, server := startStandIn(t)
+	notifier := testNotifier(t, server)
+	webhooks := []config.Webhook{{Url: server.URL, OnChange: true}}
+
+	otherSpace := testResults("Projects-2")
+	otherSpace[0].SpaceId = "Spaces-2"
+
+	for i := 0; i < 2; i++ {
+		notifier.Notify(context.Background(), webhooks, testResults("Projects-1"), reporters.OctopusReportMetadata{}, checks.Warning)
+		notifier.Notify(context.Background(), webhooks, otherSpace, reporters.OctopusReportMetadata{}, checks.Warning)
+	}
+
+	if len(standIn.bodies) != 2 {
+		t.Fatalf("Should have sent the results of each space once, but sent %d requests", len(standIn.bodies))
+	}
+}
+
+func TestWebhookStateIsUpdatedConcurrently(t *testing.T) {
+	_, server := startStandIn(t)
+	notifier := testNotifier(t, server)
+	webhooks := []config.Webhook{{Url: server.URL, OnChange: true}}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		spaces := testResults("Projects-1")
+		spaces[0].SpaceId = fmt.Sprint("Spaces-", i)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			notifier.Notify(context.Background(), webhooks, spaces, reporters.OctopusReportMetadata{}, checks.Warning)
+		}()
+	}
+	wg.Wait()
+
+	state, err := readWebhookState(notifier.statePath)
+
+	if err != nil || len(state.Webhooks) != 10 {
+		t.Fatalf("Should have saved the state of every scan, but saved %d", len(state.Webhooks))
+	}
+}
+
+func TestWebhookMinSeverity(t *testing.T) {
+	standIn, server := startStandIn(t)
+	webhooks := []config.Webhook{{Url: server.URL, MinSeverity: "error"}}
+
+	outcomes := testNotifier(t, server).Notify(context.Background(), webhooks, testResults("Projects-1"), reporters.OctopusReportMetadata{}, checks.Warning)
+
+	if outcomes[0].Sent || outcomes[0].Skipped == "" || len(standIn.bodies) != 0 {
+		t.Fatal("Should not have sent results without errors")
+	}
+}
+
+func TestChatTemplates(t *testing.T) {
+	for _, template := range []string{SlackTemplate, TeamsTemplate, DiscordTemplate} {
+		payload, err := templatePayload(template, testResults("Projects-1"), reporters.OctopusReportMetadata{ServerUrl: "https://example.octopus.app"}, checks.Warning)
+
+		if err != nil {
+			t.Fatal("Should not have returned an error for the " + template + " template")
+		}
+
+		if !json.Valid(payload) || !strings.Contains(string(payload), "OctoLintEmptyProject") {
+			t.Fatal("Should have listed the check in the " + template + " template")
+		}
+	}
+}
+
+func TestValidateWebhooks(t *testing.T) {
+	if err := ValidateWebhooks([]config.Webhook{{Url: "https://example.com/hook", Template: SlackTemplate, MinSeverity: "warning"}}); err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	invalid := []config.Webhook{
+		{Url: "example.com/hook"},
+		{Url: "https://example.com/hook", Template: "irc"},
+		{Url: "https://example.com/hook", MinSeverity: "critical"},
+	}
+
+	for _, webhook := range invalid {
+		if err := ValidateWebhooks([]config.Webhook{webhook}); err == nil {
+			t.Fatalf("Should have returned an error for %v", webhook)
+		}
+	}
+}
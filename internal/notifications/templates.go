@@ -0,0 +1,140 @@
+package notifications
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
+	"sort"
+	"strings"
+)
+
+// The payload templates supported by the webhooks
+const (
+	JsonTemplate    = "json"
+	SlackTemplate   = "slack"
+	TeamsTemplate   = "teams"
+	DiscordTemplate = "discord"
+)
+
+// Templates lists the payload templates supported by the webhooks.
+var Templates = []string{JsonTemplate, SlackTemplate, TeamsTemplate, DiscordTemplate}
+
+// maxChatChecks is the number of checks listed in the messages posted to chat tools
+const maxChatChecks = 10
+
+// maxDiscordLength is the maximum length of a Discord message
+const maxDiscordLength = 2000
+
+// templatePayload builds the body of the request sent to a webhook. The json template is the JSON report, while the
+// chat templates are a short message listing the most severe issues.
+func templatePayload(template string, spaces []checks.OctopusSpaceResults, metadata reporters.OctopusReportMetadata, minSeverity int) ([]byte, error) {
+	switch strings.ToLower(strings.TrimSpace(template)) {
+	case "", JsonTemplate:
+		report, err := reporters.NewOctopusJsonCheckReporter(minSeverity, metadata).GenerateSpaces(spaces)
+		return []byte(report), err
+	case SlackTemplate:
+		return json.Marshal(map[string]string{
+			"text": chatMessage(spaces, metadata, minSeverity, func(text string, link string) string {
+				return "<" + link + "|" + text + ">"
+			}),
+		})
+	case TeamsTemplate:
+		summary := checks.NewOctopusCheckSummary(checks.AllResults(spaces))
+		return json.Marshal(map[string]string{
+			"@type":      "MessageCard",
+			"@context":   "https://schema.org/extensions",
+			"summary":    chatTitle(metadata),
+			"themeColor": themeColor(summary),
+			"title":      chatTitle(metadata),
+			"text":       strings.ReplaceAll(chatMessage(spaces, metadata, minSeverity, markdownLink), "\n", "\n\n"),
+		})
+	case DiscordTemplate:
+		message := chatMessage(spaces, metadata, minSeverity, func(text string, link string) string {
+			// Wrapping the link in angle brackets prevents Discord from embedding a preview of every link
+			return "[" + text + "](<" + link + ">)"
+		})
+
+		if runes := []rune(message); len(runes) > maxDiscordLength {
+			message = string(runes[:maxDiscordLength-3]) + "..."
+		}
+
+		return json.Marshal(map[string]string{"content": message})
+	default:
+		return nil, errors.New("unknown template \"" + template + "\". The supported templates are: " + strings.Join(Templates, ", "))
+	}
+}
+
+func chatTitle(metadata reporters.OctopusReportMetadata) string {
+	if metadata.ServerUrl == "" {
+		return "Octolint results"
+	}
+
+	return "Octolint results for " + metadata.ServerUrl
+}
+
+// chatMessage summarises the results, and lists the most severe checks that found issues with a link to their
+// documentation. The link function formats a link in the markup of the chat tool.
+func chatMessage(spaces []checks.OctopusSpaceResults, metadata reporters.OctopusReportMetadata, minSeverity int, link func(text string, link string) string) string {
+	lines := []string{
+		chatTitle(metadata),
+		checks.NewOctopusCheckSummary(checks.AllResults(spaces)).String(),
+	}
+
+	if metadata.Interrupted {
+		lines = append(lines, "The scan was interrupted. These results only include the checks that completed.")
+	}
+
+	failing := []checks.OctopusCheckResult{}
+	for _, r := range checks.AllResults(spaces) {
+		if r.Category() != checks.GeneralError && r.Severity() >= max(minSeverity, checks.Info) {
+			failing = append(failing, r)
+		}
+	}
+
+	sort.SliceStable(failing, func(i, j int) bool {
+		return failing[i].Severity() > failing[j].Severity()
+	})
+
+	for i, r := range failing {
+		if i >= maxChatChecks {
+			lines = append(lines, fmt.Sprintf("...and %d more", len(failing)-maxChatChecks))
+			break
+		}
+
+		line := "• " + checks.SeverityName(r.Severity()) + ": " + link(r.Code(), resultLink(r))
+
+		if len(r.Findings()) != 0 {
+			line += fmt.Sprintf(" (%d resource(s))", len(r.Findings()))
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func markdownLink(text string, link string) string {
+	return "[" + text + "](" + link + ")"
+}
+
+// themeColor is the color of the card posted to Teams, based on the most severe issue.
+func themeColor(summary checks.OctopusCheckSummary) string {
+	switch {
+	case summary.Errors != 0 || summary.Failed != 0:
+		return "D93025"
+	case summary.Warnings != 0:
+		return "F9A825"
+	default:
+		return "1E8E3E"
+	}
+}
+
+func resultLink(result checks.OctopusCheckResult) string {
+	if result.Link() != "" {
+		return result.Link()
+	}
+
+	return checks.DocumentationLink(result.Code())
+}
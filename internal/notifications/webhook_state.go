@@ -0,0 +1,140 @@
+package notifications
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// webhookState records a hash of the issues last sent to each webhook with onChange enabled. The webhooks are keyed by
+// a hash of their URL and the spaces that were scanned, as chat tools include a secret in the URL, and scans of
+// different spaces can post to the same webhook.
+type webhookState struct {
+	Webhooks map[string]string `json:"webhooks"`
+}
+
+// webhookStateMutex serializes the updates to the state file, as the scans run by the serve command notify the webhooks
+// at the same time.
+var webhookStateMutex sync.Mutex
+
+// readWebhookState reads the state file. A missing file is treated as an empty state.
+func readWebhookState(path string) (webhookState, error) {
+	state := webhookState{Webhooks: map[string]string{}}
+
+	content, err := os.ReadFile(path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+
+	if err != nil {
+		return state, err
+	}
+
+	if err := json.Unmarshal(content, &state); err != nil {
+		return webhookState{Webhooks: map[string]string{}}, err
+	}
+
+	if state.Webhooks == nil {
+		state.Webhooks = map[string]string{}
+	}
+
+	return state, nil
+}
+
+// updateWebhookState saves the hashes of the issues sent to the webhooks. The state file is read again while it is
+// locked, so the hashes saved by other scans since it was first read are kept.
+func updateWebhookState(path string, hashes map[string]string) error {
+	webhookStateMutex.Lock()
+	defer webhookStateMutex.Unlock()
+
+	state, err := readWebhookState(path)
+
+	if err != nil {
+		zap.L().Debug("Failed to read the webhook state " + path + ", so it will be replaced: " + err.Error())
+	}
+
+	for key, hash := range hashes {
+		state.Webhooks[key] = hash
+	}
+
+	return writeWebhookState(state, path)
+}
+
+// writeWebhookState saves the state to a temporary file that is then renamed, so the state file is never left partly
+// written.
+func writeWebhookState(state webhookState, path string) error {
+	content, err := json.MarshalIndent(state, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	temporaryFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(temporaryFile.Name())
+
+	if _, err := temporaryFile.Write(content); err != nil {
+		_ = temporaryFile.Close()
+		return err
+	}
+
+	if err := temporaryFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(temporaryFile.Name(), path)
+}
+
+// webhookKey identifies a webhook and the spaces whose results are sent to it.
+func webhookKey(webhookUrl string, spaces []checks.OctopusSpaceResults) string {
+	spaceIds := []string{}
+	for _, space := range spaces {
+		spaceIds = append(spaceIds, space.SpaceId)
+	}
+
+	sort.Strings(spaceIds)
+
+	hash := sha256.Sum256([]byte(webhookUrl + "\n" + strings.Join(spaceIds, ",")))
+	return hex.EncodeToString(hash[:])
+}
+
+// issuesHash identifies the issues found by a scan. It changes when an issue is found or resolved, but not when the
+// wording of a message changes or the results are returned in a different order.
+func issuesHash(spaces []checks.OctopusSpaceResults) string {
+	issues := []string{}
+
+	for _, space := range spaces {
+		for _, r := range space.Results {
+			if r.Category() == checks.GeneralError || r.Severity() < checks.Info {
+				continue
+			}
+
+			// Results that don't list resources are identified by the check and space
+			if len(r.Findings()) == 0 {
+				issues = append(issues, r.Code()+"/"+space.SpaceId+"/"+checks.SeverityName(r.Severity()))
+			}
+
+			for _, f := range r.Findings() {
+				issues = append(issues, checks.Fingerprint(r.Code(), f)+"/"+checks.SeverityName(r.Severity()))
+			}
+		}
+	}
+
+	sort.Strings(issues)
+
+	hash := sha256.Sum256([]byte(strings.Join(issues, "\n")))
+	return hex.EncodeToString(hash[:])
+}
@@ -1,8 +1,91 @@
 package reporters
 
-import "github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+import (
+	"errors"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"strings"
+)
+
+const (
+	PlainFormat       = "plain"
+	JsonFormat        = "json"
+	SarifFormat       = "sarif"
+	JUnitFormat       = "junit"
+	HtmlFormat        = "html"
+	MarkdownFormat    = "markdown"
+	OctopusFormat     = "octopus"
+	CsvFormat         = "csv"
+	OpenMetricsFormat = "openmetrics"
+)
+
+// Formats lists the report formats supported by NewOctopusCheckReporter.
+var Formats = []string{PlainFormat, JsonFormat, SarifFormat, JUnitFormat, HtmlFormat, MarkdownFormat, OctopusFormat, CsvFormat, OpenMetricsFormat}
+
+// TextFormats lists the formats whose reports can be printed alongside other messages, like the summary.
+var TextFormats = []string{PlainFormat, OctopusFormat}
 
 // OctopusCheckReporter defines the contract used by reporters to print the result of lint checks.
 type OctopusCheckReporter interface {
+	// Generate reports on the results of a single space
 	Generate(results []checks.OctopusCheckResult) (string, error)
+	// GenerateSpaces reports on the results of many spaces, grouping the results by space
+	GenerateSpaces(spaces []checks.OctopusSpaceResults) (string, error)
+}
+
+// NewOctopusCheckReporter returns the reporter for the format set in the config.
+func NewOctopusCheckReporter(octolintConfig *config.OctolintConfig, minSeverity int, metadata OctopusReportMetadata) (OctopusCheckReporter, error) {
+	format := octolintConfig.Format
+
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", PlainFormat:
+		return NewOctopusPlainCheckReporter(minSeverity, metadata), nil
+	case JsonFormat:
+		return NewOctopusJsonCheckReporter(minSeverity, metadata), nil
+	case SarifFormat:
+		return NewOctopusSarifCheckReporter(minSeverity, metadata), nil
+	case JUnitFormat:
+		return NewOctopusJUnitCheckReporter(minSeverity, metadata), nil
+	case HtmlFormat:
+		return NewOctopusHtmlCheckReporter(minSeverity, metadata), nil
+	case MarkdownFormat:
+		return NewOctopusMarkdownCheckReporter(minSeverity, metadata, octolintConfig.MarkdownMaxFindings, octolintConfig.MarkdownMaxLength, octolintConfig.MarkdownCollapse), nil
+	case OctopusFormat:
+		return NewOctopusServiceMessageCheckReporter(minSeverity, metadata, octolintConfig.ArtifactDirectory), nil
+	case CsvFormat:
+		return NewOctopusCsvCheckReporter(minSeverity, metadata), nil
+	case OpenMetricsFormat:
+		return NewOctopusOpenMetricsCheckReporter(minSeverity, metadata), nil
+	default:
+		return nil, errors.New("unknown format \"" + format + "\". The supported formats are: " + strings.Join(Formats, ", "))
+	}
+}
+
+// resultLink returns the link reported by the check, or the documentation of the check if it did not report a link.
+func resultLink(result checks.OctopusCheckResult) string {
+	if result.Link() != "" {
+		return result.Link()
+	}
+
+	return checks.DocumentationLink(result.Code())
+}
+
+// spaceHealthScore is the health score of a space, as included in the machine readable reports that list the spaces
+// separately from the results.
+type spaceHealthScore struct {
+	SpaceId   string `json:"spaceId,omitempty"`
+	SpaceName string `json:"spaceName,omitempty"`
+	checks.OctopusHealthScore
+}
+
+func spaceHealthScores(spaces []checks.OctopusSpaceResults, weights checks.OctopusScoreWeights) []spaceHealthScore {
+	scores := []spaceHealthScore{}
+	for _, space := range spaces {
+		scores = append(scores, spaceHealthScore{
+			SpaceId:            space.SpaceId,
+			SpaceName:          space.SpaceName,
+			OctopusHealthScore: checks.NewOctopusHealthScore(space, weights),
+		})
+	}
+	return scores
 }
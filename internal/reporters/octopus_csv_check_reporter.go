@@ -0,0 +1,125 @@
+package reporters
+
+import (
+	"bytes"
+	"encoding/csv"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"strings"
+)
+
+// csvScoreSeverity is the severity of the rows reporting the health score
+const csvScoreSeverity = "Score"
+
+var csvHeader = []string{"Space", "Check ID", "Category", "Severity", "Resource Type", "Resource Name", "Resource ID", "Project", "Message", "Link"}
+
+// OctopusCsvCheckReporter prints the lint reports as CSV, with a row for each affected resource, so the issues can be
+// sorted and filtered in a spreadsheet. Results without findings, and checks that failed to run, are reported as a
+// single row without a resource. The health score of each space, each category, and the top contributors to the score
+// are reported in rows with the Score severity.
+type OctopusCsvCheckReporter struct {
+	minSeverity int
+	metadata    OctopusReportMetadata
+}
+
+func NewOctopusCsvCheckReporter(minSeverity int, metadata OctopusReportMetadata) OctopusCsvCheckReporter {
+	return OctopusCsvCheckReporter{minSeverity: minSeverity, metadata: metadata}
+}
+
+func (o OctopusCsvCheckReporter) Generate(results []checks.OctopusCheckResult) (string, error) {
+	return o.GenerateSpaces([]checks.OctopusSpaceResults{{Results: results}})
+}
+
+func (o OctopusCsvCheckReporter) GenerateSpaces(spaces []checks.OctopusSpaceResults) (string, error) {
+	var content bytes.Buffer
+	writer := csv.NewWriter(&content)
+
+	if err := writer.Write(csvHeader); err != nil {
+		return "", err
+	}
+
+	for _, space := range spaces {
+		urlBuilder := checks.NewOctopusUrlBuilder(o.metadata.ServerUrl, space.SpaceId).WithProjectRepositories(space.ProjectRepositories)
+
+		for _, r := range space.Results {
+			severity := checks.SeverityName(r.Severity())
+
+			if r.Category() == checks.GeneralError {
+				severity = "Failed"
+			} else if r.Severity() == checks.Ok || r.Severity() < o.minSeverity {
+				continue
+			}
+
+			if len(r.Findings()) == 0 || r.Category() == checks.GeneralError {
+				if err := writer.Write(csvRow(spaceDisplayName(space), r.Code(), resultCategory(r), severity, "", "", "", "", r.Description(), resultLink(r))); err != nil {
+					return "", err
+				}
+				continue
+			}
+
+			for _, f := range r.Findings() {
+				link := urlBuilder.FindingUrl(f)
+				if link == "" {
+					link = resultLink(r)
+				}
+
+				message := f.Message
+				if message == "" {
+					message = firstLine(r.Description())
+				}
+
+				if err := writer.Write(csvRow(spaceDisplayName(space), r.Code(), resultCategory(r), severity, f.ResourceType, f.ResourceName, f.ResourceId, f.ProjectName, message, link)); err != nil {
+					return "", err
+				}
+			}
+		}
+
+		if err := writer.WriteAll(csvScoreRows(spaceDisplayName(space), checks.NewOctopusHealthScore(space, o.metadata.ScoreWeights))); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(content.String(), "\n"), nil
+}
+
+// csvRow returns the values of a row. Values that a spreadsheet would treat as a formula, like a resource named
+// "=cmd|...", are prefixed with a quote so they are displayed as text.
+func csvRow(values ...string) []string {
+	row := []string{}
+	for _, value := range values {
+		if value != "" && strings.ContainsAny(value[:1], "=+-@\t\r") {
+			value = "'" + value
+		}
+		row = append(row, value)
+	}
+	return row
+}
+
+// csvScoreRows reports the health score of a space, the score of each category, and the points each of the top
+// contributors took off the score.
+func csvScoreRows(space string, score checks.OctopusHealthScore) [][]string {
+	if !score.Scored() {
+		return [][]string{}
+	}
+
+	rows := [][]string{
+		csvRow(space, "", "", csvScoreSeverity, "", "", "", "", score.String(), ""),
+	}
+
+	for _, category := range score.Categories {
+		rows = append(rows, csvRow(space, "", category.Category, csvScoreSeverity, "", "", "", "",
+			category.Category+" health score: "+checks.FormatScore(category.Score)+"/100 ("+category.Grade+")", ""))
+	}
+
+	for _, contributor := range score.TopContributors {
+		rows = append(rows, csvRow(space, contributor.CheckId, contributor.Category, csvScoreSeverity, "", "", "", "",
+			"Lowered the health score by "+checks.FormatScore(contributor.Points)+" points", checks.DocumentationLink(contributor.CheckId)))
+	}
+
+	return rows
+}
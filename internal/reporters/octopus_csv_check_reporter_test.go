@@ -0,0 +1,59 @@
+package reporters
+
+import (
+	"encoding/csv"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/samber/lo"
+	"strings"
+	"testing"
+)
+
+func TestCsvReport(t *testing.T) {
+	tooManyEnvironments := checks.NewOctopusCheckResultImpl("There are 30 environments", "OctoLintEnvironmentCount", "", checks.Error, checks.Organization)
+	passResult := checks.NewOctopusCheckResultImpl("This check always passes", "OctoRecAlwaysPass", "", checks.Ok, checks.Organization)
+
+	content, err := NewOctopusCsvCheckReporter(checks.Warning, OctopusReportMetadata{ServerUrl: "https://example.octopus.app"}).GenerateSpaces([]checks.OctopusSpaceResults{
+		{SpaceId: "Spaces-1", SpaceName: "Default", Results: []checks.OctopusCheckResult{unusedVariables(2), tooManyEnvironments, passResult}},
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	rows, err := csv.NewReader(strings.NewReader(content)).ReadAll()
+
+	if err != nil {
+		t.Fatal("Should have generated valid CSV: " + err.Error())
+	}
+
+	scoreRows := lo.Filter(rows, func(item []string, index int) bool {
+		return item[3] == csvScoreSeverity
+	})
+	rows = lo.Filter(rows, func(item []string, index int) bool {
+		return item[3] != csvScoreSeverity
+	})
+
+	if len(rows) != 4 {
+		t.Fatal("Should have returned a header, a row for each finding, and a row for the result without findings")
+	}
+
+	if len(scoreRows) == 0 || !strings.HasPrefix(scoreRows[0][8], "Health score: ") {
+		t.Fatal("Should have reported the health score of the space")
+	}
+
+	expected := []string{"Default", "OctoLintUnusedVariables", "Organization", "Warning", "Variable", "Variable_1", "Variables-1", "App",
+		"The variable is not used", "https://example.octopus.app/app#/Spaces-1/projects/Projects-1/variables"}
+	if strings.Join(rows[2], ",") != strings.Join(expected, ",") {
+		t.Fatalf("Unexpected row %v", rows[2])
+	}
+
+	if rows[3][1] != "OctoLintEnvironmentCount" || rows[3][4] != "" || rows[3][8] != "There are 30 environments" {
+		t.Fatal("Should have reported the result without findings")
+	}
+}
+
+func TestCsvFormulas(t *testing.T) {
+	if strings.Join(csvRow("=1+2", "-dev", "App"), ",") != "'=1+2,'-dev,App" {
+		t.Fatal("Should have prevented spreadsheets from treating the values as formulas")
+	}
+}
@@ -0,0 +1,185 @@
+package reporters
+
+import (
+	"bytes"
+	_ "embed"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/samber/lo"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+)
+
+//go:embed templates/report.html
+var htmlTemplate string
+
+type htmlReport struct {
+	OctolintVersion  string
+	ServerUrl        string
+	StartTime        string
+	Interrupted      bool
+	DocumentationUrl string
+	Summary          checks.OctopusCheckSummary
+	Categories       []htmlCategory
+	MultipleSpaces   bool
+	Spaces           []htmlSpace
+}
+
+type htmlSpace struct {
+	Id         string
+	Name       string
+	Summary    checks.OctopusCheckSummary
+	Score      checks.OctopusHealthScore
+	Categories []htmlCategory
+	Errors     []htmlError
+}
+
+type htmlCategory struct {
+	Name   string
+	Issues int
+	Checks []htmlCheck
+}
+
+type htmlCheck struct {
+	Code          string
+	Severity      string
+	SeverityClass string
+	Description   string
+	Link          string
+	Findings      []htmlFinding
+}
+
+type htmlError struct {
+	Code    string
+	Message string
+}
+
+type htmlFinding struct {
+	Name         string
+	Url          string
+	ResourceType string
+	Project      string
+	Message      string
+}
+
+// OctopusHtmlCheckReporter prints the lint reports as a single HTML page that can be shared and viewed offline. The
+// page has no external dependencies, and includes a summary, the health score of each space, collapsible sections for
+// each category and check, sortable tables of the affected resources with links to the Octopus web UI, and a text
+// filter.
+type OctopusHtmlCheckReporter struct {
+	minSeverity int
+	metadata    OctopusReportMetadata
+}
+
+func NewOctopusHtmlCheckReporter(minSeverity int, metadata OctopusReportMetadata) OctopusHtmlCheckReporter {
+	return OctopusHtmlCheckReporter{minSeverity: minSeverity, metadata: metadata}
+}
+
+func (o OctopusHtmlCheckReporter) Generate(results []checks.OctopusCheckResult) (string, error) {
+	return o.GenerateSpaces([]checks.OctopusSpaceResults{{Results: results}})
+}
+
+func (o OctopusHtmlCheckReporter) GenerateSpaces(spaces []checks.OctopusSpaceResults) (string, error) {
+	tmpl, err := template.New("report").Funcs(template.FuncMap{"score": checks.FormatScore}).Parse(htmlTemplate)
+
+	if err != nil {
+		return "", err
+	}
+
+	report := htmlReport{
+		OctolintVersion:  o.metadata.OctolintVersion,
+		ServerUrl:        o.metadata.ServerUrl,
+		Interrupted:      o.metadata.Interrupted,
+		DocumentationUrl: checks.DocumentationUrl,
+		Summary:          checks.NewOctopusCheckSummary(checks.AllResults(spaces)),
+		MultipleSpaces:   len(spaces) > 1,
+		Spaces:           []htmlSpace{},
+	}
+
+	if !o.metadata.StartTime.IsZero() {
+		report.StartTime = o.metadata.StartTime.Format(time.RFC1123)
+	}
+
+	categoryIssues := map[string]int{}
+
+	for _, space := range spaces {
+		urlBuilder := checks.NewOctopusUrlBuilder(o.metadata.ServerUrl, space.SpaceId).WithProjectRepositories(space.ProjectRepositories)
+		spaceReport := htmlSpace{
+			Id:         space.SpaceId,
+			Name:       spaceDisplayName(space),
+			Summary:    checks.NewOctopusCheckSummary(space.Results),
+			Score:      checks.NewOctopusHealthScore(space, o.metadata.ScoreWeights),
+			Categories: []htmlCategory{},
+			Errors:     []htmlError{},
+		}
+
+		categories := map[string]*htmlCategory{}
+
+		for _, r := range space.Results {
+			if r.Category() == checks.GeneralError {
+				spaceReport.Errors = append(spaceReport.Errors, htmlError{Code: r.Code(), Message: r.Description()})
+				continue
+			}
+
+			if r.Severity() < o.minSeverity {
+				continue
+			}
+
+			category, found := categories[resultCategory(r)]
+			if !found {
+				category = &htmlCategory{Name: resultCategory(r), Checks: []htmlCheck{}}
+				categories[category.Name] = category
+			}
+
+			check := htmlCheck{
+				Code:          r.Code(),
+				Severity:      checks.SeverityName(r.Severity()),
+				SeverityClass: strings.ToLower(checks.SeverityName(r.Severity())),
+				Description:   r.Description(),
+				Link:          resultLink(r),
+				Findings:      []htmlFinding{},
+			}
+
+			for _, f := range r.Findings() {
+				check.Findings = append(check.Findings, htmlFinding{
+					Name:         f.ResourceName,
+					Url:          urlBuilder.FindingUrl(f),
+					ResourceType: f.ResourceType,
+					Project:      f.ProjectName,
+					Message:      f.Message,
+				})
+			}
+
+			if r.Severity() >= checks.Info {
+				category.Issues++
+				categoryIssues[category.Name]++
+			}
+
+			category.Checks = append(category.Checks, check)
+		}
+
+		for _, name := range sortedKeys(categories) {
+			spaceReport.Categories = append(spaceReport.Categories, *categories[name])
+		}
+
+		report.Spaces = append(report.Spaces, spaceReport)
+	}
+
+	for _, name := range sortedKeys(categoryIssues) {
+		report.Categories = append(report.Categories, htmlCategory{Name: name, Issues: categoryIssues[name]})
+	}
+
+	var content bytes.Buffer
+	if err := tmpl.Execute(&content, report); err != nil {
+		return "", err
+	}
+
+	return content.String(), nil
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := lo.Keys(values)
+	sort.Strings(keys)
+	return keys
+}
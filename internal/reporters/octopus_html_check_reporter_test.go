@@ -0,0 +1,38 @@
+package reporters
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/organization"
+	"strings"
+	"testing"
+)
+
+func TestHtmlReport(t *testing.T) {
+	failedResult := checks.NewOctopusCheckResultImpl("The following projects have no runbooks and no deployment process:\n<App>", organization.OctoLintEmptyProject, "", checks.Warning, checks.Organization,
+		checks.OctopusCheckFinding{ResourceType: checks.ProjectResource, ResourceId: "Projects-1", ResourceName: "<App>", ProjectId: "Projects-1", ProjectName: "<App>", Message: "The project has no runbooks and no deployment process"})
+	erroredResult := checks.NewOctopusCheckResultImpl("The check failed to run: timeout", "OctoRecAlwaysTimeout", "", checks.Error, checks.GeneralError)
+
+	content, err := NewOctopusHtmlCheckReporter(checks.Warning, OctopusReportMetadata{ServerUrl: "https://example.octopus.app"}).GenerateSpaces([]checks.OctopusSpaceResults{
+		{SpaceId: "Spaces-1", SpaceName: "Default", Results: []checks.OctopusCheckResult{failedResult, erroredResult}},
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if !strings.Contains(content, `href="https://example.octopus.app/app#/Spaces-1/projects/Projects-1"`) {
+		t.Fatal("Should have linked the resource to the Octopus web UI")
+	}
+
+	if strings.Contains(content, "<App>") || !strings.Contains(content, "&lt;App&gt;") {
+		t.Fatal("Should have escaped the resource names")
+	}
+
+	if !strings.Contains(content, "OctoRecAlwaysTimeout") || !strings.Contains(content, "Checks that failed to run") {
+		t.Fatal("Should have listed the checks that failed to run")
+	}
+
+	if strings.Contains(content, "<script src") || strings.Contains(content, "<link") {
+		t.Fatal("Should not have referenced any external scripts or styles")
+	}
+}
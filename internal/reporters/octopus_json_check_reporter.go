@@ -0,0 +1,182 @@
+package reporters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"os"
+	"time"
+)
+
+// JsonSchemaVersion is the version of the JSON report format. It is incremented when a field is removed or changes
+// meaning. New fields may be added without changing the version.
+const JsonSchemaVersion = 1
+
+// JsonReport is the document generated by OctopusJsonCheckReporter.
+type JsonReport struct {
+	SchemaVersion   int                        `json:"schemaVersion"`
+	OctolintVersion string                     `json:"octolintVersion"`
+	ServerUrl       string                     `json:"serverUrl"`
+	StartTime       time.Time                  `json:"startTime"`
+	EndTime         time.Time                  `json:"endTime"`
+	Interrupted     bool                       `json:"interrupted"`
+	Summary         checks.OctopusCheckSummary `json:"summary"`
+	Spaces          []JsonSpaceReport          `json:"spaces"`
+}
+
+// JsonSpaceReport holds the results of a single space.
+type JsonSpaceReport struct {
+	SpaceId   string                     `json:"spaceId"`
+	SpaceName string                     `json:"spaceName"`
+	Summary   checks.OctopusCheckSummary `json:"summary"`
+	// HealthScore rates the space from 0 to 100, based on the issues found by the checks
+	HealthScore checks.OctopusHealthScore `json:"healthScore"`
+	// Results lists the results of the checks that ran, at or above the minimum severity
+	Results []JsonCheckResult `json:"results"`
+	// Errors lists the checks that failed to run
+	Errors []JsonCheckError `json:"errors"`
+}
+
+// JsonCheckResult is the result of a single check.
+type JsonCheckResult struct {
+	Code        string                       `json:"code"`
+	Category    string                       `json:"category"`
+	Severity    string                       `json:"severity"`
+	Description string                       `json:"description"`
+	Link        string                       `json:"link"`
+	Findings    []checks.OctopusCheckFinding `json:"findings"`
+}
+
+// JsonCheckError describes a check that failed to run.
+type JsonCheckError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// OctopusJsonCheckReporter prints the lint reports as a JSON document, for consumption by other tools.
+type OctopusJsonCheckReporter struct {
+	minSeverity int
+	metadata    OctopusReportMetadata
+}
+
+func NewOctopusJsonCheckReporter(minSeverity int, metadata OctopusReportMetadata) OctopusJsonCheckReporter {
+	return OctopusJsonCheckReporter{minSeverity: minSeverity, metadata: metadata}
+}
+
+func (o OctopusJsonCheckReporter) Generate(results []checks.OctopusCheckResult) (string, error) {
+	return o.GenerateSpaces([]checks.OctopusSpaceResults{{Results: results}})
+}
+
+func (o OctopusJsonCheckReporter) GenerateSpaces(spaces []checks.OctopusSpaceResults) (string, error) {
+	report := JsonReport{
+		SchemaVersion:   JsonSchemaVersion,
+		OctolintVersion: o.metadata.OctolintVersion,
+		ServerUrl:       o.metadata.ServerUrl,
+		StartTime:       o.metadata.StartTime,
+		EndTime:         o.metadata.EndTime,
+		Interrupted:     o.metadata.Interrupted,
+		Summary:         checks.NewOctopusCheckSummary(checks.AllResults(spaces)),
+		Spaces:          []JsonSpaceReport{},
+	}
+
+	for _, space := range spaces {
+		spaceReport := JsonSpaceReport{
+			SpaceId:     space.SpaceId,
+			SpaceName:   space.SpaceName,
+			Summary:     checks.NewOctopusCheckSummary(space.Results),
+			HealthScore: checks.NewOctopusHealthScore(space, o.metadata.ScoreWeights),
+			Results:     []JsonCheckResult{},
+			Errors:      []JsonCheckError{},
+		}
+
+		for _, r := range space.Results {
+			if r.Category() == checks.GeneralError {
+				spaceReport.Errors = append(spaceReport.Errors, JsonCheckError{
+					Code:    r.Code(),
+					Message: r.Description(),
+				})
+				continue
+			}
+
+			if r.Severity() < o.minSeverity {
+				continue
+			}
+
+			spaceReport.Results = append(spaceReport.Results, JsonCheckResult{
+				Code:        r.Code(),
+				Category:    r.Category(),
+				Severity:    checks.SeverityName(r.Severity()),
+				Description: r.Description(),
+				Link:        resultLink(r),
+				Findings:    r.Findings(),
+			})
+		}
+
+		report.Spaces = append(report.Spaces, spaceReport)
+	}
+
+	content, err := json.MarshalIndent(report, "", "  ")
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+// ErrNotJsonReport is returned by ReadJsonReport when the file is JSON, but not a report saved by the json format.
+var ErrNotJsonReport = errors.New("the file is not an octolint JSON report")
+
+// ReadJsonReport reads a report saved by the json format.
+func ReadJsonReport(path string) (JsonReport, error) {
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return JsonReport{}, err
+	}
+
+	report := JsonReport{}
+	if err := json.Unmarshal(content, &report); err != nil {
+		return JsonReport{}, err
+	}
+
+	if report.SchemaVersion < 1 {
+		return JsonReport{}, ErrNotJsonReport
+	}
+
+	if report.SchemaVersion > JsonSchemaVersion {
+		return JsonReport{}, fmt.Errorf("the JSON report schema version %d is not supported by this version of octolint, which supports up to version %d", report.SchemaVersion, JsonSchemaVersion)
+	}
+
+	return report, nil
+}
+
+// SpaceResults converts the report back into the results of each space. The report only includes the results at or
+// above the minimum severity of the scan that saved it, and the checks that failed to run are returned as results in
+// the GeneralError category.
+func (o JsonReport) SpaceResults() ([]checks.OctopusSpaceResults, error) {
+	spaces := []checks.OctopusSpaceResults{}
+
+	for _, space := range o.Spaces {
+		results := []checks.OctopusCheckResult{}
+
+		for _, r := range space.Results {
+			severity, err := checks.ParseSeverity(r.Severity)
+
+			if err != nil {
+				return nil, fmt.Errorf("the result of the check %s is invalid: %w", r.Code, err)
+			}
+
+			results = append(results, checks.NewOctopusCheckResultImpl(r.Description, r.Code, r.Link, severity, r.Category, r.Findings...))
+		}
+
+		for _, e := range space.Errors {
+			results = append(results, checks.NewOctopusCheckResultImpl(e.Message, e.Code, "", checks.Error, checks.GeneralError))
+		}
+
+		spaces = append(spaces, checks.OctopusSpaceResults{SpaceId: space.SpaceId, SpaceName: space.SpaceName, Results: results})
+	}
+
+	return spaces, nil
+}
@@ -0,0 +1,125 @@
+package reporters
+
+import (
+	"encoding/json"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestJsonReport(t *testing.T) {
+	failedResult := checks.NewOctopusCheckResultImpl("This check always fails", "OctoRecAlwaysFail", "", checks.Error, checks.Organization,
+		checks.OctopusCheckFinding{ResourceType: checks.ProjectResource, ResourceId: "Projects-1", ResourceName: "App"})
+	passResult := checks.NewOctopusCheckResultImpl("This check always passes", "OctoRecAlwaysPass", "", checks.Ok, checks.Organization)
+	erroredResult := checks.NewOctopusCheckResultImpl("The check failed to run: timeout", "OctoRecAlwaysTimeout", "", checks.Error, checks.GeneralError)
+
+	metadata := OctopusReportMetadata{
+		OctolintVersion: "1.0.0",
+		ServerUrl:       "https://example.octopus.app",
+		StartTime:       time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+		EndTime:         time.Date(2024, 6, 1, 12, 1, 0, 0, time.UTC),
+	}
+
+	content, err := NewOctopusJsonCheckReporter(checks.Warning, metadata).GenerateSpaces([]checks.OctopusSpaceResults{
+		{SpaceId: "Spaces-1", SpaceName: "Default", Results: []checks.OctopusCheckResult{failedResult, passResult, erroredResult}},
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	report := JsonReport{}
+	if err := json.Unmarshal([]byte(content), &report); err != nil {
+		t.Fatal("Should have generated valid JSON: " + err.Error())
+	}
+
+	if report.SchemaVersion != JsonSchemaVersion || report.OctolintVersion != "1.0.0" || report.ServerUrl != "https://example.octopus.app" {
+		t.Fatal("Should have included the run metadata")
+	}
+
+	if len(report.Spaces) != 1 || report.Spaces[0].SpaceId != "Spaces-1" {
+		t.Fatal("Should have reported the space")
+	}
+
+	space := report.Spaces[0]
+	if len(space.Results) != 1 || space.Results[0].Code != "OctoRecAlwaysFail" || space.Results[0].Severity != "Error" {
+		t.Fatal("Should have only included the results at or above the minimum severity")
+	}
+
+	if space.Results[0].Link != checks.DocumentationUrl+"/OctoRecAlwaysFail" || len(space.Results[0].Findings) != 1 {
+		t.Fatal("Should have included the link and findings")
+	}
+
+	if len(space.Errors) != 1 || space.Errors[0].Code != "OctoRecAlwaysTimeout" {
+		t.Fatal("Should have reported the check that failed to run")
+	}
+
+	if report.Summary.Errors != 1 || report.Summary.Ok != 1 || report.Summary.Failed != 1 {
+		t.Fatal("Should have summarised every result")
+	}
+
+	if space.HealthScore.Score != 50 || space.HealthScore.Grade != "F" || space.HealthScore.Checks != 2 ||
+		len(space.HealthScore.TopContributors) != 1 || space.HealthScore.TopContributors[0].CheckId != "OctoRecAlwaysFail" {
+		t.Fatalf("Should have scored the checks that ran: %+v", space.HealthScore)
+	}
+}
+
+func TestUnknownFormat(t *testing.T) {
+	if _, err := NewOctopusCheckReporter(&config.OctolintConfig{Format: "yaml"}, checks.Warning, OctopusReportMetadata{}); err == nil {
+		t.Fatal("Should have returned an error")
+	}
+}
+
+func TestReadJsonReport(t *testing.T) {
+	failedResult := checks.NewOctopusCheckResultImpl("This check always fails", "OctoRecAlwaysFail", "", checks.Error, checks.Organization,
+		checks.OctopusCheckFinding{ResourceType: checks.ProjectResource, ResourceId: "Projects-1", ResourceName: "App"})
+	erroredResult := checks.NewOctopusCheckResultImpl("The check failed to run: timeout", "OctoRecAlwaysTimeout", "", checks.Error, checks.GeneralError)
+
+	content, err := NewOctopusJsonCheckReporter(checks.Warning, OctopusReportMetadata{}).GenerateSpaces([]checks.OctopusSpaceResults{
+		{SpaceId: "Spaces-1", SpaceName: "Default", Results: []checks.OctopusCheckResult{failedResult, erroredResult}},
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := ReadJsonReport(path)
+
+	if err != nil {
+		t.Fatal("Should have read the report: " + err.Error())
+	}
+
+	spaces, err := report.SpaceResults()
+
+	if err != nil || len(spaces) != 1 || len(spaces[0].Results) != 2 {
+		t.Fatal("Should have returned the results of the space")
+	}
+
+	result := spaces[0].Results[0]
+	if result.Code() != "OctoRecAlwaysFail" || result.Severity() != checks.Error || len(result.Findings()) != 1 {
+		t.Fatal("Should have returned the result and its findings")
+	}
+
+	if spaces[0].Results[1].Category() != checks.GeneralError {
+		t.Fatal("Should have returned the check that failed to run")
+	}
+}
+
+func TestReadJsonReportRejectsOtherFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := os.WriteFile(path, []byte(`{"version": 1}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ReadJsonReport(path); err != ErrNotJsonReport {
+		t.Fatal("Should have returned ErrNotJsonReport")
+	}
+}
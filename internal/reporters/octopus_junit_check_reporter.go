@@ -0,0 +1,216 @@
+package reporters
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/registry"
+	"github.com/samber/lo"
+	"strings"
+	"time"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Errors     int              `xml:"errors,attr"`
+	Skipped    int              `xml:"skipped,attr"`
+	Time       string           `xml:"time,attr"`
+	Timestamp  string           `xml:"timestamp,attr,omitempty"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	TestCases  []junitTestCase  `xml:"testcase"`
+
+	duration time.Duration
+	category string
+}
+
+type junitProperties struct {
+	Properties []junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// OctopusJUnitCheckReporter prints the lint reports as JUnit XML, so CI servers can display the checks as tests. Each
+// check is a test case, grouped into a test suite for each category. Issues at or above the minimum severity are
+// failures, checks that failed to run are errors, and checks that could not run due to missing permissions are
+// skipped.
+type OctopusJUnitCheckReporter struct {
+	minSeverity int
+	metadata    OctopusReportMetadata
+}
+
+func NewOctopusJUnitCheckReporter(minSeverity int, metadata OctopusReportMetadata) OctopusJUnitCheckReporter {
+	return OctopusJUnitCheckReporter{minSeverity: minSeverity, metadata: metadata}
+}
+
+func (o OctopusJUnitCheckReporter) Generate(results []checks.OctopusCheckResult) (string, error) {
+	return o.GenerateSpaces([]checks.OctopusSpaceResults{{Results: results}})
+}
+
+func (o OctopusJUnitCheckReporter) GenerateSpaces(spaces []checks.OctopusSpaceResults) (string, error) {
+	report := junitTestSuites{
+		Name:   "octolint",
+		Suites: []junitTestSuite{},
+	}
+
+	var totalDuration time.Duration
+
+	for _, space := range spaces {
+		suites := []*junitTestSuite{}
+		suiteIndexes := map[string]*junitTestSuite{}
+
+		for _, r := range space.Results {
+			category := resultCategory(r)
+			suiteName := category
+
+			// The suites are prefixed with the space when reporting on many spaces, as a CI server will usually merge
+			// suites with the same name
+			if len(spaces) > 1 {
+				suiteName = spaceDisplayName(space) + "." + category
+			}
+
+			suite, found := suiteIndexes[suiteName]
+			if !found {
+				suite = &junitTestSuite{Name: suiteName, TestCases: []junitTestCase{}, category: category}
+				if !o.metadata.StartTime.IsZero() {
+					suite.Timestamp = o.metadata.StartTime.UTC().Format("2006-01-02T15:04:05")
+				}
+				suiteIndexes[suiteName] = suite
+				suites = append(suites, suite)
+			}
+
+			duration := space.Durations[r.Code()]
+			testCase := junitTestCase{
+				Name:      r.Code(),
+				ClassName: "octolint." + suiteName,
+				Time:      junitSeconds(duration),
+			}
+
+			switch {
+			case r.Category() == checks.GeneralError:
+				testCase.Error = &junitMessage{Message: firstLine(r.Description()), Text: r.Description()}
+				suite.Errors++
+				report.Errors++
+			case r.Severity() >= checks.Warning && r.Severity() >= o.minSeverity:
+				testCase.Failure = &junitMessage{Message: firstLine(r.Description()), Type: checks.SeverityName(r.Severity()), Text: r.Description()}
+				suite.Failures++
+				report.Failures++
+			case r.Severity() >= checks.Permission && r.Severity() < checks.Info:
+				testCase.Skipped = &junitMessage{Message: firstLine(r.Description())}
+				suite.Skipped++
+				report.Skipped++
+			case r.Severity() > checks.Ok:
+				testCase.SystemOut = r.Description()
+			}
+
+			suite.Tests++
+			suite.duration += duration
+			report.Tests++
+			totalDuration += duration
+
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+
+		score := checks.NewOctopusHealthScore(space, o.metadata.ScoreWeights)
+
+		for _, suite := range suites {
+			suite.Time = junitSeconds(suite.duration)
+			suite.Properties = junitScoreProperties(score, *suite)
+			report.Suites = append(report.Suites, *suite)
+		}
+	}
+
+	report.Time = junitSeconds(totalDuration)
+
+	content, err := xml.MarshalIndent(report, "", "  ")
+
+	if err != nil {
+		return "", err
+	}
+
+	return xml.Header + string(content), nil
+}
+
+// junitScoreProperties describes the health score of the space, the score of the category of the suite, and the checks
+// in the suite that are among the top contributors to the score.
+func junitScoreProperties(score checks.OctopusHealthScore, suite junitTestSuite) *junitProperties {
+	if !score.Scored() {
+		return nil
+	}
+
+	properties := []junitProperty{
+		{Name: "spaceHealthScore", Value: checks.FormatScore(score.Score)},
+		{Name: "spaceHealthGrade", Value: score.Grade},
+	}
+
+	if category, found := lo.Find(score.Categories, func(item checks.OctopusCategoryScore) bool {
+		return item.Category == suite.category
+	}); found {
+		properties = append(properties,
+			junitProperty{Name: "categoryHealthScore", Value: checks.FormatScore(category.Score)},
+			junitProperty{Name: "categoryHealthGrade", Value: category.Grade})
+	}
+
+	for _, contributor := range score.TopContributors {
+		if contributor.Category == suite.category {
+			properties = append(properties, junitProperty{Name: "topContributor", Value: contributor.Describe()})
+		}
+	}
+
+	return &junitProperties{Properties: properties}
+}
+
+// resultCategory returns the category of the check that produced the result. Checks that failed to run report the
+// GeneralError category, so the category is looked up in the registry instead.
+func resultCategory(result checks.OctopusCheckResult) string {
+	if result.Category() == checks.GeneralError {
+		if check, found := registry.GetCheck(result.Code()); found {
+			return check.Category
+		}
+	}
+
+	if result.Category() == "" {
+		return "Other"
+	}
+
+	return result.Category()
+}
+
+func junitSeconds(duration time.Duration) string {
+	return fmt.Sprintf("%.3f", duration.Seconds())
+}
+
+func firstLine(text string) string {
+	return strings.SplitN(text, "\n", 2)[0]
+}
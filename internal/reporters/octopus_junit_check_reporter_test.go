@@ -0,0 +1,61 @@
+package reporters
+
+import (
+	"encoding/xml"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/organization"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/security"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJUnitReport(t *testing.T) {
+	failedResult := checks.NewOctopusCheckResultImpl("The following projects have no runbooks and no deployment process:\nApp", organization.OctoLintEmptyProject, "", checks.Warning, checks.Organization)
+	passResult := checks.NewOctopusCheckResultImpl("There are 3 environments", organization.OctopusEnvironmentCountCheckName, "", checks.Ok, checks.Organization)
+	permissionResult := checks.NewOctopusCheckResultImpl("The API key does not have permission to read the accounts", security.OctoLintUnrotatedAccounts, "", checks.Permission, checks.Security)
+	erroredResult := checks.NewOctopusCheckResultImpl("The check failed to run: the check timed out", security.OctoLintPerpetualApiKeys, "", checks.Error, checks.GeneralError)
+
+	content, err := NewOctopusJUnitCheckReporter(checks.Warning, OctopusReportMetadata{}).GenerateSpaces([]checks.OctopusSpaceResults{
+		{
+			SpaceId:   "Spaces-1",
+			SpaceName: "Default",
+			Results:   []checks.OctopusCheckResult{failedResult, passResult, permissionResult, erroredResult},
+			Durations: map[string]time.Duration{organization.OctoLintEmptyProject: 1500 * time.Millisecond},
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if !strings.HasPrefix(content, xml.Header) {
+		t.Fatal("Should have included the XML header")
+	}
+
+	report := junitTestSuites{}
+	if err := xml.Unmarshal([]byte(content), &report); err != nil {
+		t.Fatal("Should have generated valid XML: " + err.Error())
+	}
+
+	if report.Tests != 4 || report.Failures != 1 || report.Errors != 1 || report.Skipped != 1 {
+		t.Fatalf("Unexpected totals: %+v", report)
+	}
+
+	if len(report.Suites) != 2 || report.Suites[0].Name != checks.Organization || report.Suites[1].Name != checks.Security {
+		t.Fatal("Should have grouped the checks by category, including the checks that failed to run")
+	}
+
+	emptyProject := report.Suites[0].TestCases[0]
+	if emptyProject.Failure == nil || emptyProject.Failure.Message != "The following projects have no runbooks and no deployment process:" {
+		t.Fatal("Should have reported the warning as a failure")
+	}
+
+	if emptyProject.Time != "1.500" || report.Suites[0].Time != "1.500" {
+		t.Fatal("Should have reported the time the check took")
+	}
+
+	if report.Suites[1].TestCases[1].Error == nil {
+		t.Fatal("Should have reported the check that failed to run as an error")
+	}
+}
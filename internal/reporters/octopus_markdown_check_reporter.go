@@ -0,0 +1,253 @@
+package reporters
+
+import (
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/samber/lo"
+	"sort"
+	"strings"
+)
+
+// markdownEscaper escapes the characters that would otherwise be rendered as markdown or HTML.
+var markdownEscaper = strings.NewReplacer(
+	"\\", "\\\\",
+	"`", "\\`",
+	"*", "\\*",
+	"_", "\\_",
+	"[", "\\[",
+	"]", "\\]",
+	"<", "&lt;",
+	">", "&gt;",
+	"|", "\\|",
+	"#", "\\#")
+
+// OctopusMarkdownCheckReporter prints the lint reports as markdown, for pull request comments and wiki pages. The report
+// has a summary table, followed by a section for each check that found issues. Long lists of resources are truncated,
+// and the checks that do not fit in the maximum length of the report are left out, so the report can be posted to
+// systems that limit the size of a comment.
+type OctopusMarkdownCheckReporter struct {
+	minSeverity int
+	metadata    OctopusReportMetadata
+	// maxFindings is the number of resources listed for each check. A value of 0 lists all the resources.
+	maxFindings int
+	// maxLength is the maximum number of characters in the report. A value of 0 disables the limit.
+	maxLength int
+	// collapse places the resources in a collapsible details block
+	collapse bool
+}
+
+func NewOctopusMarkdownCheckReporter(minSeverity int, metadata OctopusReportMetadata, maxFindings int, maxLength int, collapse bool) OctopusMarkdownCheckReporter {
+	return OctopusMarkdownCheckReporter{
+		minSeverity: minSeverity,
+		metadata:    metadata,
+		maxFindings: maxFindings,
+		maxLength:   maxLength,
+		collapse:    collapse,
+	}
+}
+
+func (o OctopusMarkdownCheckReporter) Generate(results []checks.OctopusCheckResult) (string, error) {
+	return o.GenerateSpaces([]checks.OctopusSpaceResults{{Results: results}})
+}
+
+func (o OctopusMarkdownCheckReporter) GenerateSpaces(spaces []checks.OctopusSpaceResults) (string, error) {
+	header := []string{"## Octolint report", o.summaryTable(spaces)}
+	header = append(header, o.healthScore(spaces)...)
+
+	if o.metadata.Interrupted {
+		header = append(header, "The scan was interrupted. This report only includes the checks that completed.")
+	}
+
+	sections := []string{}
+	for _, space := range spaces {
+		spaceSections := o.spaceSections(space)
+
+		// The sections of each space are preceded by a heading when reporting on many spaces
+		if len(spaces) > 1 && len(spaceSections) != 0 {
+			spaceSections[0] = "## " + markdownEscaper.Replace(spaceDisplayName(space)) + "\n\n" + spaceSections[0]
+		}
+
+		sections = append(sections, spaceSections...)
+	}
+
+	if len(sections) == 0 {
+		header = append(header, "No issues detected")
+	}
+
+	footer := "The checks are documented at " + checks.DocumentationUrl
+
+	report := strings.Join(header, "\n\n")
+	for i, section := range sections {
+		omitted := len(sections) - i
+		truncatedNote := fmt.Sprintf("_%d more section(s) were left out to keep this report under %d characters._", omitted, o.maxLength)
+
+		// Leave room for the note explaining that the report was truncated
+		if o.maxLength > 0 && len(report)+len(section)+len(truncatedNote)+len(footer)+6 > o.maxLength {
+			report += "\n\n" + truncatedNote
+			break
+		}
+
+		report += "\n\n" + section
+	}
+
+	return report + "\n\n" + footer + "\n", nil
+}
+
+// summaryTable counts the results by severity, with a row for each space when reporting on many spaces.
+func (o OctopusMarkdownCheckReporter) summaryTable(spaces []checks.OctopusSpaceResults) string {
+	rows := []string{
+		"| Space | Errors | Warnings | Info | Permission errors | Passed | Failed to run |",
+		"|---|---|---|---|---|---|---|",
+	}
+
+	summaryRow := func(name string, summary checks.OctopusCheckSummary) string {
+		return fmt.Sprintf("| %s | %d | %d | %d | %d | %d | %d |", name, summary.Errors, summary.Warnings, summary.Info,
+			summary.Permission, summary.Ok, summary.Failed)
+	}
+
+	if len(spaces) > 1 {
+		for _, space := range spaces {
+			rows = append(rows, summaryRow(markdownEscaper.Replace(spaceDisplayName(space)), checks.NewOctopusCheckSummary(space.Results)))
+		}
+	}
+
+	rows = append(rows, summaryRow("**Total**", checks.NewOctopusCheckSummary(checks.AllResults(spaces))))
+
+	return strings.Join(rows, "\n")
+}
+
+// healthScore returns a table with the health score of each space and category, followed by the checks that lowered
+// the score of each space the most.
+func (o OctopusMarkdownCheckReporter) healthScore(spaces []checks.OctopusSpaceResults) []string {
+	rows := []string{
+		"| Space | Health score | " + strings.Join(checks.ScoredCategories, " | ") + " |",
+		"|---|---" + strings.Repeat("|---", len(checks.ScoredCategories)) + "|",
+	}
+	contributors := []string{}
+
+	for _, space := range spaces {
+		score := checks.NewOctopusHealthScore(space, o.metadata.ScoreWeights)
+
+		if !score.Scored() {
+			continue
+		}
+
+		row := "| " + markdownEscaper.Replace(spaceDisplayName(space)) + " | **" + checks.FormatScore(score.Score) + "** (" + score.Grade + ") |"
+		for _, name := range checks.ScoredCategories {
+			category, found := lo.Find(score.Categories, func(item checks.OctopusCategoryScore) bool {
+				return item.Category == name
+			})
+
+			if found {
+				row += " " + checks.FormatScore(category.Score) + " (" + category.Grade + ") |"
+			} else {
+				row += " - |"
+			}
+		}
+		rows = append(rows, row)
+
+		if len(score.TopContributors) != 0 {
+			heading := "Top contributors to the health score"
+			if len(spaces) > 1 {
+				heading += " of " + markdownEscaper.Replace(spaceDisplayName(space))
+			}
+
+			items := []string{heading + ":", ""}
+			for _, contributor := range score.TopContributors {
+				items = append(items, "- "+markdownEscaper.Replace(contributor.Describe()))
+			}
+			contributors = append(contributors, strings.Join(items, "\n"))
+		}
+	}
+
+	if len(rows) == 2 {
+		return []string{}
+	}
+
+	return append([]string{strings.Join(rows, "\n")}, contributors...)
+}
+
+// spaceSections returns a section for each check that found issues in the space, ordered from the most to the least
+// severe, followed by a section listing the checks that failed to run.
+func (o OctopusMarkdownCheckReporter) spaceSections(space checks.OctopusSpaceResults) []string {
+	urlBuilder := checks.NewOctopusUrlBuilder(o.metadata.ServerUrl, space.SpaceId).WithProjectRepositories(space.ProjectRepositories)
+
+	failing := []checks.OctopusCheckResult{}
+	errored := []string{}
+
+	for _, r := range space.Results {
+		if r.Category() == checks.GeneralError {
+			errored = append(errored, "- **"+r.Code()+"**: "+markdownEscaper.Replace(r.Description()))
+		} else if r.Severity() > checks.Ok && r.Severity() >= o.minSeverity {
+			failing = append(failing, r)
+		}
+	}
+
+	sort.SliceStable(failing, func(i, j int) bool {
+		return failing[i].Severity() > failing[j].Severity()
+	})
+
+	sections := []string{}
+	for _, r := range failing {
+		sections = append(sections, o.checkSection(r, urlBuilder))
+	}
+
+	if len(errored) != 0 {
+		sections = append(sections, "### Checks that failed to run\n\n"+strings.Join(errored, "\n"))
+	}
+
+	return sections
+}
+
+func (o OctopusMarkdownCheckReporter) checkSection(result checks.OctopusCheckResult, urlBuilder checks.OctopusUrlBuilder) string {
+	section := []string{
+		"### " + result.Code() + " (" + checks.SeverityName(result.Severity()) + ")",
+		resultCategory(result) + " · [Documentation](" + resultLink(result) + ")",
+	}
+
+	if len(result.Findings()) == 0 {
+		return strings.Join(append(section, markdownEscaper.Replace(result.Description())), "\n\n")
+	}
+
+	section = append(section, markdownEscaper.Replace(firstLine(result.Description())))
+
+	items := []string{}
+	for i, f := range result.Findings() {
+		if o.maxFindings > 0 && i >= o.maxFindings {
+			items = append(items, fmt.Sprintf("- ...and %d more", len(result.Findings())-o.maxFindings))
+			break
+		}
+
+		items = append(items, markdownFinding(f, urlBuilder))
+	}
+
+	list := strings.Join(items, "\n")
+
+	if o.collapse {
+		list = fmt.Sprintf("<details>\n<summary>%d affected resource(s)</summary>\n\n%s\n\n</details>", len(result.Findings()), list)
+	}
+
+	return strings.Join(append(section, list), "\n\n")
+}
+
+// markdownFinding is a list item describing an affected resource, linked to the Octopus web UI when possible.
+func markdownFinding(finding checks.OctopusCheckFinding, urlBuilder checks.OctopusUrlBuilder) string {
+	name := markdownEscaper.Replace(finding.ResourceName)
+	if url := urlBuilder.FindingUrl(finding); url != "" {
+		name = "[" + name + "](" + url + ")"
+	}
+
+	item := "- " + name + " (" + finding.ResourceType
+
+	if finding.ProjectName != "" && !(finding.ResourceType == checks.ProjectResource && finding.ResourceName == finding.ProjectName) {
+		item += " in " + markdownEscaper.Replace(finding.ProjectName)
+	}
+
+	item += ")"
+
+	if finding.Message != "" {
+		item += ": " + markdownEscaper.Replace(finding.Message)
+	}
+
+	return item
+}
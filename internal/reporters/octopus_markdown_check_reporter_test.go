@@ -0,0 +1,83 @@
+package reporters
+
+import (
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/organization"
+	"strings"
+	"testing"
+)
+
+func unusedVariables(count int) checks.OctopusCheckResult {
+	findings := []checks.OctopusCheckFinding{}
+	for i := 0; i < count; i++ {
+		findings = append(findings, checks.OctopusCheckFinding{
+			ResourceType: checks.VariableResource,
+			ResourceId:   fmt.Sprint("Variables-", i),
+			ResourceName: fmt.Sprint("Variable_", i),
+			ProjectId:    "Projects-1",
+			ProjectName:  "App",
+			Message:      "The variable is not used",
+		})
+	}
+
+	return checks.NewOctopusCheckResultImpl("The following variables may be unused:", organization.OctoLintUnusedVariables, "", checks.Warning, checks.Organization, findings...)
+}
+
+func TestMarkdownReport(t *testing.T) {
+	tooManyEnvironments := checks.NewOctopusCheckResultImpl("There are 30 environments", organization.OctopusEnvironmentCountCheckName, "", checks.Error, checks.Organization)
+	erroredResult := checks.NewOctopusCheckResultImpl("The check failed to run: timeout", "OctoRecAlwaysTimeout", "", checks.Error, checks.GeneralError)
+
+	report, err := NewOctopusMarkdownCheckReporter(checks.Warning, OctopusReportMetadata{ServerUrl: "https://example.octopus.app"}, 3, 0, true).GenerateSpaces([]checks.OctopusSpaceResults{
+		{SpaceId: "Spaces-1", SpaceName: "Default", Results: []checks.OctopusCheckResult{unusedVariables(5), tooManyEnvironments, erroredResult}},
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if !strings.Contains(report, "| **Total** | 1 | 1 | 0 | 0 | 0 | 1 |") {
+		t.Fatal("Should have summarised the results by severity")
+	}
+
+	if strings.Index(report, organization.OctopusEnvironmentCountCheckName) > strings.Index(report, organization.OctoLintUnusedVariables) {
+		t.Fatal("Should have listed the most severe checks first")
+	}
+
+	if !strings.Contains(report, "- [Variable\\_2](https://example.octopus.app/app#/Spaces-1/projects/Projects-1/variables) (Variable in App): The variable is not used") {
+		t.Fatal("Should have listed the escaped and linked resources")
+	}
+
+	if strings.Contains(report, "Variable\\_3") || !strings.Contains(report, "- ...and 2 more") {
+		t.Fatal("Should have truncated the list of resources")
+	}
+
+	if !strings.Contains(report, "<summary>5 affected resource(s)</summary>") {
+		t.Fatal("Should have placed the resources in a collapsible block")
+	}
+
+	if !strings.Contains(report, "### Checks that failed to run") {
+		t.Fatal("Should have listed the checks that failed to run")
+	}
+}
+
+func TestMarkdownReportMaxLength(t *testing.T) {
+	results := []checks.OctopusCheckResult{}
+	for i := 0; i < 20; i++ {
+		results = append(results, unusedVariables(20))
+	}
+
+	report, err := NewOctopusMarkdownCheckReporter(checks.Warning, OctopusReportMetadata{}, 0, 5000, false).Generate(results)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if len(report) > 5000 {
+		t.Fatal("Should have kept the report under the maximum length, but it was " + fmt.Sprint(len(report)))
+	}
+
+	if !strings.Contains(report, "more section(s) were left out to keep this report under 5000 characters") {
+		t.Fatal("Should have noted that the report was truncated")
+	}
+}
@@ -0,0 +1,160 @@
+package reporters
+
+import (
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/registry"
+	"strconv"
+	"strings"
+)
+
+// OpenMetricsContentType is the content type of the metrics served by the metrics endpoint
+const OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// openMetricsEscaper escapes label values as required by the OpenMetrics text format
+var openMetricsEscaper = strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n")
+
+// openMetricsFamily is a metric and its samples, which are written together as required by the OpenMetrics format
+type openMetricsFamily struct {
+	name    string
+	help    string
+	samples []string
+}
+
+// OctopusOpenMetricsCheckReporter prints the lint reports in the OpenMetrics text format, to be collected by the
+// node_exporter textfile collector or served from the metrics endpoint. The severity label is the severity the check is
+// configured to report rather than the severity of its latest result, so a check that stops finding issues drops to
+// zero rather than disappearing from the graphs. For the same reason, the minimum severity is compared to the configured
+// severity of each check when deciding which findings to report. The duration, failures, and resources scanned are
+// reported for every check.
+type OctopusOpenMetricsCheckReporter struct {
+	minSeverity int
+	metadata    OctopusReportMetadata
+}
+
+func NewOctopusOpenMetricsCheckReporter(minSeverity int, metadata OctopusReportMetadata) OctopusOpenMetricsCheckReporter {
+	return OctopusOpenMetricsCheckReporter{minSeverity: minSeverity, metadata: metadata}
+}
+
+func (o OctopusOpenMetricsCheckReporter) Generate(results []checks.OctopusCheckResult) (string, error) {
+	return o.GenerateSpaces([]checks.OctopusSpaceResults{{Results: results}})
+}
+
+func (o OctopusOpenMetricsCheckReporter) GenerateSpaces(spaces []checks.OctopusSpaceResults) (string, error) {
+	findings := openMetricsFamily{name: "octolint_findings", help: "The number of resources with issues found by each check."}
+	durations := openMetricsFamily{name: "octolint_check_duration_seconds", help: "The time each check took to run, including retries."}
+	failures := openMetricsFamily{name: "octolint_check_failures", help: "Set to 1 when a check failed to run."}
+	scanned := openMetricsFamily{name: "octolint_check_resources_scanned", help: "The number of resources each check read from the space."}
+	healthScores := openMetricsFamily{name: "octolint_health_score", help: "The health score of each space, from 0 to 100."}
+	categoryScores := openMetricsFamily{name: "octolint_category_health_score", help: "The health score of each category in each space, from 0 to 100."}
+	contributions := openMetricsFamily{name: "octolint_health_score_contribution", help: "The points taken off the health score by the checks that lowered it the most."}
+
+	for _, space := range spaces {
+		spaceLabels := openMetricsLabels("space", spaceDisplayName(space), "space_id", space.SpaceId)
+
+		for _, r := range space.Results {
+			checkLabels := spaceLabels + "," + openMetricsLabels("check", r.Code())
+
+			failed := 0
+			configuredSeverity := o.configuredSeverity(r)
+			if r.Category() == checks.GeneralError {
+				failed = 1
+			} else if configuredSeverity >= o.minSeverity {
+				findings.samples = append(findings.samples, fmt.Sprintf("octolint_findings{%s,%s} %d", checkLabels,
+					openMetricsLabels("category", resultCategory(r), "severity", strings.ToLower(checks.SeverityName(configuredSeverity))),
+					resultFindings(r)))
+			}
+
+			failures.samples = append(failures.samples, fmt.Sprintf("octolint_check_failures{%s} %d", checkLabels, failed))
+
+			if duration, ok := space.Durations[r.Code()]; ok {
+				durations.samples = append(durations.samples, fmt.Sprintf("octolint_check_duration_seconds{%s} %s", checkLabels,
+					openMetricsFloat(duration.Seconds())))
+			}
+
+			if count, ok := space.ResourcesScanned[r.Code()]; ok {
+				scanned.samples = append(scanned.samples, fmt.Sprintf("octolint_check_resources_scanned{%s} %d", checkLabels, count))
+			}
+		}
+
+		score := checks.NewOctopusHealthScore(space, o.metadata.ScoreWeights)
+
+		if !score.Scored() {
+			continue
+		}
+
+		healthScores.samples = append(healthScores.samples, fmt.Sprintf("octolint_health_score{%s} %s", spaceLabels, openMetricsFloat(score.Score)))
+
+		for _, category := range score.Categories {
+			categoryScores.samples = append(categoryScores.samples, fmt.Sprintf("octolint_category_health_score{%s,%s} %s", spaceLabels,
+				openMetricsLabels("category", category.Category), openMetricsFloat(category.Score)))
+		}
+
+		for _, contributor := range score.TopContributors {
+			contributions.samples = append(contributions.samples, fmt.Sprintf("octolint_health_score_contribution{%s,%s} %s", spaceLabels,
+				openMetricsLabels("check", contributor.CheckId), openMetricsFloat(contributor.Points)))
+		}
+	}
+
+	families := []openMetricsFamily{findings, durations, failures, scanned, healthScores, categoryScores, contributions}
+
+	if !o.metadata.EndTime.IsZero() {
+		families = append(families, openMetricsFamily{
+			name:    "octolint_last_scan_timestamp_seconds",
+			help:    "The time the last scan finished.",
+			samples: []string{"octolint_last_scan_timestamp_seconds " + strconv.FormatInt(o.metadata.EndTime.Unix(), 10)},
+		})
+	}
+
+	interrupted := 0
+	if o.metadata.Interrupted {
+		interrupted = 1
+	}
+
+	families = append(families, openMetricsFamily{
+		name:    "octolint_scan_interrupted",
+		help:    "Set to 1 when the last scan was interrupted before all the checks completed.",
+		samples: []string{"octolint_scan_interrupted " + fmt.Sprint(interrupted)},
+	})
+
+	report := []string{}
+	for _, family := range families {
+		report = append(report, "# TYPE "+family.name+" gauge", "# HELP "+family.name+" "+family.help)
+		report = append(report, family.samples...)
+	}
+
+	return strings.Join(append(report, "# EOF"), "\n") + "\n", nil
+}
+
+// configuredSeverity is the severity the check reports when it finds an issue. Checks that are not registered fall back
+// to the severity of their result.
+func (o OctopusOpenMetricsCheckReporter) configuredSeverity(result checks.OctopusCheckResult) int {
+	if severity, found := registry.ConfiguredSeverity(result.Code(), o.metadata.SeverityOverrides); found {
+		return severity
+	}
+
+	return result.Severity()
+}
+
+// resultFindings is the number of resources with issues reported by a check. A failing result without findings counts
+// as a single issue. A check without the permissions to read the space has not found any issues.
+func resultFindings(result checks.OctopusCheckResult) int {
+	if result.Severity() == checks.Ok || result.Severity() == checks.Permission {
+		return 0
+	}
+
+	return max(len(result.Findings()), 1)
+}
+
+func openMetricsFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// openMetricsLabels formats the labels, supplied as name and value pairs, as a comma separated list.
+func openMetricsLabels(labels ...string) string {
+	formatted := []string{}
+	for i := 0; i+1 < len(labels); i += 2 {
+		formatted = append(formatted, labels[i]+"=\""+openMetricsEscaper.Replace(labels[i+1])+"\"")
+	}
+	return strings.Join(formatted, ",")
+}
@@ -0,0 +1,101 @@
+package reporters
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOpenMetricsReport(t *testing.T) {
+	passResult := checks.NewOctopusCheckResultImpl("This check always passes", "OctoRecAlwaysPass", "", checks.Ok, checks.Organization)
+	erroredResult := checks.NewOctopusCheckResultImpl("The check failed to run: timeout", "OctoRecAlwaysTimeout", "", checks.Error, checks.GeneralError)
+
+	content, err := NewOctopusOpenMetricsCheckReporter(checks.Ok, OctopusReportMetadata{EndTime: time.Unix(1717243260, 0)}).GenerateSpaces([]checks.OctopusSpaceResults{
+		{
+			SpaceId:          "Spaces-1",
+			SpaceName:        "The \"Default\" space",
+			Results:          []checks.OctopusCheckResult{unusedVariables(2), passResult, erroredResult},
+			Durations:        map[string]time.Duration{"OctoLintUnusedVariables": 1500 * time.Millisecond},
+			ResourcesScanned: map[string]int{"OctoLintUnusedVariables": 12},
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	labels := `space="The \"Default\" space",space_id="Spaces-1",check="OctoLintUnusedVariables"`
+
+	expected := []string{
+		`octolint_findings{` + labels + `,category="Organization",severity="warning"} 2`,
+		`octolint_findings{space="The \"Default\" space",space_id="Spaces-1",check="OctoRecAlwaysPass",category="Organization",severity="ok"} 0`,
+		`octolint_check_duration_seconds{` + labels + `} 1.5`,
+		`octolint_check_failures{space="The \"Default\" space",space_id="Spaces-1",check="OctoRecAlwaysTimeout"} 1`,
+		`octolint_check_resources_scanned{` + labels + `} 12`,
+		`octolint_last_scan_timestamp_seconds 1717243260`,
+		`octolint_health_score{space="The \"Default\" space",space_id="Spaces-1"} 95.8`,
+		`octolint_category_health_score{space="The \"Default\" space",space_id="Spaces-1",category="Organization"} 95.8`,
+		`octolint_health_score_contribution{` + labels + `} 4.2`,
+	}
+
+	for _, line := range expected {
+		if !strings.Contains(content, line+"\n") {
+			t.Fatal("Should have included the sample " + line)
+		}
+	}
+
+	if strings.Contains(content, `octolint_findings{space="The \"Default\" space",space_id="Spaces-1",check="OctoRecAlwaysTimeout"`) {
+		t.Fatal("Should not have reported findings for the check that failed to run")
+	}
+
+	if !strings.HasSuffix(content, "# EOF\n") {
+		t.Fatal("Should have ended the report with the EOF marker")
+	}
+}
+
+func TestOpenMetricsSeverityIsStable(t *testing.T) {
+	failing := checks.NewOctopusCheckResultImpl("The following projects are empty", "OctoLintEmptyProject", "", checks.Warning, checks.Organization,
+		checks.OctopusCheckFinding{ResourceType: checks.ProjectResource, ResourceId: "Projects-1", ResourceName: "Empty"})
+	passing := checks.NewOctopusCheckResultImpl("There are no empty projects", "OctoLintEmptyProject", "", checks.Ok, checks.Organization)
+
+	for _, result := range []checks.OctopusCheckResult{failing, passing} {
+		content, err := NewOctopusOpenMetricsCheckReporter(checks.Ok, OctopusReportMetadata{}).Generate([]checks.OctopusCheckResult{result})
+
+		if err != nil || !strings.Contains(content, `check="OctoLintEmptyProject",category="Organization",severity="warning"}`) {
+			t.Fatal("Should have labelled the findings with the default severity of the check")
+		}
+	}
+
+	content, err := NewOctopusOpenMetricsCheckReporter(checks.Ok, OctopusReportMetadata{SeverityOverrides: map[string]int{"OctoLintEmptyProject": checks.Error}}).
+		Generate([]checks.OctopusCheckResult{passing})
+
+	if err != nil || !strings.Contains(content, `check="OctoLintEmptyProject",category="Organization",severity="error"} 0`) {
+		t.Fatal("Should have labelled the findings with the configured severity of the check")
+	}
+}
+
+func TestOpenMetricsMinSeverity(t *testing.T) {
+	overrides := map[string]int{"OctoLintEmptyProject": checks.Info}
+	passing := checks.NewOctopusCheckResultImpl("There are no empty projects", "OctoLintEmptyProject", "", checks.Ok, checks.Organization)
+	failing := checks.NewOctopusCheckResultImpl("The following projects are empty", "OctoLintEmptyProject", "", checks.Info, checks.Organization,
+		checks.OctopusCheckFinding{ResourceType: checks.ProjectResource, ResourceId: "Projects-1", ResourceName: "Empty"})
+
+	for _, result := range []checks.OctopusCheckResult{passing, failing} {
+		content, err := NewOctopusOpenMetricsCheckReporter(checks.Warning, OctopusReportMetadata{SeverityOverrides: overrides}).Generate([]checks.OctopusCheckResult{result})
+
+		if err != nil || strings.Contains(content, "octolint_findings{") {
+			t.Fatal("Should not have reported the findings of a check configured below the minimum severity")
+		}
+
+		if !strings.Contains(content, `octolint_check_failures{space="Space",space_id="",check="OctoLintEmptyProject"} 0`) {
+			t.Fatal("Should have reported the failures of every check")
+		}
+	}
+
+	content, err := NewOctopusOpenMetricsCheckReporter(checks.Info, OctopusReportMetadata{SeverityOverrides: overrides}).Generate([]checks.OctopusCheckResult{passing})
+
+	if err != nil || !strings.Contains(content, `check="OctoLintEmptyProject",category="Organization",severity="info"} 0`) {
+		t.Fatal("Should have reported the findings of a check configured at the minimum severity")
+	}
+}
@@ -8,10 +8,11 @@ import (
 // OctopusPlainCheckReporter prints the lint reports in plain text to std out.
 type OctopusPlainCheckReporter struct {
 	minSeverity int
+	metadata    OctopusReportMetadata
 }
 
-func NewOctopusPlainCheckReporter(minSeverity int) OctopusPlainCheckReporter {
-	return OctopusPlainCheckReporter{minSeverity: minSeverity}
+func NewOctopusPlainCheckReporter(minSeverity int, metadata OctopusReportMetadata) OctopusPlainCheckReporter {
+	return OctopusPlainCheckReporter{minSeverity: minSeverity, metadata: metadata}
 }
 
 func (o OctopusPlainCheckReporter) Generate(results []checks.OctopusCheckResult) (string, error) {
@@ -19,6 +20,58 @@ func (o OctopusPlainCheckReporter) Generate(results []checks.OctopusCheckResult)
 		return "", nil
 	}
 
+	report := o.generateResults(results)
+
+	if len(report) == 0 {
+		return "No issues detected", nil
+	} else {
+		report = append(report, "The checks are documented at "+checks.DocumentationUrl)
+	}
+
+	return strings.Join(report[:], "\n\n"), nil
+}
+
+// GenerateSpaces prints the results under a heading for each space, followed by a summary and the health score of the
+// space. The results of a single space are printed without a heading, followed by the health score.
+func (o OctopusPlainCheckReporter) GenerateSpaces(spaces []checks.OctopusSpaceResults) (string, error) {
+	if len(spaces) == 1 {
+		report, err := o.Generate(spaces[0].Results)
+
+		if err != nil || report == "" {
+			return report, err
+		}
+
+		return report + "\n\n" + plainHealthScore(checks.NewOctopusHealthScore(spaces[0], o.metadata.ScoreWeights)), nil
+	}
+
+	report := []string{}
+	issues := false
+
+	for _, space := range spaces {
+		spaceReport := o.generateResults(space.Results)
+		issues = issues || len(spaceReport) != 0
+
+		report = append(report, "####################################################################################################")
+		report = append(report, "Space: "+spaceHeading(space))
+
+		if len(spaceReport) == 0 {
+			report = append(report, "No issues detected")
+		} else {
+			report = append(report, spaceReport...)
+		}
+
+		report = append(report, "Summary for "+space.SpaceName+": "+checks.NewOctopusCheckSummary(space.Results).Counts())
+		report = append(report, plainHealthScore(checks.NewOctopusHealthScore(space, o.metadata.ScoreWeights)))
+	}
+
+	if issues {
+		report = append(report, "The checks are documented at "+checks.DocumentationUrl)
+	}
+
+	return strings.Join(report[:], "\n\n"), nil
+}
+
+func (o OctopusPlainCheckReporter) generateResults(results []checks.OctopusCheckResult) []string {
 	report := []string{}
 
 	for _, r := range results {
@@ -29,11 +82,25 @@ func (o OctopusPlainCheckReporter) Generate(results []checks.OctopusCheckResult)
 		}
 	}
 
-	if len(report) == 0 {
-		return "No issues detected", nil
-	} else {
-		report = append(report, "The checks are documented at https://github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/wiki")
+	return report
+}
+
+// plainHealthScore prints the health score, the score of each category, and the checks that lowered the score the most.
+func plainHealthScore(score checks.OctopusHealthScore) string {
+	lines := []string{score.String()}
+
+	if !score.Scored() {
+		return lines[0]
 	}
 
-	return strings.Join(report[:], "\n\n"), nil
+	lines = append(lines, "Categories: "+score.CategoryScores())
+
+	if len(score.TopContributors) != 0 {
+		lines = append(lines, "Top contributors:")
+		for _, contributor := range score.TopContributors {
+			lines = append(lines, "  "+contributor.Describe())
+		}
+	}
+
+	return strings.Join(lines, "\n")
 }
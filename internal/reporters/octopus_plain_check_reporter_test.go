@@ -62,3 +62,39 @@ func TestFailAndPassWithOkChecks(t *testing.T) {
 		t.Fatal("Should have returned 1 pass result")
 	}
 }
+
+func TestGenerateSpaces(t *testing.T) {
+	failedResult := checks.NewOctopusCheckResultImpl("This check always fails", "OctoRecAlwaysFail", "", checks.Error, "")
+	passResult := checks.NewOctopusCheckResultImpl("This check always passes", "OctoRecAlwaysPass", "", checks.Ok, "")
+	results, err := OctopusPlainCheckReporter{minSeverity: checks.Warning}.GenerateSpaces([]checks.OctopusSpaceResults{
+		{SpaceId: "Spaces-1", SpaceName: "Default", Results: []checks.OctopusCheckResult{failedResult}},
+		{SpaceId: "Spaces-2", SpaceName: "Team A", Results: []checks.OctopusCheckResult{passResult}},
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if strings.Index(results, "Space: Default (Spaces-1)") == -1 || strings.Index(results, "Space: Team A (Spaces-2)") == -1 {
+		t.Fatal("Should have grouped the results by space")
+	}
+
+	if strings.Index(results, "Summary for Default: 1 error(s)") == -1 || strings.Index(results, "Summary for Team A: 0 error(s)") == -1 {
+		t.Fatal("Should have summarised each space")
+	}
+}
+
+func TestGenerateSingleSpace(t *testing.T) {
+	failedResult := checks.NewOctopusCheckResultImpl("This check always fails", "OctoRecAlwaysFail", "", checks.Error, "")
+	results, err := OctopusPlainCheckReporter{minSeverity: checks.Warning}.GenerateSpaces([]checks.OctopusSpaceResults{
+		{SpaceId: "Spaces-1", SpaceName: "Default", Results: []checks.OctopusCheckResult{failedResult}},
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if strings.Index(results, "Space:") != -1 {
+		t.Fatal("Should not have added a heading for a single space")
+	}
+}
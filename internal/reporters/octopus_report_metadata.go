@@ -0,0 +1,20 @@
+package reporters
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"time"
+)
+
+// OctopusReportMetadata describes the scan that produced the results. It is included by the machine readable reports.
+type OctopusReportMetadata struct {
+	OctolintVersion string
+	ServerUrl       string
+	StartTime       time.Time
+	EndTime         time.Time
+	// Interrupted is true if the scan was cancelled before all the checks completed
+	Interrupted bool
+	// ScoreWeights sets how the health score of each space is calculated. The default weights are used if it is empty.
+	ScoreWeights checks.OctopusScoreWeights
+	// SeverityOverrides are the severities configured for the checks, keyed by the check ID
+	SeverityOverrides map[string]int
+}
@@ -0,0 +1,365 @@
+package reporters
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/registry"
+	"path"
+	"strings"
+	"time"
+)
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+const sarifVersion = "2.1.0"
+const sarifInformationUri = "https://github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine"
+
+// sarifFingerprintKey identifies the version of the fingerprint reported with each result. Code scanning tools use the
+// fingerprint to track a result between scans.
+const sarifFingerprintKey = "octolintFinding/v1"
+
+// oclFiles maps the resources saved in the OCL files of a version controlled project to the file they are saved in.
+var oclFiles = map[string]string{
+	checks.ProjectResource:  "deployment_settings.ocl",
+	checks.StepResource:     "deployment_process.ocl",
+	checks.VariableResource: "variables.ocl",
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool                     sarifTool                    `json:"tool"`
+	Invocations              []sarifInvocation            `json:"invocations"`
+	VersionControlProvenance []sarifVersionControlDetails `json:"versionControlProvenance,omitempty"`
+	Results                  []sarifResult                `json:"results"`
+	Properties               *sarifRunProperties          `json:"properties,omitempty"`
+}
+
+// sarifRunProperties is the property bag of the run, holding the values that SARIF has no place for
+type sarifRunProperties struct {
+	HealthScores []spaceHealthScore `json:"healthScores"`
+}
+
+type sarifTool struct {
+	Driver sarifToolComponent `json:"driver"`
+}
+
+type sarifToolComponent struct {
+	Name           string                     `json:"name"`
+	Version        string                     `json:"version,omitempty"`
+	InformationUri string                     `json:"informationUri"`
+	Rules          []sarifReportingDescriptor `json:"rules"`
+}
+
+type sarifReportingDescriptor struct {
+	Id                   string               `json:"id"`
+	Name                 string               `json:"name"`
+	ShortDescription     *sarifMessage        `json:"shortDescription,omitempty"`
+	FullDescription      *sarifMessage        `json:"fullDescription,omitempty"`
+	Help                 *sarifMessage        `json:"help,omitempty"`
+	HelpUri              string               `json:"helpUri"`
+	DefaultConfiguration *sarifConfiguration  `json:"defaultConfiguration,omitempty"`
+	Properties           *sarifRuleProperties `json:"properties,omitempty"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifRuleProperties struct {
+	Tags []string `json:"tags"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	StartTimeUtc               string              `json:"startTimeUtc,omitempty"`
+	EndTimeUtc                 string              `json:"endTimeUtc,omitempty"`
+	ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications"`
+}
+
+type sarifNotification struct {
+	Level      string                    `json:"level"`
+	Message    sarifMessage              `json:"message"`
+	Descriptor *sarifDescriptorReference `json:"descriptor,omitempty"`
+}
+
+type sarifDescriptorReference struct {
+	Id string `json:"id"`
+}
+
+type sarifVersionControlDetails struct {
+	RepositoryUri string                `json:"repositoryUri"`
+	Branch        string                `json:"branch,omitempty"`
+	MappedTo      sarifArtifactLocation `json:"mappedTo"`
+}
+
+type sarifResult struct {
+	RuleId              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Kind                string            `json:"kind"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	Uri       string `json:"uri,omitempty"`
+	UriBaseId string `json:"uriBaseId,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// OctopusSarifCheckReporter prints the lint reports as a SARIF 2.1.0 log, which can be uploaded to code scanning tools.
+// Each check is a rule, and each finding is a result. Findings are located by their space, project, and resource, and
+// by the OCL file they are saved in if the project is version controlled.
+type OctopusSarifCheckReporter struct {
+	minSeverity int
+	metadata    OctopusReportMetadata
+}
+
+func NewOctopusSarifCheckReporter(minSeverity int, metadata OctopusReportMetadata) OctopusSarifCheckReporter {
+	return OctopusSarifCheckReporter{minSeverity: minSeverity, metadata: metadata}
+}
+
+func (o OctopusSarifCheckReporter) Generate(results []checks.OctopusCheckResult) (string, error) {
+	return o.GenerateSpaces([]checks.OctopusSpaceResults{{Results: results}})
+}
+
+func (o OctopusSarifCheckReporter) GenerateSpaces(spaces []checks.OctopusSpaceResults) (string, error) {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifToolComponent{
+				Name:           "octolint",
+				Version:        o.metadata.OctolintVersion,
+				InformationUri: sarifInformationUri,
+				Rules:          []sarifReportingDescriptor{},
+			},
+		},
+		Results: []sarifResult{},
+	}
+
+	invocation := sarifInvocation{
+		ExecutionSuccessful:        !o.metadata.Interrupted,
+		ToolExecutionNotifications: []sarifNotification{},
+	}
+
+	if !o.metadata.StartTime.IsZero() {
+		invocation.StartTimeUtc = o.metadata.StartTime.UTC().Format(time.RFC3339)
+		invocation.EndTimeUtc = o.metadata.EndTime.UTC().Format(time.RFC3339)
+	}
+
+	ruleIndexes := map[string]int{}
+	repositoryIds := map[string]string{}
+
+	for _, space := range spaces {
+		for _, r := range space.Results {
+			if r.Category() == checks.GeneralError {
+				invocation.ExecutionSuccessful = false
+				invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, sarifNotification{
+					Level:      "error",
+					Message:    sarifMessage{Text: r.Description()},
+					Descriptor: &sarifDescriptorReference{Id: r.Code()},
+				})
+				continue
+			}
+
+			if r.Severity() < o.minSeverity {
+				continue
+			}
+
+			// A permission error means the check could not inspect the space, which is a problem with the scan rather
+			// than an issue with the space
+			if r.Severity() >= checks.Permission && r.Severity() < checks.Info {
+				invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, sarifNotification{
+					Level:      "warning",
+					Message:    sarifMessage{Text: r.Description()},
+					Descriptor: &sarifDescriptorReference{Id: r.Code()},
+				})
+				continue
+			}
+
+			ruleIndex, found := ruleIndexes[r.Code()]
+			if !found {
+				ruleIndex = len(run.Tool.Driver.Rules)
+				ruleIndexes[r.Code()] = ruleIndex
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule(r, o.metadata.SeverityOverrides))
+			}
+
+			kind, level := sarifKindAndLevel(r.Severity())
+
+			// Results without findings, like a count of environments, apply to the space as a whole
+			if len(r.Findings()) == 0 {
+				run.Results = append(run.Results, sarifResult{
+					RuleId:    r.Code(),
+					RuleIndex: ruleIndex,
+					Kind:      kind,
+					Level:     level,
+					Message:   sarifMessage{Text: r.Description()},
+					Locations: []sarifLocation{{LogicalLocations: []sarifLogicalLocation{spaceLocation(space)}}},
+				})
+				continue
+			}
+
+			for _, f := range r.Findings() {
+				message := f.Message
+				if message == "" {
+					message = r.Description()
+				}
+
+				location := sarifLocation{LogicalLocations: []sarifLogicalLocation{findingLocation(space, f)}}
+
+				if repository, found := space.ProjectRepositories[f.ProjectId]; found && oclFiles[f.ResourceType] != "" {
+					repositoryId, found := repositoryIds[repository.Url]
+					if !found {
+						repositoryId = "REPOSITORY" + fmt.Sprint(len(repositoryIds)+1)
+						repositoryIds[repository.Url] = repositoryId
+						run.VersionControlProvenance = append(run.VersionControlProvenance, sarifVersionControlDetails{
+							RepositoryUri: repository.Url,
+							Branch:        repository.DefaultBranch,
+							MappedTo:      sarifArtifactLocation{UriBaseId: repositoryId},
+						})
+					}
+
+					location.PhysicalLocation = &sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{
+							Uri:       path.Join(strings.Trim(repository.BasePath, "/"), oclFiles[f.ResourceType]),
+							UriBaseId: repositoryId,
+						},
+					}
+				}
+
+				run.Results = append(run.Results, sarifResult{
+					RuleId:              r.Code(),
+					RuleIndex:           ruleIndex,
+					Kind:                kind,
+					Level:               level,
+					Message:             sarifMessage{Text: message},
+					Locations:           []sarifLocation{location},
+					PartialFingerprints: map[string]string{sarifFingerprintKey: checks.Fingerprint(r.Code(), f)},
+				})
+			}
+		}
+	}
+
+	run.Invocations = []sarifInvocation{invocation}
+	run.Properties = &sarifRunProperties{HealthScores: spaceHealthScores(spaces, o.metadata.ScoreWeights)}
+
+	content, err := json.MarshalIndent(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	}, "", "  ")
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+// sarifRule describes the check that reported the result, using the registry documentation if the check is registered.
+// The default level of the rule is the severity configured for the check.
+func sarifRule(result checks.OctopusCheckResult, severityOverrides map[string]int) sarifReportingDescriptor {
+	rule := sarifReportingDescriptor{
+		Id:         result.Code(),
+		Name:       result.Code(),
+		HelpUri:    checks.DocumentationLink(result.Code()),
+		Properties: &sarifRuleProperties{Tags: []string{result.Category()}},
+	}
+
+	if check, found := registry.GetCheck(result.Code()); found {
+		severity, _ := registry.ConfiguredSeverity(result.Code(), severityOverrides)
+		_, level := sarifKindAndLevel(severity)
+		rule.ShortDescription = &sarifMessage{Text: check.Description}
+		rule.FullDescription = &sarifMessage{Text: check.Rationale}
+		rule.Help = &sarifMessage{Text: check.Remediation}
+		rule.HelpUri = check.DocumentationUrl
+		rule.DefaultConfiguration = &sarifConfiguration{Level: level}
+		rule.Properties = &sarifRuleProperties{Tags: []string{check.Category}}
+	}
+
+	return rule
+}
+
+// sarifKindAndLevel maps a severity to a SARIF result kind and level. Passing results are reported with the "pass" kind.
+func sarifKindAndLevel(severity int) (string, string) {
+	switch {
+	case severity >= checks.Error:
+		return "fail", "error"
+	case severity >= checks.Warning:
+		return "fail", "warning"
+	case severity >= checks.Info:
+		return "fail", "note"
+	default:
+		return "pass", "none"
+	}
+}
+
+// spaceLocation is the logical location of a result that applies to a whole space.
+func spaceLocation(space checks.OctopusSpaceResults) sarifLogicalLocation {
+	return sarifLogicalLocation{
+		Name:               spaceDisplayName(space),
+		FullyQualifiedName: spaceDisplayName(space),
+		Kind:               "namespace",
+	}
+}
+
+// findingLocation is the logical location of a finding, like "Default/My Project/Deploy a package".
+func findingLocation(space checks.OctopusSpaceResults, finding checks.OctopusCheckFinding) sarifLogicalLocation {
+	names := []string{spaceDisplayName(space)}
+
+	if finding.ProjectName != "" && !(finding.ResourceType == checks.ProjectResource && finding.ResourceName == finding.ProjectName) {
+		names = append(names, finding.ProjectName)
+	}
+
+	names = append(names, finding.ResourceName)
+
+	return sarifLogicalLocation{
+		Name:               finding.ResourceName,
+		FullyQualifiedName: strings.Join(names, "/"),
+		Kind:               strings.ToLower(finding.ResourceType),
+	}
+}
+
+func spaceDisplayName(space checks.OctopusSpaceResults) string {
+	if space.SpaceName != "" {
+		return space.SpaceName
+	}
+
+	if space.SpaceId != "" {
+		return space.SpaceId
+	}
+
+	return "Space"
+}
+
+// spaceHeading is the name and ID of the space. The instance level entry has no ID.
+func spaceHeading(space checks.OctopusSpaceResults) string {
+	if space.SpaceId == "" {
+		return spaceDisplayName(space)
+	}
+
+	return spaceDisplayName(space) + " (" + space.SpaceId + ")"
+}
@@ -0,0 +1,97 @@
+package reporters
+
+import (
+	"encoding/json"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/organization"
+	"testing"
+)
+
+func TestSarifReport(t *testing.T) {
+	emptyProjects := checks.NewOctopusCheckResultImpl("The following projects have no runbooks and no deployment process:\nApp\nWeb", organization.OctoLintEmptyProject, "", checks.Warning, checks.Organization,
+		checks.OctopusCheckFinding{ResourceType: checks.ProjectResource, ResourceId: "Projects-1", ResourceName: "App", ProjectId: "Projects-1", ProjectName: "App", Message: "The project has no runbooks and no deployment process"},
+		checks.OctopusCheckFinding{ResourceType: checks.ProjectResource, ResourceId: "Projects-2", ResourceName: "Web", ProjectId: "Projects-2", ProjectName: "Web", Message: "The project has no runbooks and no deployment process"})
+	tooManyEnvironments := checks.NewOctopusCheckResultImpl("There are 30 environments", organization.OctopusEnvironmentCountCheckName, "", checks.Error, checks.Organization)
+	passResult := checks.NewOctopusCheckResultImpl("This check always passes", "OctoRecAlwaysPass", "", checks.Ok, checks.Organization)
+	erroredResult := checks.NewOctopusCheckResultImpl("The check failed to run: timeout", "OctoRecAlwaysTimeout", "", checks.Error, checks.GeneralError)
+
+	content, err := NewOctopusSarifCheckReporter(checks.Warning, OctopusReportMetadata{OctolintVersion: "1.0.0"}).GenerateSpaces([]checks.OctopusSpaceResults{
+		{
+			SpaceId:   "Spaces-1",
+			SpaceName: "Default",
+			Results:   []checks.OctopusCheckResult{emptyProjects, tooManyEnvironments, passResult, erroredResult},
+			ProjectRepositories: map[string]checks.OctopusProjectRepository{
+				"Projects-2": {Url: "https://github.com/example/web.git", BasePath: ".octopus/web", DefaultBranch: "main"},
+			},
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	log := sarifLog{}
+	if err := json.Unmarshal([]byte(content), &log); err != nil {
+		t.Fatal("Should have generated valid JSON: " + err.Error())
+	}
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatal("Should have generated a SARIF 2.1.0 log with one run")
+	}
+
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 2 || run.Tool.Driver.Rules[0].Id != organization.OctoLintEmptyProject || run.Tool.Driver.Rules[0].HelpUri == "" {
+		t.Fatal("Should have described the rules of the results that were reported")
+	}
+
+	if len(run.Results) != 3 {
+		t.Fatal("Should have reported a result for each finding, and for the result without findings")
+	}
+
+	if run.Results[0].Level != "warning" || run.Results[0].Locations[0].LogicalLocations[0].FullyQualifiedName != "Default/App" {
+		t.Fatal("Should have located the finding by its space and project")
+	}
+
+	if run.Results[0].Locations[0].PhysicalLocation != nil {
+		t.Fatal("Should not have linked a project that is not version controlled to a file")
+	}
+
+	physical := run.Results[1].Locations[0].PhysicalLocation
+	if physical == nil || physical.ArtifactLocation.Uri != ".octopus/web/deployment_settings.ocl" {
+		t.Fatal("Should have linked the version controlled project to its OCL file")
+	}
+
+	if len(run.VersionControlProvenance) != 1 || run.VersionControlProvenance[0].MappedTo.UriBaseId != physical.ArtifactLocation.UriBaseId {
+		t.Fatal("Should have described the repository holding the OCL file")
+	}
+
+	if run.Results[2].Level != "error" || run.Results[2].Locations[0].LogicalLocations[0].FullyQualifiedName != "Default" {
+		t.Fatal("Should have located the result without findings in the space")
+	}
+
+	if run.Invocations[0].ExecutionSuccessful || len(run.Invocations[0].ToolExecutionNotifications) != 1 {
+		t.Fatal("Should have reported the check that failed to run")
+	}
+}
+
+func TestSarifRuleLevelUsesSeverityOverrides(t *testing.T) {
+	emptyProjects := checks.NewOctopusCheckResultImpl("The following projects have no runbooks and no deployment process:\nApp", organization.OctoLintEmptyProject, "", checks.Error, checks.Organization,
+		checks.OctopusCheckFinding{ResourceType: checks.ProjectResource, ResourceId: "Projects-1", ResourceName: "App"})
+
+	metadata := OctopusReportMetadata{SeverityOverrides: map[string]int{organization.OctoLintEmptyProject: checks.Error}}
+	content, err := NewOctopusSarifCheckReporter(checks.Warning, metadata).Generate([]checks.OctopusCheckResult{emptyProjects})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	log := sarifLog{}
+	if err := json.Unmarshal([]byte(content), &log); err != nil {
+		t.Fatal("Should have generated valid JSON: " + err.Error())
+	}
+
+	rule := log.Runs[0].Tool.Driver.Rules[0]
+	if rule.DefaultConfiguration == nil || rule.DefaultConfiguration.Level != "error" {
+		t.Fatal("Should have set the default level of the rule to the configured severity")
+	}
+}
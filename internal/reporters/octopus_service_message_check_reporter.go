@@ -0,0 +1,148 @@
+package reporters
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// The values of the octolint status output variable
+const (
+	StatusPassed       = "Passed"
+	StatusIssuesFound  = "IssuesFound"
+	StatusChecksFailed = "ChecksFailed"
+)
+
+// OctopusServiceMessageCheckReporter prints the lint reports as Octopus service messages, for use in a deployment or
+// runbook step. Warnings and errors are highlighted in the task log, each check sets an output variable to the severity
+// of its result, the Octolint.Status output variable is set to the overall result, the health score is saved in the
+// Octolint.HealthScore output variables, and the HTML and JSON reports are attached as artifacts.
+type OctopusServiceMessageCheckReporter struct {
+	minSeverity int
+	metadata    OctopusReportMetadata
+	// artifactDirectory is where the HTML and JSON reports are saved. An empty string disables the artifacts.
+	artifactDirectory string
+}
+
+func NewOctopusServiceMessageCheckReporter(minSeverity int, metadata OctopusReportMetadata, artifactDirectory string) OctopusServiceMessageCheckReporter {
+	return OctopusServiceMessageCheckReporter{minSeverity: minSeverity, metadata: metadata, artifactDirectory: artifactDirectory}
+}
+
+func (o OctopusServiceMessageCheckReporter) Generate(results []checks.OctopusCheckResult) (string, error) {
+	return o.GenerateSpaces([]checks.OctopusSpaceResults{{Results: results}})
+}
+
+func (o OctopusServiceMessageCheckReporter) GenerateSpaces(spaces []checks.OctopusSpaceResults) (string, error) {
+	report := []string{}
+
+	for _, space := range spaces {
+		variablePrefix := "Octolint."
+		if len(spaces) > 1 {
+			variablePrefix = "Octolint." + spaceDisplayName(space) + "."
+			report = append(report, "Space: "+spaceHeading(space))
+		}
+
+		for _, r := range space.Results {
+			switch {
+			case r.Category() == checks.GeneralError:
+				report = append(report, "##octopus[stdout-highlight]", "["+r.Code()+"] "+r.Description(), "##octopus[stdout-default]")
+			case r.Severity() < o.minSeverity || r.Severity() == checks.Ok:
+				// Passing results and results below the minimum severity are only reported in the output variables
+			case r.Severity() >= checks.Error:
+				report = append(report, "##octopus[stdout-highlight]", "["+r.Code()+"] "+r.Description(), "##octopus[stdout-default]")
+			case r.Severity() >= checks.Warning:
+				report = append(report, "##octopus[stdout-warning]", "["+r.Code()+"] "+r.Description(), "##octopus[stdout-default]")
+			default:
+				report = append(report, "["+r.Code()+"] "+r.Description())
+			}
+		}
+
+		for _, r := range space.Results {
+			status := checks.SeverityName(r.Severity())
+			if r.Category() == checks.GeneralError {
+				status = "Failed"
+			}
+
+			report = append(report, serviceMessage("setVariable", "name", variablePrefix+r.Code(), "value", status))
+		}
+
+		if score := checks.NewOctopusHealthScore(space, o.metadata.ScoreWeights); score.Scored() {
+			report = append(report, plainHealthScore(score),
+				serviceMessage("setVariable", "name", variablePrefix+"HealthScore", "value", checks.FormatScore(score.Score)),
+				serviceMessage("setVariable", "name", variablePrefix+"HealthGrade", "value", score.Grade))
+
+			for _, category := range score.Categories {
+				report = append(report, serviceMessage("setVariable", "name", variablePrefix+"HealthScore."+category.Category, "value", checks.FormatScore(category.Score)))
+			}
+		}
+	}
+
+	summary := checks.NewOctopusCheckSummary(checks.AllResults(spaces))
+
+	status := StatusPassed
+	if summary.Failed != 0 || o.metadata.Interrupted {
+		status = StatusChecksFailed
+	} else if summary.IssuesAtOrAbove(max(o.minSeverity, checks.Info)) != 0 {
+		status = StatusIssuesFound
+	}
+
+	report = append(report,
+		serviceMessage("setVariable", "name", "Octolint.Status", "value", status),
+		serviceMessage("setVariable", "name", "Octolint.Summary", "value", summary.Counts()))
+
+	if o.artifactDirectory != "" {
+		artifacts, err := o.createArtifacts(spaces)
+
+		if err != nil {
+			return "", err
+		}
+
+		report = append(report, artifacts...)
+	}
+
+	return strings.Join(report, "\n"), nil
+}
+
+// createArtifacts saves the HTML and JSON reports, and returns the service messages that attach them to the task.
+func (o OctopusServiceMessageCheckReporter) createArtifacts(spaces []checks.OctopusSpaceResults) ([]string, error) {
+	artifactReporters := map[string]OctopusCheckReporter{
+		"octolint-report.html": NewOctopusHtmlCheckReporter(o.minSeverity, o.metadata),
+		"octolint-report.json": NewOctopusJsonCheckReporter(o.minSeverity, o.metadata),
+	}
+
+	messages := []string{}
+	for _, name := range sortedKeys(artifactReporters) {
+		content, err := artifactReporters[name].GenerateSpaces(spaces)
+
+		if err != nil {
+			return nil, err
+		}
+
+		path, err := filepath.Abs(filepath.Join(o.artifactDirectory, name))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, serviceMessage("createArtifact", "path", path, "name", name, "length", fmt.Sprint(len(content))))
+	}
+
+	return messages, nil
+}
+
+// serviceMessage builds an Octopus service message. The attributes are supplied as name and value pairs, and the values
+// are base64 encoded as required by Octopus.
+func serviceMessage(name string, attributes ...string) string {
+	message := "##octopus[" + name
+	for i := 0; i+1 < len(attributes); i += 2 {
+		message += " " + attributes[i] + "='" + base64.StdEncoding.EncodeToString([]byte(attributes[i+1])) + "'"
+	}
+	return message + "]"
+}
@@ -0,0 +1,64 @@
+package reporters
+
+import (
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestServiceMessageReport(t *testing.T) {
+	failedResult := checks.NewOctopusCheckResultImpl("This check always fails", "OctoRecAlwaysFail", "", checks.Error, checks.Organization)
+	warningResult := checks.NewOctopusCheckResultImpl("This check always warns", "OctoRecAlwaysWarn", "", checks.Warning, checks.Organization)
+	passResult := checks.NewOctopusCheckResultImpl("This check always passes", "OctoRecAlwaysPass", "", checks.Ok, checks.Organization)
+	artifactDirectory := t.TempDir()
+
+	report, err := NewOctopusServiceMessageCheckReporter(checks.Warning, OctopusReportMetadata{}, artifactDirectory).Generate(
+		[]checks.OctopusCheckResult{failedResult, warningResult, passResult})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if !strings.Contains(report, "##octopus[stdout-highlight]\n[OctoRecAlwaysFail] This check always fails\n##octopus[stdout-default]") {
+		t.Fatal("Should have highlighted the error")
+	}
+
+	if !strings.Contains(report, "##octopus[stdout-warning]\n[OctoRecAlwaysWarn] This check always warns\n##octopus[stdout-default]") {
+		t.Fatal("Should have printed the warning")
+	}
+
+	if strings.Contains(report, "This check always passes") {
+		t.Fatal("Should not have printed the passing result")
+	}
+
+	if !strings.Contains(report, serviceMessage("setVariable", "name", "Octolint.OctoRecAlwaysPass", "value", "Ok")) {
+		t.Fatal("Should have set an output variable for each check")
+	}
+
+	if !strings.Contains(report, serviceMessage("setVariable", "name", "Octolint.Status", "value", StatusIssuesFound)) {
+		t.Fatal("Should have set the status output variable")
+	}
+
+	for _, name := range []string{"octolint-report.html", "octolint-report.json"} {
+		path := filepath.Join(artifactDirectory, name)
+
+		info, err := os.Stat(path)
+
+		if err != nil {
+			t.Fatal("Should have saved the artifact " + name)
+		}
+
+		if !strings.Contains(report, serviceMessage("createArtifact", "path", path, "name", name, "length", fmt.Sprint(info.Size()))) {
+			t.Fatal("Should have attached the artifact " + name)
+		}
+	}
+}
+
+func TestServiceMessage(t *testing.T) {
+	if serviceMessage("setVariable", "name", "Octolint.Status", "value", "Passed") != "##octopus[setVariable name='T2N0b2xpbnQuU3RhdHVz' value='UGFzc2Vk']" {
+		t.Fatal("Should have base64 encoded the attribute values")
+	}
+}
@@ -0,0 +1,219 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// aliases are the shorthand schedules supported by most cron implementations
+var aliases = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+var dayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// cronField is a set of the values matched by one field of a cron expression
+type cronField uint64
+
+func (o cronField) has(value int) bool {
+	return o&(1<<uint(value)) != 0
+}
+
+// OctopusScanSchedule is the time of day, day of the month, month, and day of the week that scans run. Schedules are
+// defined with the five fields of a cron expression, like "0 2 * * 1-5" for 2am on weekdays, with one of the aliases
+// like "@daily", or with an interval like "@every 6h".
+type OctopusScanSchedule struct {
+	minutes  cronField
+	hours    cronField
+	days     cronField
+	months   cronField
+	weekdays cronField
+	// anyDay is true when either the day of the month or the day of the week is *, in which case both must match.
+	// Otherwise, a day matching either field matches, like cron.
+	anyDay bool
+	every  time.Duration
+}
+
+// ParseSchedule parses a cron expression, alias, or interval.
+func ParseSchedule(expression string) (OctopusScanSchedule, error) {
+	expression = strings.TrimSpace(expression)
+
+	if expression == "" {
+		return OctopusScanSchedule{}, errors.New("the schedule is empty")
+	}
+
+	if interval, found := strings.CutPrefix(expression, "@every "); found {
+		every, err := time.ParseDuration(strings.TrimSpace(interval))
+
+		if err != nil {
+			return OctopusScanSchedule{}, errors.New("the interval \"" + interval + "\" is invalid. Intervals are durations like 30m or 6h")
+		}
+
+		if every < time.Minute {
+			return OctopusScanSchedule{}, errors.New("the interval \"" + interval + "\" must be at least 1m")
+		}
+
+		return OctopusScanSchedule{every: every}, nil
+	}
+
+	if alias, found := aliases[strings.ToLower(expression)]; found {
+		expression = alias
+	}
+
+	fields := strings.Fields(expression)
+
+	if len(fields) != 5 {
+		return OctopusScanSchedule{}, errors.New("the schedule \"" + expression + "\" must have five fields: minute, hour, day of the month, month, and day of the week")
+	}
+
+	schedule := OctopusScanSchedule{
+		anyDay: strings.HasPrefix(fields[2], "*") || strings.HasPrefix(fields[4], "*"),
+	}
+
+	var err error
+	if schedule.minutes, err = parseField(fields[0], "minute", 0, 59, nil); err != nil {
+		return OctopusScanSchedule{}, err
+	}
+
+	if schedule.hours, err = parseField(fields[1], "hour", 0, 23, nil); err != nil {
+		return OctopusScanSchedule{}, err
+	}
+
+	if schedule.days, err = parseField(fields[2], "day of the month", 1, 31, nil); err != nil {
+		return OctopusScanSchedule{}, err
+	}
+
+	if schedule.months, err = parseField(fields[3], "month", 1, 12, monthNames); err != nil {
+		return OctopusScanSchedule{}, err
+	}
+
+	// Sunday can be 0 or 7
+	if schedule.weekdays, err = parseField(fields[4], "day of the week", 0, 7, dayNames); err != nil {
+		return OctopusScanSchedule{}, err
+	}
+
+	if schedule.weekdays.has(7) {
+		schedule.weekdays |= 1
+	}
+
+	if schedule.Next(time.Now()).IsZero() {
+		return OctopusScanSchedule{}, errors.New("the schedule \"" + expression + "\" never runs")
+	}
+
+	return schedule, nil
+}
+
+// Next returns the first time the schedule runs after the supplied time, in the time zone of the supplied time. It
+// returns the zero time if the schedule does not run in the next five years, like a schedule for the 30th of February.
+func (o OctopusScanSchedule) Next(after time.Time) time.Time {
+	if o.every != 0 {
+		return after.Add(o.every)
+	}
+
+	next := after.Truncate(time.Minute).Add(time.Minute)
+	limit := next.AddDate(5, 0, 0)
+	location := next.Location()
+
+	for next.Before(limit) {
+		switch {
+		case !o.months.has(int(next.Month())):
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, location)
+		case !o.matchesDay(next):
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, location)
+		case !o.hours.has(next.Hour()):
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, location)
+		case !o.minutes.has(next.Minute()):
+			next = next.Add(time.Minute)
+		default:
+			return next
+		}
+	}
+
+	return time.Time{}
+}
+
+func (o OctopusScanSchedule) matchesDay(date time.Time) bool {
+	if o.anyDay {
+		return o.days.has(date.Day()) && o.weekdays.has(int(date.Weekday()))
+	}
+
+	return o.days.has(date.Day()) || o.weekdays.has(int(date.Weekday()))
+}
+
+// parseField parses a comma separated list of values, ranges like 1-5, and steps like */15 or 1-30/5. Names, like the
+// names of months, can be used in place of numbers, starting from the min value.
+func parseField(field string, name string, min int, max int, names []string) (cronField, error) {
+	var values cronField
+
+	for _, item := range strings.Split(field, ",") {
+		rangeExpression, stepExpression, hasStep := strings.Cut(item, "/")
+
+		step := 1
+		if hasStep {
+			parsedStep, err := strconv.Atoi(stepExpression)
+
+			if err != nil || parsedStep <= 0 {
+				return 0, errors.New("the step \"" + stepExpression + "\" of the " + name + " field must be a number greater than 0")
+			}
+
+			step = parsedStep
+		}
+
+		start, end := min, max
+
+		if rangeExpression != "*" {
+			startExpression, endExpression, isRange := strings.Cut(rangeExpression, "-")
+
+			var err error
+			if start, err = parseValue(startExpression, name, min, max, names); err != nil {
+				return 0, err
+			}
+
+			end = start
+			if isRange {
+				if end, err = parseValue(endExpression, name, min, max, names); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				// A step after a single value, like 5/15, runs from that value to the max
+				end = max
+			}
+
+			if end < start {
+				return 0, errors.New("the range \"" + rangeExpression + "\" of the " + name + " field ends before it starts")
+			}
+		}
+
+		for value := start; value <= end; value += step {
+			values |= 1 << uint(value)
+		}
+	}
+
+	return values, nil
+}
+
+func parseValue(expression string, name string, min int, max int, names []string) (int, error) {
+	for index, valueName := range names {
+		if strings.EqualFold(expression, valueName) {
+			return min + index, nil
+		}
+	}
+
+	value, err := strconv.Atoi(expression)
+
+	if err != nil || value < min || value > max {
+		return 0, errors.New("the " + name + " \"" + expression + "\" must be a number from " + fmt.Sprint(min) + " to " + fmt.Sprint(max))
+	}
+
+	return value, nil
+}
@@ -0,0 +1,48 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNext(t *testing.T) {
+	// Friday the 16th of October 2026
+	after := time.Date(2026, 10, 16, 14, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		expression string
+		next       time.Time
+	}{
+		{"*/15 * * * *", time.Date(2026, 10, 16, 14, 15, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2026, 10, 17, 2, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC)},
+		{"30 9 * * mon-fri", time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC)},
+		{"0 0 1 jan *", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)},
+		// Either the day of the month or the day of the week can match when both are restricted
+		{"0 0 20 * 6", time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"5,10-12/2 8 * * *", time.Date(2026, 10, 17, 8, 5, 0, 0, time.UTC)},
+		{"@every 6h", time.Date(2026, 10, 16, 20, 7, 30, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		schedule, err := ParseSchedule(test.expression)
+
+		if err != nil {
+			t.Fatal("Should not have returned an error for " + test.expression + ": " + err.Error())
+		}
+
+		if next := schedule.Next(after); !next.Equal(test.next) {
+			t.Fatalf("The schedule %s should have run at %v, but ran at %v", test.expression, test.next, next)
+		}
+	}
+}
+
+func TestInvalidSchedules(t *testing.T) {
+	for _, expression := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "0 0 0 * *", "* * * foo *", "5-1 * * * *", "*/0 * * * *", "0 0 30 2 *", "@every 10s", "@every soon"} {
+		if _, err := ParseSchedule(expression); err == nil {
+			t.Fatal("Should have returned an error for \"" + expression + "\"")
+		}
+	}
+}
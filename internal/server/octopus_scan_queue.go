@@ -0,0 +1,265 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
+	"sort"
+	"sync"
+	"time"
+)
+
+// The states of a scan requested through the API
+const (
+	StatusQueued    = "queued"
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+	StatusCancelled = "cancelled"
+)
+
+// ErrQueueFull is returned by Enqueue when the queue already holds the maximum number of scans waiting to run.
+var ErrQueueFull = errors.New("the queue is full")
+
+// OctopusScanOutcome is the result of a scan, which is used to generate the reports.
+type OctopusScanOutcome struct {
+	Spaces      []checks.OctopusSpaceResults
+	Metadata    reporters.OctopusReportMetadata
+	MinSeverity int
+}
+
+// OctopusScanFunc runs a scan that has been validated. A scan that is cancelled returns the results of the checks that
+// completed, with the Interrupted metadata set.
+type OctopusScanFunc func(ctx context.Context) (OctopusScanOutcome, error)
+
+// OctopusPrepareFunc validates the config of a scan, returning the function that runs it.
+type OctopusPrepareFunc func(scanConfig *config.OctolintConfig) (OctopusScanFunc, error)
+
+// OctopusScanRun describes a scan requested through the API.
+type OctopusScanRun struct {
+	Id     string `json:"id"`
+	Status string `json:"status"`
+	Space  string `json:"space,omitempty"`
+	// Arguments are the arguments supplied with the request, which override the arguments of the server
+	Arguments  map[string]any `json:"arguments"`
+	QueuedTime time.Time      `json:"queuedTime"`
+	StartTime  *time.Time     `json:"startTime,omitempty"`
+	EndTime    *time.Time     `json:"endTime,omitempty"`
+	// Error describes why a failed scan did not complete
+	Error string `json:"error,omitempty"`
+	// Summary counts the results of a scan that completed or was cancelled
+	Summary *checks.OctopusCheckSummary `json:"summary,omitempty"`
+}
+
+// scanJob is a run, along with the config used to scan the spaces and generate the reports
+type scanJob struct {
+	run        OctopusScanRun
+	scanConfig *config.OctolintConfig
+	scan       OctopusScanFunc
+	outcome    *OctopusScanOutcome
+}
+
+// OctopusScanQueue runs the scans requested through the API with a fixed number of workers, so concurrent requests do
+// not overload the Octopus server. The queue holds a limited number of scans waiting to run, and remembers a limited
+// number of past runs.
+type OctopusScanQueue struct {
+	mutex      sync.Mutex
+	jobs       map[string]*scanJob
+	pending    chan *scanJob
+	maxHistory int
+}
+
+// NewOctopusScanQueue creates a queue that holds up to queueSize scans waiting to run, and remembers up to maxHistory
+// runs. Runs that are queued or running are never forgotten.
+func NewOctopusScanQueue(queueSize int, maxHistory int) *OctopusScanQueue {
+	return &OctopusScanQueue{
+		jobs:       map[string]*scanJob{},
+		pending:    make(chan *scanJob, queueSize),
+		maxHistory: maxHistory,
+	}
+}
+
+// Run starts the workers, and blocks until the context is cancelled and the workers have stopped. Cancelling the
+// context interrupts the running scans, and cancels the scans waiting to run.
+func (o *OctopusScanQueue) Run(ctx context.Context, workers int) {
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-o.pending:
+					o.runJob(ctx, job)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	for {
+		select {
+		case job := <-o.pending:
+			o.finish(job, StatusCancelled, nil, errors.New("the server stopped before the scan started"))
+		default:
+			return
+		}
+	}
+}
+
+// Enqueue adds a scan to the queue, returning ErrQueueFull if too many scans are waiting to run.
+func (o *OctopusScanQueue) Enqueue(scanConfig *config.OctolintConfig, arguments map[string]any, scan OctopusScanFunc) (OctopusScanRun, error) {
+	id, err := newRunId()
+
+	if err != nil {
+		return OctopusScanRun{}, err
+	}
+
+	job := &scanJob{
+		run: OctopusScanRun{
+			Id:         id,
+			Status:     StatusQueued,
+			Space:      scanConfig.Space,
+			Arguments:  arguments,
+			QueuedTime: time.Now(),
+		},
+		scanConfig: scanConfig,
+		scan:       scan,
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	select {
+	case o.pending <- job:
+	default:
+		return OctopusScanRun{}, ErrQueueFull
+	}
+
+	o.jobs[id] = job
+	o.forgetOldRuns()
+
+	return job.run, nil
+}
+
+// Get returns a run by its ID.
+func (o *OctopusScanQueue) Get(id string) (OctopusScanRun, bool) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	job, found := o.jobs[id]
+	if !found {
+		return OctopusScanRun{}, false
+	}
+
+	return job.run, true
+}
+
+// List returns the runs the queue remembers, starting with the most recently queued.
+func (o *OctopusScanQueue) List() []OctopusScanRun {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	runs := []OctopusScanRun{}
+	for _, job := range o.sortedJobs() {
+		runs = append(runs, job.run)
+	}
+
+	return runs
+}
+
+// Outcome returns the results of a run, and the config used to generate its reports. The results are only available
+// once the scan has completed, or was cancelled after some checks completed.
+func (o *OctopusScanQueue) Outcome(id string) (OctopusScanOutcome, *config.OctolintConfig, bool) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	job, found := o.jobs[id]
+	if !found || job.outcome == nil {
+		return OctopusScanOutcome{}, nil, false
+	}
+
+	return *job.outcome, job.scanConfig, true
+}
+
+func (o *OctopusScanQueue) runJob(ctx context.Context, job *scanJob) {
+	o.mutex.Lock()
+	startTime := time.Now()
+	job.run.Status = StatusRunning
+	job.run.StartTime = &startTime
+	o.mutex.Unlock()
+
+	outcome, err := job.scan(ctx)
+
+	switch {
+	case err != nil:
+		o.finish(job, StatusFailed, nil, err)
+	case outcome.Metadata.Interrupted:
+		o.finish(job, StatusCancelled, &outcome, nil)
+	default:
+		o.finish(job, StatusCompleted, &outcome, nil)
+	}
+}
+
+func (o *OctopusScanQueue) finish(job *scanJob, status string, outcome *OctopusScanOutcome, err error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	endTime := time.Now()
+	job.run.Status = status
+	job.run.EndTime = &endTime
+	job.outcome = outcome
+
+	if err != nil {
+		job.run.Error = err.Error()
+	}
+
+	if outcome != nil {
+		summary := checks.NewOctopusCheckSummary(checks.AllResults(outcome.Spaces))
+		job.run.Summary = &summary
+	}
+}
+
+// forgetOldRuns removes the oldest finished runs once the queue remembers more than the maximum number of runs
+func (o *OctopusScanQueue) forgetOldRuns() {
+	jobs := o.sortedJobs()
+
+	for i := len(jobs) - 1; i >= 0 && len(o.jobs) > o.maxHistory; i-- {
+		if jobs[i].run.Status != StatusQueued && jobs[i].run.Status != StatusRunning {
+			delete(o.jobs, jobs[i].run.Id)
+		}
+	}
+}
+
+// sortedJobs returns the jobs starting with the most recently queued
+func (o *OctopusScanQueue) sortedJobs() []*scanJob {
+	jobs := []*scanJob{}
+	for _, job := range o.jobs {
+		jobs = append(jobs, job)
+	}
+
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].run.QueuedTime.After(jobs[j].run.QueuedTime)
+	})
+
+	return jobs
+}
+
+// newRunId returns a random ID, so the IDs of runs can not be guessed and are not reused when the server restarts
+func newRunId() (string, error) {
+	id := make([]byte, 8)
+
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,312 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
+	"github.com/mitchellh/mapstructure"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ScansPath is the path of the API used to start and list scans
+const ScansPath = "/api/scans"
+
+// maxRequestSize is the largest request body accepted when starting a scan
+const maxRequestSize = 1 << 20
+
+// retryAfterSeconds is the number of seconds clients are asked to wait when the queue is full
+const retryAfterSeconds = 60
+
+// reportContentTypes maps the report formats to the content type they are served with
+var reportContentTypes = map[string]string{
+	reporters.PlainFormat:       "text/plain; charset=utf-8",
+	reporters.JsonFormat:        "application/json",
+	reporters.SarifFormat:       "application/sarif+json",
+	reporters.JUnitFormat:       "application/xml",
+	reporters.HtmlFormat:        "text/html; charset=utf-8",
+	reporters.MarkdownFormat:    "text/markdown; charset=utf-8",
+	reporters.OctopusFormat:     "text/plain; charset=utf-8",
+	reporters.CsvFormat:         "text/csv; charset=utf-8",
+	reporters.OpenMetricsFormat: reporters.OpenMetricsContentType,
+}
+
+// OctopusScanServer exposes an HTTP API that starts scans, reports their status, and returns their results in any of
+// the report formats. Scans inherit the arguments of the server, and requests can override the arguments listed in
+// allowedArguments. The Octopus URL and API key are always taken from the server, so clients never handle credentials.
+type OctopusScanServer struct {
+	serverConfig     *config.OctolintConfig
+	allowedArguments map[string]string
+	prepare          OctopusPrepareFunc
+	queue            *OctopusScanQueue
+	token            string
+}
+
+// NewOctopusScanServer creates a server that adds the scans it is asked to run to the queue. Requests must include the
+// token as a bearer token, unless the token is empty.
+func NewOctopusScanServer(serverConfig *config.OctolintConfig, allowedArguments []string, prepare OctopusPrepareFunc, queue *OctopusScanQueue, token string) *OctopusScanServer {
+	return &OctopusScanServer{
+		serverConfig: serverConfig,
+		allowedArguments: lo.SliceToMap(allowedArguments, func(argument string) (string, string) {
+			return strings.ToLower(argument), argument
+		}),
+		prepare: prepare,
+		queue:   queue,
+		token:   token,
+	}
+}
+
+// ServeHTTP routes the requests:
+//
+//	POST /api/scans                      starts a scan, returning 202 and the queued run
+//	GET  /api/scans                      lists the past runs, starting with the most recent
+//	GET  /api/scans/{id}                 returns the status of a run
+//	GET  /api/scans/{id}/report?format=  returns the results of a completed run, in the json format by default
+func (o *OctopusScanServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !o.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		writeError(w, http.StatusUnauthorized, "The request must include the server token as a bearer token")
+		return
+	}
+
+	if r.URL.Path != ScansPath && !strings.HasPrefix(r.URL.Path, ScansPath+"/") {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	segments := lo.Filter(strings.Split(strings.TrimPrefix(r.URL.Path, ScansPath), "/"), func(segment string, _ int) bool {
+		return segment != ""
+	})
+
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		o.startScan(w, r)
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		writeJson(w, http.StatusOK, map[string]any{"scans": o.queue.List()})
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		o.getScan(w, segments[0])
+	case len(segments) == 2 && segments[1] == "report" && r.Method == http.MethodGet:
+		o.getReport(w, r, segments[0])
+	case len(segments) <= 2:
+		writeError(w, http.StatusMethodNotAllowed, "The "+r.Method+" method is not supported")
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (o *OctopusScanServer) startScan(w http.ResponseWriter, r *http.Request) {
+	arguments := map[string]any{}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Failed to read the request.\nThe error was: "+err.Error())
+		return
+	}
+
+	if len(strings.TrimSpace(string(body))) != 0 {
+		if err := json.Unmarshal(body, &arguments); err != nil {
+			writeError(w, http.StatusBadRequest, "The request must be a JSON object mapping argument names to values.\nThe error was: "+err.Error())
+			return
+		}
+	}
+
+	scanConfig, err := o.scanConfig(arguments)
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	scan, err := o.prepare(scanConfig)
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	run, err := o.queue.Enqueue(scanConfig, arguments, scan)
+
+	if errors.Is(err, ErrQueueFull) {
+		w.Header().Set("Retry-After", fmt.Sprint(retryAfterSeconds))
+		writeError(w, http.StatusTooManyRequests, "Too many scans are waiting to run. Try again later.")
+		return
+	}
+
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to queue the scan.\nThe error was: "+err.Error())
+		return
+	}
+
+	w.Header().Set("Location", ScansPath+"/"+run.Id)
+	writeJson(w, http.StatusAccepted, run)
+}
+
+func (o *OctopusScanServer) getScan(w http.ResponseWriter, id string) {
+	run, found := o.queue.Get(id)
+
+	if !found {
+		writeError(w, http.StatusNotFound, "The scan "+id+" was not found")
+		return
+	}
+
+	writeJson(w, http.StatusOK, run)
+}
+
+func (o *OctopusScanServer) getReport(w http.ResponseWriter, r *http.Request, id string) {
+	run, found := o.queue.Get(id)
+
+	if !found {
+		writeError(w, http.StatusNotFound, "The scan "+id+" was not found")
+		return
+	}
+
+	outcome, scanConfig, found := o.queue.Outcome(id)
+
+	if !found {
+		writeError(w, http.StatusConflict, "The scan "+id+" is "+run.Status+" and has no results")
+		return
+	}
+
+	format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format")))
+	if format == "" {
+		format = reporters.JsonFormat
+	}
+
+	contentType, found := reportContentTypes[format]
+
+	if !found {
+		writeError(w, http.StatusBadRequest, "The format must be one of "+strings.Join(reporters.Formats, ", "))
+		return
+	}
+
+	// The reports are returned in the response rather than attached to an Octopus task
+	reportConfig := *scanConfig
+	reportConfig.Format = format
+	reportConfig.ArtifactDirectory = ""
+
+	reporter, err := reporters.NewOctopusCheckReporter(&reportConfig, outcome.MinSeverity, outcome.Metadata)
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := reporter.GenerateSpaces(outcome.Spaces)
+
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to generate the report.\nThe error was: "+err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.WriteString(w, report); err != nil {
+		zap.L().Debug("Failed to write the report: " + err.Error())
+	}
+}
+
+// scanConfig copies the server config, and overrides it with the arguments of the request
+func (o *OctopusScanServer) scanConfig(arguments map[string]any) (*config.OctolintConfig, error) {
+	notAllowed := lo.Filter(lo.Keys(arguments), func(argument string, _ int) bool {
+		_, found := o.allowedArguments[strings.ToLower(argument)]
+		return !found
+	})
+
+	if len(notAllowed) != 0 {
+		allowed := lo.Values(o.allowedArguments)
+		sort.Strings(notAllowed)
+		sort.Strings(allowed)
+		return nil, errors.New("The arguments " + strings.Join(notAllowed, ", ") + " can not be set by a request. " +
+			"The arguments that can be set are " + strings.Join(allowed, ", "))
+	}
+
+	scanConfig := *o.serverConfig
+
+	// The severities of a request replace the severities of the server rather than adding to them
+	scanConfig.Severities = append(config.StringSliceFlag{}, o.serverConfig.Severities...)
+	for argument := range arguments {
+		if strings.EqualFold(argument, "severities") {
+			scanConfig.Severities = nil
+		}
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       stringSliceFlagHook,
+		WeaklyTypedInput: true,
+		Result:           &scanConfig,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := decoder.Decode(arguments); err != nil {
+		return nil, errors.New("The arguments are invalid.\nThe error was: " + err.Error())
+	}
+
+	if scanConfig.Space == "" && scanConfig.Snapshot == "" {
+		return nil, errors.New("The request must include the space argument, as the server does not define a default space")
+	}
+
+	return &scanConfig, nil
+}
+
+func (o *OctopusScanServer) authorized(r *http.Request) bool {
+	if o.token == "" {
+		return true
+	}
+
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return found && subtle.ConstantTimeCompare([]byte(token), []byte(o.token)) == 1
+}
+
+// stringSliceFlagHook splits strings into a StringSliceFlag in the same way as the command line, so the severities
+// argument can be a list or a comma separated string
+func stringSliceFlagHook(from reflect.Type, to reflect.Type, data any) (any, error) {
+	if to != reflect.TypeOf(config.StringSliceFlag{}) {
+		return data, nil
+	}
+
+	values := config.StringSliceFlag{}
+
+	switch typed := data.(type) {
+	case string:
+		_ = values.Set(typed)
+	case []any:
+		for _, value := range typed {
+			_ = values.Set(fmt.Sprint(value))
+		}
+	default:
+		return data, nil
+	}
+
+	return values, nil
+}
+
+func writeJson(w http.ResponseWriter, status int, body any) {
+	content, err := json.MarshalIndent(body, "", "  ")
+
+	if err != nil {
+		http.Error(w, "Failed to serialize the response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(content); err != nil {
+		zap.L().Debug("Failed to write the response: " + err.Error())
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJson(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,211 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// prepareStandIn returns a scan of a single empty project, recording the config of the scan
+func prepareStandIn(prepared *[]config.OctolintConfig) OctopusPrepareFunc {
+	return func(scanConfig *config.OctolintConfig) (OctopusScanFunc, error) {
+		if scanConfig.MinSeverity == "critical" {
+			return nil, errors.New("The minSeverity argument is invalid")
+		}
+
+		*prepared = append(*prepared, *scanConfig)
+
+		return func(ctx context.Context) (OctopusScanOutcome, error) {
+			return OctopusScanOutcome{
+				Spaces: []checks.OctopusSpaceResults{{
+					SpaceId: "Spaces-1",
+					Results: []checks.OctopusCheckResult{
+						checks.NewOctopusCheckResultImpl("The following projects are empty", "OctoLintEmptyProject", "", checks.Warning, checks.Organization,
+							checks.OctopusCheckFinding{ResourceType: checks.ProjectResource, ResourceId: "Projects-1", ResourceName: "Project 1"}),
+					},
+				}},
+				MinSeverity: checks.Warning,
+			}, nil
+		}, nil
+	}
+}
+
+func startServer(t *testing.T, queue *OctopusScanQueue, prepared *[]config.OctolintConfig, token string) *httptest.Server {
+	serverConfig := &config.OctolintConfig{Url: "https://example.octopus.app", ApiKey: "API-SECRET", Space: "Spaces-1", Severities: config.StringSliceFlag{"OctoLintEmptyProject=info"}}
+	server := httptest.NewServer(NewOctopusScanServer(serverConfig, []string{"space", "minSeverity", "maxEnvironments", "severities"}, prepareStandIn(prepared), queue, token))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func startScan(t *testing.T, server *httptest.Server, body string) (*http.Response, OctopusScanRun) {
+	response, err := http.Post(server.URL+ScansPath, "application/json", strings.NewReader(body))
+
+	if err != nil {
+		t.Fatal("Should not have failed to start the scan: " + err.Error())
+	}
+	defer response.Body.Close()
+
+	run := OctopusScanRun{}
+	_ = json.NewDecoder(response.Body).Decode(&run)
+
+	return response, run
+}
+
+func TestScanAndReport(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	queue := NewOctopusScanQueue(10, 10)
+	go queue.Run(ctx, 1)
+
+	prepared := []config.OctolintConfig{}
+	server := startServer(t, queue, &prepared, "")
+
+	response, run := startScan(t, server, `{"space": "Spaces-2", "maxEnvironments": "5", "severities": "OctoLintEmptyProject=error"}`)
+
+	if response.StatusCode != http.StatusAccepted || response.Header.Get("Location") != ScansPath+"/"+run.Id {
+		t.Fatalf("Should have queued the scan, but returned %d", response.StatusCode)
+	}
+
+	scanConfig := prepared[0]
+	if scanConfig.Space != "Spaces-2" || scanConfig.MaxEnvironments != 5 || scanConfig.ApiKey != "API-SECRET" {
+		t.Fatal("Should have overridden the arguments of the server with the arguments of the request")
+	}
+
+	if len(scanConfig.Severities) != 1 || scanConfig.Severities[0] != "OctoLintEmptyProject=error" {
+		t.Fatal("Should have replaced the severities of the server")
+	}
+
+	for deadline := time.Now().Add(5 * time.Second); run.Status != StatusCompleted; {
+		if time.Now().After(deadline) {
+			t.Fatal("The scan should have completed")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		run, _ = queue.Get(run.Id)
+	}
+
+	if run.Summary == nil || run.Summary.Warnings != 1 {
+		t.Fatal("Should have summarised the results of the scan")
+	}
+
+	report, err := http.Get(server.URL + ScansPath + "/" + run.Id + "/report?format=json")
+
+	if err != nil {
+		t.Fatal("Should not have failed to get the report: " + err.Error())
+	}
+	defer report.Body.Close()
+
+	jsonReport := reporters.JsonReport{}
+	if err := json.NewDecoder(report.Body).Decode(&jsonReport); err != nil || len(jsonReport.Spaces) != 1 {
+		t.Fatal("Should have returned the JSON report")
+	}
+}
+
+func TestScanArgumentsAreValidated(t *testing.T) {
+	prepared := []config.OctolintConfig{}
+	server := startServer(t, NewOctopusScanQueue(10, 10), &prepared, "")
+
+	for _, body := range []string{`{"apiKey": "API-OTHER"}`, `{"url": "https://other.octopus.app"}`, `{"minSeverity": "critical"}`, `{"maxEnvironments": "many"}`, `[]`} {
+		if response, _ := startScan(t, server, body); response.StatusCode != http.StatusBadRequest {
+			t.Fatalf("Should have rejected the request %s, but returned %d", body, response.StatusCode)
+		}
+	}
+
+	if len(prepared) != 0 {
+		t.Fatal("Should not have prepared any scans")
+	}
+}
+
+func TestQueueIsBounded(t *testing.T) {
+	prepared := []config.OctolintConfig{}
+	// The queue is not running, so the first scan waits in the queue
+	server := startServer(t, NewOctopusScanQueue(1, 10), &prepared, "")
+
+	if response, _ := startScan(t, server, `{}`); response.StatusCode != http.StatusAccepted {
+		t.Fatal("Should have queued the first scan")
+	}
+
+	response, _ := startScan(t, server, `{}`)
+
+	if response.StatusCode != http.StatusTooManyRequests || response.Header.Get("Retry-After") == "" {
+		t.Fatalf("Should have rejected the second scan, but returned %d", response.StatusCode)
+	}
+}
+
+func TestReportOfQueuedScan(t *testing.T) {
+	prepared := []config.OctolintConfig{}
+	server := startServer(t, NewOctopusScanQueue(1, 10), &prepared, "")
+
+	_, run := startScan(t, server, `{}`)
+
+	response, err := http.Get(server.URL + ScansPath + "/" + run.Id + "/report")
+
+	if err != nil || response.StatusCode != http.StatusConflict {
+		t.Fatal("Should not have returned a report for a scan that has not completed")
+	}
+
+	response, err = http.Get(server.URL + ScansPath + "/unknown")
+
+	if err != nil || response.StatusCode != http.StatusNotFound {
+		t.Fatal("Should not have found an unknown scan")
+	}
+}
+
+func TestToken(t *testing.T) {
+	prepared := []config.OctolintConfig{}
+	server := startServer(t, NewOctopusScanQueue(1, 10), &prepared, "secret")
+
+	response, err := http.Get(server.URL + ScansPath)
+
+	if err != nil || response.StatusCode != http.StatusUnauthorized {
+		t.Fatal("Should have rejected a request without the token")
+	}
+
+	request, _ := http.NewRequest(http.MethodGet, server.URL+ScansPath, nil)
+	request.Header.Set("Authorization", "Bearer secret")
+	response, err = http.DefaultClient.Do(request)
+
+	if err != nil || response.StatusCode != http.StatusOK {
+		t.Fatal("Should have accepted a request with the token")
+	}
+}
+
+func TestHistoryForgetsOldestFinishedRuns(t *testing.T) {
+	queue := NewOctopusScanQueue(10, 2)
+	scan := func(ctx context.Context) (OctopusScanOutcome, error) { return OctopusScanOutcome{}, nil }
+
+	first, _ := queue.Enqueue(&config.OctolintConfig{}, nil, scan)
+	second, _ := queue.Enqueue(&config.OctolintConfig{}, nil, scan)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		for {
+			if run, _ := queue.Get(second.Id); run.Status == StatusCompleted {
+				cancel()
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+	queue.Run(ctx, 1)
+
+	third, _ := queue.Enqueue(&config.OctolintConfig{}, nil, scan)
+
+	if _, found := queue.Get(first.Id); found {
+		t.Fatal("Should have forgotten the oldest run")
+	}
+
+	runs := queue.List()
+	if len(runs) != 2 || runs[0].Id != third.Id || runs[1].Id != second.Id {
+		t.Fatal("Should have listed the remaining runs, starting with the most recent")
+	}
+}
@@ -0,0 +1,49 @@
+package snapshot
+
+import "time"
+
+// CustomProject is the simplest representation of a project and its version controlled settings
+type CustomProject struct {
+	Id                  string                    `json:"Id"`
+	PersistenceSettings CustomPersistenceSettings `json:"PersistenceSettings"`
+	Name                string                    `json:"Name"`
+}
+
+type CustomPersistenceSettings struct {
+	Type        string             `json:"Type"`
+	Credentials *CustomCredentials `json:"Credentials"`
+}
+
+type CustomCredentials struct {
+	Type     string  `json:"Type"`
+	Username *string `json:"Username"`
+}
+
+// OctopusSubscription is used because the go client does not expose the webhook settings of a subscription
+type OctopusSubscription struct {
+	Id                            string
+	Name                          string
+	EventNotificationSubscription *OctopusEventNotificationSubscription
+}
+
+type OctopusEventNotificationSubscription struct {
+	WebhookURI string
+}
+
+type APIKeyKey struct {
+	Hint *string
+}
+
+// APIKey is used because the go client has an invalid APIKey value that prevents the usual functions for querying users keys
+type APIKey struct {
+	Id      string     `json:"Id,omitempty"`
+	APIKey  APIKeyKey  `json:"ApiKey,omitempty"`
+	Expires *time.Time `json:"Expires,omitempty"`
+}
+
+// OctopusAudit is the subset of an event that is used to determine when a resource was last modified
+type OctopusAudit struct {
+	Category           string
+	Occurred           time.Time
+	RelatedDocumentIds []string
+}
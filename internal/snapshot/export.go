@@ -0,0 +1,248 @@
+package snapshot
+
+import (
+	"context"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/feeds"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"strings"
+	"sync"
+	"time"
+)
+
+// exportParallelTasks is the number of projects, machines, accounts or users that are exported at the same time
+const exportParallelTasks = 10
+
+// exportEventMonths is how far back events are exported. It must cover the longest period any check looks back
+// over, as the bundle snapshot filters the exported events by the dates the checks ask for.
+const exportEventMonths = 6
+
+// Export reads everything the checks need from the source snapshot into a bundle. Resources that can not be read,
+// usually because of missing permissions, are recorded in the bundle errors rather than failing the export, so the
+// checks that depend on them report the same errors they would when run against the server. maxDeploymentTasks
+// is the number of deployment events to export. An error is returned if the context is done before the export
+// completes, as the bundle would be missing resources.
+func Export(ctx context.Context, source OctopusSpaceSnapshot, serverUrl string, octolintVersion string, maxDeploymentTasks int) (*OctopusSnapshotBundle, error) {
+	bundle := NewOctopusSnapshotBundle(serverUrl, source.GetSpaceID(), octolintVersion, source.Now())
+	exporter := &bundleExporter{bundle: bundle}
+
+	from := source.Now().AddDate(0, -exportEventMonths, 0)
+
+	allProjects, err := source.GetProjects(ctx, 0)
+	bundle.Projects = exportList(exporter, "projects", allProjects, err)
+
+	persistenceSettings, err := source.GetProjectPersistenceSettings(ctx)
+	bundle.ProjectPersistenceSettings = exportList(exporter, "projectPersistenceSettings", persistenceSettings, err)
+
+	projectGroups, err := source.GetProjectGroups(ctx)
+	bundle.ProjectGroups = exportList(exporter, "projectGroups", projectGroups, err)
+
+	runbooks, err := source.GetRunbooks(ctx)
+	bundle.Runbooks = exportList(exporter, "runbooks", runbooks, err)
+
+	channels, err := source.GetChannels(ctx)
+	bundle.Channels = exportList(exporter, "channels", channels, err)
+
+	environments, err := source.GetEnvironments(ctx, 0)
+	bundle.Environments = exportList(exporter, "environments", environments, err)
+
+	lifecycles, err := source.GetLifecycles(ctx)
+	bundle.Lifecycles = exportList(exporter, "lifecycles", lifecycles, err)
+
+	allMachines, err := source.GetMachines(ctx, 0)
+	bundle.Machines = exportList(exporter, "machines", allMachines, err)
+
+	tenants, err := source.GetTenants(ctx, 0)
+	bundle.Tenants = exportList(exporter, "tenants", tenants, err)
+
+	allAccounts, err := source.GetAccounts(ctx)
+	bundle.Accounts = exportList(exporter, "accounts", allAccounts, err)
+
+	certificates, err := source.GetCertificates(ctx)
+	bundle.Certificates = exportList(exporter, "certificates", certificates, err)
+
+	allFeeds, err := source.GetFeeds(ctx)
+	bundle.Feeds = exportFeeds(exporter, exportList(exporter, "feeds", allFeeds, err))
+
+	workerPools, err := source.GetWorkerPools(ctx)
+	bundle.WorkerPools = exportList(exporter, "workerPools", workerPools, err)
+
+	subscriptions, err := source.GetSubscriptions(ctx)
+	bundle.Subscriptions = exportList(exporter, "subscriptions", subscriptions, err)
+
+	allUsers, err := source.GetUsers(ctx)
+	bundle.Users = exportList(exporter, "users", allUsers, err)
+
+	teams, err := source.GetTeams(ctx)
+	bundle.Teams = exportList(exporter, "teams", teams, err)
+
+	deploymentEvents, err := source.GetDeploymentEvents(ctx, maxDeploymentTasks)
+	bundle.DeploymentEvents = exportList(exporter, "deploymentEvents", deploymentEvents, err)
+
+	// The remaining resources are loaded one request per project, machine, account, or user, so load them in parallel
+	g := errgroup.Group{}
+	g.SetLimit(exportParallelTasks)
+
+	for _, p := range allProjects {
+		p := p
+		g.Go(func() error {
+			exportProject(ctx, exporter, source, p, from)
+			return nil
+		})
+	}
+
+	for _, m := range allMachines {
+		m := m
+		g.Go(func() error {
+			machineTasks, err := source.GetMachineDeploymentTasks(ctx, m)
+			exportKey(exporter, "machineDeploymentTasks", m.ID, bundle.MachineDeploymentTasks, machineTasks, err)
+
+			machineEvents, err := source.GetMachineEvents(ctx, m.ID)
+			exportKey(exporter, "machineEvents", m.ID, bundle.MachineEvents, machineEvents, err)
+			return nil
+		})
+	}
+
+	for _, a := range allAccounts {
+		a := a
+		g.Go(func() error {
+			audits, err := source.GetAccountAudits(ctx, a.GetID(), from, source.Now())
+			exportKey(exporter, "accountAudits", a.GetID(), bundle.AccountAudits, audits, err)
+			return nil
+		})
+	}
+
+	for _, u := range allUsers {
+		u := u
+		g.Go(func() error {
+			keys, err := source.GetUserApiKeys(ctx, u)
+			exportKey(exporter, "userApiKeys", u.ID, bundle.UserApiKeys, keys, err)
+			return nil
+		})
+	}
+
+	for _, id := range getDeploymentIds(deploymentEvents) {
+		id := id
+		g.Go(func() error {
+			deployment, err := source.GetDeployment(ctx, id)
+			exportKey(exporter, "deployments", id, bundle.Deployments, deployment, err)
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return bundle, nil
+}
+
+// exportProject exports the resources that belong to a single project.
+func exportProject(ctx context.Context, exporter *bundleExporter, source OctopusSpaceSnapshot, project *projects.Project, from time.Time) {
+	bundle := exporter.bundle
+
+	variableSet, err := source.GetVariableSet(ctx, project.ID)
+	exportKey(exporter, "variableSets", project.ID, bundle.VariableSets, variableSet, err)
+
+	if project.DeploymentProcessID != "" {
+		deploymentProcess, err := source.GetDeploymentProcess(ctx, project.DeploymentProcessID)
+		exportKey(exporter, "deploymentProcesses", project.DeploymentProcessID, bundle.DeploymentProcesses, deploymentProcess, err)
+	}
+
+	projectRunbooks, err := source.GetProjectRunbooks(ctx, project)
+	exportKey(exporter, "projectRunbooks", project.ID, bundle.ProjectRunbooks, projectRunbooks, err)
+
+	for _, runbook := range projectRunbooks {
+		runbookProcess, err := source.GetRunbookProcess(ctx, runbook.RunbookProcessID)
+		exportKey(exporter, "runbookProcesses", runbook.RunbookProcessID, bundle.RunbookProcesses, runbookProcess, err)
+	}
+
+	projectTasks, err := source.GetProjectTasks(ctx, project.ID)
+	exportKey(exporter, "projectTasks", project.ID, bundle.ProjectTasks, projectTasks, err)
+
+	queuedEvents, err := source.GetProjectDeploymentQueuedEvents(ctx, project.ID, from)
+	exportKey(exporter, "projectDeploymentQueuedEvents", project.ID, bundle.ProjectDeploymentQueuedEvents, queuedEvents, err)
+}
+
+// exportFeeds converts the feeds to FeedResources, which unlike the IFeed interface can be read back from JSON.
+func exportFeeds(exporter *bundleExporter, allFeeds []feeds.IFeed) []*feeds.FeedResource {
+	if allFeeds == nil {
+		return nil
+	}
+
+	feedResources := []*feeds.FeedResource{}
+	for _, feed := range allFeeds {
+		feedResource, err := feeds.ToFeedResource(feed)
+
+		if err != nil {
+			exporter.recordError("feeds", err)
+			return nil
+		}
+
+		feedResources = append(feedResources, feedResource)
+	}
+
+	return feedResources
+}
+
+// getDeploymentIds returns the unique deployment IDs referenced by the events.
+func getDeploymentIds(deploymentEvents []*events.Event) []string {
+	ids := []string{}
+	for _, e := range deploymentEvents {
+		for _, id := range e.RelatedDocumentIds {
+			if strings.HasPrefix(id, "Deployments-") {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	return lo.Uniq(ids)
+}
+
+// bundleExporter synchronizes the writes to the bundle made by the parallel exports.
+type bundleExporter struct {
+	bundle *OctopusSnapshotBundle
+	mutex  sync.Mutex
+}
+
+func (o *bundleExporter) recordError(key string, err error) {
+	zap.L().Debug("Failed to export " + key + ": " + err.Error())
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.bundle.Errors[key] = err.Error()
+}
+
+// exportList returns the items to be saved in the bundle, or nil if they could not be loaded. A successful load
+// always returns a non-nil slice, as nil is how the bundle indicates a resource was not exported.
+func exportList[T any](exporter *bundleExporter, key string, items []T, err error) []T {
+	if err != nil {
+		exporter.recordError(key, err)
+		return nil
+	}
+
+	if items == nil {
+		return []T{}
+	}
+
+	return items
+}
+
+// exportKey saves an item to one of the bundle maps, or records the error if it could not be loaded.
+func exportKey[T any](exporter *bundleExporter, key string, id string, items map[string]T, item T, err error) {
+	if err != nil {
+		exporter.recordError(key+"/"+id, err)
+		return
+	}
+
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	items[id] = item
+}
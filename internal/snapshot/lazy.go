@@ -0,0 +1,98 @@
+package snapshot
+
+import (
+	"context"
+	"sync"
+)
+
+// lazyValue loads a value the first time it is requested and returns the cached value for subsequent requests.
+// Concurrent requests wait for the first load to complete rather than making duplicate requests. Errors are
+// not cached, so a failed load is attempted again by the next request. A request stops waiting when its context is
+// done, but the load carries on in the background, so the value is still cached for the other requests.
+type lazyValue[T any] struct {
+	mutex  sync.Mutex
+	loaded bool
+	value  T
+}
+
+func (l *lazyValue[T]) get(ctx context.Context, load func() (T, error)) (T, error) {
+	var empty T
+
+	if err := ctx.Err(); err != nil {
+		return empty, err
+	}
+
+	type outcome struct {
+		value T
+		err   error
+	}
+
+	// The channel is buffered so an abandoned load does not block forever when it eventually completes
+	loaded := make(chan outcome, 1)
+
+	go func() {
+		value, err := l.load(load)
+		loaded <- outcome{value: value, err: err}
+	}()
+
+	select {
+	case out := <-loaded:
+		return out.value, out.err
+	case <-ctx.Done():
+		return empty, ctx.Err()
+	}
+}
+
+func (l *lazyValue[T]) load(load func() (T, error)) (T, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.loaded {
+		return l.value, nil
+	}
+
+	value, err := load()
+
+	if err != nil {
+		var empty T
+		return empty, err
+	}
+
+	l.value = value
+	l.loaded = true
+
+	return value, nil
+}
+
+// lazyMap is a collection of lazyValues indexed by a key, typically the ID of the resource being loaded.
+type lazyMap[T any] struct {
+	mutex  sync.Mutex
+	values map[string]*lazyValue[T]
+}
+
+func (l *lazyMap[T]) get(ctx context.Context, key string, load func() (T, error)) (T, error) {
+	l.mutex.Lock()
+
+	if l.values == nil {
+		l.values = map[string]*lazyValue[T]{}
+	}
+
+	value, ok := l.values[key]
+	if !ok {
+		value = &lazyValue[T]{}
+		l.values[key] = value
+	}
+
+	l.mutex.Unlock()
+
+	return value.get(ctx, load)
+}
+
+// limitSlice returns the first limit items of a slice, or all items if the limit is 0.
+func limitSlice[T any](items []T, limit int) []T {
+	if limit <= 0 || limit >= len(items) {
+		return items
+	}
+
+	return items[:limit]
+}
@@ -0,0 +1,129 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLazyValueIsLoadedOnce(t *testing.T) {
+	value := lazyValue[string]{}
+	loads := 0
+
+	for i := 0; i < 3; i++ {
+		result, err := value.get(context.Background(), func() (string, error) {
+			loads++
+			return "loaded", nil
+		})
+
+		if err != nil {
+			t.Fatal("Should not have returned an error")
+		}
+
+		if result != "loaded" {
+			t.Fatal("Should have returned the loaded value")
+		}
+	}
+
+	if loads != 1 {
+		t.Fatalf("Should have loaded the value once, but loaded it %d times", loads)
+	}
+}
+
+func TestLazyValueErrorsAreNotCached(t *testing.T) {
+	value := lazyValue[string]{}
+
+	_, err := value.get(context.Background(), func() (string, error) {
+		return "", errors.New("failed")
+	})
+
+	if err == nil {
+		t.Fatal("Should have returned the error")
+	}
+
+	result, err := value.get(context.Background(), func() (string, error) {
+		return "loaded", nil
+	})
+
+	if err != nil || result != "loaded" {
+		t.Fatal("Should have loaded the value after the failed attempt")
+	}
+}
+
+func TestLazyValueStopsWaitingWhenTheContextIsDone(t *testing.T) {
+	value := lazyValue[string]{}
+	release := make(chan struct{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := value.get(ctx, func() (string, error) { return "loaded", nil }); !errors.Is(err, context.Canceled) {
+		t.Fatal("Should not have loaded the value for a cancelled context")
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := value.get(ctx, func() (string, error) {
+		<-release
+		return "loaded", nil
+	})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("Should have stopped waiting for the load when the context timed out")
+	}
+
+	// The abandoned load completes in the background, and its value is cached for the next request
+	close(release)
+	result, err := value.get(context.Background(), func() (string, error) { return "reloaded", nil })
+
+	if err != nil || result != "loaded" {
+		t.Fatal("Should have cached the value of the abandoned load")
+	}
+}
+
+func TestLazyMapIsLoadedOncePerKey(t *testing.T) {
+	values := lazyMap[string]{}
+	loads := int32(0)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		key := []string{"Projects-1", "Projects-2"}[i%2]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := values.get(context.Background(), key, func() (string, error) {
+				atomic.AddInt32(&loads, 1)
+				return key, nil
+			})
+
+			if err != nil || result != key {
+				t.Error("Should have returned the value for the key")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if loads != 2 {
+		t.Fatalf("Should have loaded each key once, but loaded %d values", loads)
+	}
+}
+
+func TestLimitSlice(t *testing.T) {
+	items := []int{1, 2, 3}
+
+	if len(limitSlice(items, 0)) != 3 {
+		t.Fatal("Should have returned all items when the limit is 0")
+	}
+
+	if len(limitSlice(items, 2)) != 2 {
+		t.Fatal("Should have returned the first 2 items")
+	}
+
+	if len(limitSlice(items, 10)) != 3 {
+		t.Fatal("Should have returned all items when the limit exceeds the length")
+	}
+}
@@ -0,0 +1,252 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/certificates"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/channels"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/feeds"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projectgroups"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/teams"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/users"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
+	"github.com/samber/lo"
+	"time"
+)
+
+// OctopusBundleSpaceSnapshot is an OctopusSpaceSnapshot that reads resources from an exported bundle rather than the
+// Octopus API. Resources that could not be exported are reported with the error that was captured during the export.
+type OctopusBundleSpaceSnapshot struct {
+	bundle *OctopusSnapshotBundle
+	feeds  []feeds.IFeed
+}
+
+func NewOctopusBundleSpaceSnapshot(bundle *OctopusSnapshotBundle) (*OctopusBundleSpaceSnapshot, error) {
+	allFeeds := []feeds.IFeed{}
+	for _, feedResource := range bundle.Feeds {
+		feed, err := feeds.ToFeed(feedResource)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allFeeds = append(allFeeds, feed)
+	}
+
+	return &OctopusBundleSpaceSnapshot{bundle: bundle, feeds: allFeeds}, nil
+}
+
+// exportError returns the error captured when the resource was exported, or an error indicating that the resource
+// was not included in the bundle.
+func (o *OctopusBundleSpaceSnapshot) exportError(key string) error {
+	if message, ok := o.bundle.Errors[key]; ok {
+		return errors.New(message)
+	}
+
+	return errors.New("the snapshot does not include " + key)
+}
+
+// getList returns a list from the bundle, or the export error if the list was not exported.
+func getList[T any](o *OctopusBundleSpaceSnapshot, key string, items []T) ([]T, error) {
+	if items == nil {
+		return nil, o.exportError(key)
+	}
+
+	return items, nil
+}
+
+// getKey returns an item from one of the bundle maps, or the export error if the item was not exported.
+func getKey[T any](o *OctopusBundleSpaceSnapshot, key string, id string, items map[string]T) (T, error) {
+	item, ok := items[id]
+
+	if !ok {
+		var empty T
+		return empty, o.exportError(key + "/" + id)
+	}
+
+	return item, nil
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetSpaceID() string {
+	return o.bundle.SpaceId
+}
+
+func (o *OctopusBundleSpaceSnapshot) Now() time.Time {
+	return o.bundle.Created
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetProjects(ctx context.Context, limit int) ([]*projects.Project, error) {
+	allProjects, err := getList(o, "projects", o.bundle.Projects)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return limitSlice(allProjects, limit), nil
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetProjectPersistenceSettings(ctx context.Context) ([]CustomProject, error) {
+	return getList(o, "projectPersistenceSettings", o.bundle.ProjectPersistenceSettings)
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetProjectGroups(ctx context.Context) ([]*projectgroups.ProjectGroup, error) {
+	return getList(o, "projectGroups", o.bundle.ProjectGroups)
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetProjectTasks(ctx context.Context, projectId string) ([]*tasks.Task, error) {
+	return getKey(o, "projectTasks", projectId, o.bundle.ProjectTasks)
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetProjectDeploymentQueuedEvents(ctx context.Context, projectId string, from time.Time) ([]*events.Event, error) {
+	queuedEvents, err := getKey(o, "projectDeploymentQueuedEvents", projectId, o.bundle.ProjectDeploymentQueuedEvents)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The API filters events by date, so ignore the time of day
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+
+	return lo.Filter(queuedEvents, func(item *events.Event, index int) bool {
+		return !item.Occurred.Before(fromDate)
+	}), nil
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetVariableSet(ctx context.Context, ownerId string) (variables.VariableSet, error) {
+	return getKey(o, "variableSets", ownerId, o.bundle.VariableSets)
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetDeploymentProcess(ctx context.Context, id string) (*deployments.DeploymentProcess, error) {
+	return getKey(o, "deploymentProcesses", id, o.bundle.DeploymentProcesses)
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetRunbooks(ctx context.Context) ([]*runbooks.Runbook, error) {
+	return getList(o, "runbooks", o.bundle.Runbooks)
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetProjectRunbooks(ctx context.Context, project *projects.Project) ([]*runbooks.Runbook, error) {
+	return getKey(o, "projectRunbooks", project.ID, o.bundle.ProjectRunbooks)
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetRunbookProcess(ctx context.Context, id string) (*runbooks.RunbookProcess, error) {
+	return getKey(o, "runbookProcesses", id, o.bundle.RunbookProcesses)
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetChannels(ctx context.Context) ([]*channels.Channel, error) {
+	return getList(o, "channels", o.bundle.Channels)
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetEnvironments(ctx context.Context, limit int) ([]*environments.Environment, error) {
+	allEnvironments, err := getList(o, "environments", o.bundle.Environments)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return limitSlice(allEnvironments, limit), nil
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetLifecycles(ctx context.Context) ([]*lifecycles.Lifecycle, error) {
+	return getList(o, "lifecycles", o.bundle.Lifecycles)
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetMachines(ctx context.Context, limit int) ([]*machines.DeploymentTarget, error) {
+	allMachines, err := getList(o, "machines", o.bundle.Machines)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return limitSlice(allMachines, limit), nil
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetMachineDeploymentTasks(ctx context.Context, machine *machines.DeploymentTarget) ([]*tasks.Task, error) {
+	return getKey(o, "machineDeploymentTasks", machine.ID, o.bundle.MachineDeploymentTasks)
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetMachineEvents(ctx context.Context, machineId string) ([]*events.Event, error) {
+	return getKey(o, "machineEvents", machineId, o.bundle.MachineEvents)
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetTenants(ctx context.Context, limit int) ([]*tenants.Tenant, error) {
+	allTenants, err := getList(o, "tenants", o.bundle.Tenants)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return limitSlice(allTenants, limit), nil
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetAccounts(ctx context.Context) ([]*accounts.AccountResource, error) {
+	return getList(o, "accounts", o.bundle.Accounts)
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetAccountAudits(ctx context.Context, accountId string, from time.Time, to time.Time) ([]OctopusAudit, error) {
+	audits, err := getKey(o, "accountAudits", accountId, o.bundle.AccountAudits)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Filter(audits, func(item OctopusAudit, index int) bool {
+		return !item.Occurred.Before(from) && !item.Occurred.After(to)
+	}), nil
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetCertificates(ctx context.Context) ([]*certificates.CertificateResource, error) {
+	return getList(o, "certificates", o.bundle.Certificates)
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetFeeds(ctx context.Context) ([]feeds.IFeed, error) {
+	if o.bundle.Feeds == nil {
+		return nil, o.exportError("feeds")
+	}
+
+	return o.feeds, nil
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetWorkerPools(ctx context.Context) ([]*workerpools.WorkerPoolListResult, error) {
+	return getList(o, "workerPools", o.bundle.WorkerPools)
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetSubscriptions(ctx context.Context) ([]*OctopusSubscription, error) {
+	return getList(o, "subscriptions", o.bundle.Subscriptions)
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetUsers(ctx context.Context) ([]*users.User, error) {
+	return getList(o, "users", o.bundle.Users)
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetUserApiKeys(ctx context.Context, user *users.User) ([]APIKey, error) {
+	return getKey(o, "userApiKeys", user.ID, o.bundle.UserApiKeys)
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetTeams(ctx context.Context) ([]*teams.Team, error) {
+	return getList(o, "teams", o.bundle.Teams)
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetDeploymentEvents(ctx context.Context, take int) ([]*events.Event, error) {
+	deploymentEvents, err := getList(o, "deploymentEvents", o.bundle.DeploymentEvents)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return limitSlice(deploymentEvents, take), nil
+}
+
+func (o *OctopusBundleSpaceSnapshot) GetDeployment(ctx context.Context, id string) (*deployments.Deployment, error) {
+	return getKey(o, "deployments", id, o.bundle.Deployments)
+}
@@ -0,0 +1,204 @@
+package snapshot
+
+import (
+	"context"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/feeds"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func createTestBundle(t *testing.T) *OctopusSnapshotBundle {
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	bundle := NewOctopusSnapshotBundle("https://octopus.example.com", "Spaces-1", "test", created)
+
+	project := projects.NewProject("Project 1", "Lifecycles-1", "ProjectGroups-1")
+	project.ID = "Projects-1"
+	project.SpaceID = "Spaces-1"
+	bundle.Projects = []*projects.Project{project}
+
+	clusterUrl, err := url.Parse("https://kubernetes.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	endpoint := machines.NewKubernetesEndpoint(clusterUrl)
+	endpoint.SkipTLSVerification = true
+	machine := machines.NewDeploymentTarget("Target 1", endpoint, []string{"Environments-1"}, []string{"k8s"})
+	machine.ID = "Machines-1"
+	bundle.Machines = []*machines.DeploymentTarget{machine}
+
+	feed, err := feeds.NewHelmFeed("Helm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	feed.FeedURI = "http://charts.example.com"
+	feedResource, err := feeds.ToFeedResource(feed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle.Feeds = []*feeds.FeedResource{feedResource}
+
+	bundle.ProjectDeploymentQueuedEvents["Projects-1"] = []*events.Event{
+		{Category: "DeploymentQueued", Occurred: created.AddDate(0, -1, 0)},
+		{Category: "DeploymentQueued", Occurred: created.AddDate(0, -5, 0)},
+	}
+
+	bundle.Errors["users"] = "You do not have permission to perform this action"
+
+	return bundle
+}
+
+func TestBundleRoundTrip(t *testing.T) {
+	for _, fileName := range []string{"snapshot.json", "snapshot.json.gz"} {
+		path := filepath.Join(t.TempDir(), fileName)
+
+		if err := WriteBundle(createTestBundle(t), path); err != nil {
+			t.Fatal(err)
+		}
+
+		bundle, err := ReadBundle(path)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		bundleSnapshot, err := NewOctopusBundleSpaceSnapshot(bundle)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if bundleSnapshot.GetSpaceID() != "Spaces-1" || !bundleSnapshot.Now().Equal(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)) {
+			t.Fatal("Should have restored the space and snapshot time")
+		}
+
+		allProjects, err := bundleSnapshot.GetProjects(context.Background(), 0)
+
+		if err != nil || len(allProjects) != 1 || allProjects[0].ID != "Projects-1" {
+			t.Fatal("Should have restored the projects")
+		}
+
+		allMachines, err := bundleSnapshot.GetMachines(context.Background(), 0)
+
+		if err != nil || len(allMachines) != 1 {
+			t.Fatal("Should have restored the machines")
+		}
+
+		endpoint, ok := allMachines[0].Endpoint.(*machines.KubernetesEndpoint)
+		if !ok || !endpoint.SkipTLSVerification {
+			t.Fatal("Should have restored the kubernetes endpoint")
+		}
+
+		allFeeds, err := bundleSnapshot.GetFeeds(context.Background())
+
+		if err != nil || len(allFeeds) != 1 || allFeeds[0].GetFeedType() != feeds.FeedTypeHelm {
+			t.Fatal("Should have restored the feeds")
+		}
+	}
+}
+
+func TestBundleEventsAreFilteredByDate(t *testing.T) {
+	bundleSnapshot, err := NewOctopusBundleSpaceSnapshot(createTestBundle(t))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queuedEvents, err := bundleSnapshot.GetProjectDeploymentQueuedEvents(context.Background(), "Projects-1", bundleSnapshot.Now().AddDate(0, -3, 0))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(queuedEvents) != 1 {
+		t.Fatalf("Should have returned the 1 event in the last 3 months, but returned %d", len(queuedEvents))
+	}
+}
+
+func TestBundleReturnsExportErrors(t *testing.T) {
+	bundleSnapshot, err := NewOctopusBundleSpaceSnapshot(createTestBundle(t))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = bundleSnapshot.GetUsers(context.Background())
+
+	if err == nil || err.Error() != "You do not have permission to perform this action" {
+		t.Fatal("Should have returned the error captured during the export")
+	}
+
+	_, err = bundleSnapshot.GetVariableSet(context.Background(), "Projects-2")
+
+	if err == nil {
+		t.Fatal("Should have returned an error for a resource that was not exported")
+	}
+}
+
+func TestReadBundleRejectsNewerVersions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+
+	if err := os.WriteFile(path, []byte(`{"version": 1000}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := ReadBundle(path)
+
+	if err == nil {
+		t.Fatal("Should have rejected the newer bundle version")
+	}
+}
+
+func TestCancelledExportFails(t *testing.T) {
+	bundleSnapshot, err := NewOctopusBundleSpaceSnapshot(createTestBundle(t))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := Export(ctx, bundleSnapshot, "https://octopus.example.com", "test", 0); err == nil {
+		t.Fatal("Should have returned an error for the cancelled export")
+	}
+}
+
+func TestExportFromBundle(t *testing.T) {
+	bundleSnapshot, err := NewOctopusBundleSpaceSnapshot(createTestBundle(t))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exported, err := Export(context.Background(), bundleSnapshot, "https://octopus.example.com", "test", 0)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if len(exported.Projects) != 1 || len(exported.Machines) != 1 || len(exported.Feeds) != 1 {
+		t.Fatal("Should have exported the projects, machines and feeds")
+	}
+
+	if len(exported.ProjectDeploymentQueuedEvents["Projects-1"]) != 2 {
+		t.Fatal("Should have exported the events from the last 6 months")
+	}
+
+	if _, ok := exported.Errors["users"]; !ok {
+		t.Fatal("Should have recorded the users that could not be exported")
+	}
+
+	if _, ok := exported.Errors["variableSets/Projects-1"]; !ok {
+		t.Fatal("Should have recorded the variable set that could not be exported")
+	}
+
+	if exported.Users != nil {
+		t.Fatal("Should not have exported the users")
+	}
+}
@@ -0,0 +1,401 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/certificates"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/channels"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/feeds"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projectgroups"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/services/api"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/teams"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/users"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+const maxProjectTasks = 100
+const maxProjectDeploymentQueuedEvents = 100
+
+var linksTemplate = regexp.MustCompile(`\{.+\}`)
+
+// OctopusClientSpaceSnapshot is an OctopusSpaceSnapshot that lazily loads resources from the Octopus API. Each resource
+// is requested the first time a check asks for it, and the response is shared with every other check in the run.
+type OctopusClientSpaceSnapshot struct {
+	client *client.Client
+	now    time.Time
+
+	projects               lazyValue[[]*projects.Project]
+	projectPersistence     lazyValue[[]CustomProject]
+	projectGroups          lazyValue[[]*projectgroups.ProjectGroup]
+	projectTasks           lazyMap[[]*tasks.Task]
+	projectQueuedEvents    lazyMap[[]*events.Event]
+	variableSets           lazyMap[variables.VariableSet]
+	deploymentProcesses    lazyMap[*deployments.DeploymentProcess]
+	runbooks               lazyValue[[]*runbooks.Runbook]
+	projectRunbooks        lazyMap[[]*runbooks.Runbook]
+	runbookProcesses       lazyMap[*runbooks.RunbookProcess]
+	channels               lazyValue[[]*channels.Channel]
+	environments           lazyValue[[]*environments.Environment]
+	lifecycles             lazyValue[[]*lifecycles.Lifecycle]
+	machines               lazyValue[[]*machines.DeploymentTarget]
+	machineDeploymentTasks lazyMap[[]*tasks.Task]
+	machineEvents          lazyMap[[]*events.Event]
+	tenants                lazyValue[[]*tenants.Tenant]
+	accounts               lazyValue[[]*accounts.AccountResource]
+	accountAudits          lazyMap[[]OctopusAudit]
+	certificates           lazyValue[[]*certificates.CertificateResource]
+	feeds                  lazyValue[[]feeds.IFeed]
+	workerPools            lazyValue[[]*workerpools.WorkerPoolListResult]
+	subscriptions          lazyValue[[]*OctopusSubscription]
+	instance               *OctopusInstanceResources
+	teams                  lazyValue[[]*teams.Team]
+	deploymentEvents       lazyMap[[]*events.Event]
+	deploymentsById        lazyMap[*deployments.Deployment]
+}
+
+// NewOctopusClientSpaceSnapshot creates a snapshot of the space the client is scoped to. The snapshot time is
+// recorded when it is created.
+func NewOctopusClientSpaceSnapshot(client *client.Client) *OctopusClientSpaceSnapshot {
+	return &OctopusClientSpaceSnapshot{client: client, now: time.Now(), instance: NewOctopusInstanceResources()}
+}
+
+// WithInstanceResources shares the users and API keys loaded by the snapshot with the snapshots of other spaces.
+func (o *OctopusClientSpaceSnapshot) WithInstanceResources(instance *OctopusInstanceResources) *OctopusClientSpaceSnapshot {
+	o.instance = instance
+	return o
+}
+
+func (o *OctopusClientSpaceSnapshot) GetSpaceID() string {
+	return o.client.GetSpaceID()
+}
+
+func (o *OctopusClientSpaceSnapshot) Now() time.Time {
+	return o.now
+}
+
+func (o *OctopusClientSpaceSnapshot) GetProjects(ctx context.Context, limit int) ([]*projects.Project, error) {
+	allProjects, err := o.projects.get(ctx, func() ([]*projects.Project, error) {
+		return client_wrapper.GetProjects(0, o.client, o.client.GetSpaceID())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return limitSlice(allProjects, limit), nil
+}
+
+func (o *OctopusClientSpaceSnapshot) GetProjectPersistenceSettings(ctx context.Context) ([]CustomProject, error) {
+	return o.projectPersistence.get(ctx, func() ([]CustomProject, error) {
+		projectsUrl := o.client.HttpSession().BaseURL.String() + "/api/" + o.client.GetSpaceID() + "/Projects?take=2147483647"
+		allProjects, err := newclient.Get[resources.Resources[CustomProject]](o.client.HttpSession(), projectsUrl)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return allProjects.Items, nil
+	})
+}
+
+func (o *OctopusClientSpaceSnapshot) GetProjectGroups(ctx context.Context) ([]*projectgroups.ProjectGroup, error) {
+	return o.projectGroups.get(ctx, func() ([]*projectgroups.ProjectGroup, error) {
+		return o.client.ProjectGroups.GetAll()
+	})
+}
+
+func (o *OctopusClientSpaceSnapshot) GetProjectTasks(ctx context.Context, projectId string) ([]*tasks.Task, error) {
+	return o.projectTasks.get(ctx, projectId, func() ([]*tasks.Task, error) {
+		projectTasks, err := o.client.Tasks.Get(tasks.TasksQuery{
+			Project: projectId,
+			Skip:    0,
+			Take:    maxProjectTasks,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return projectTasks.Items, nil
+	})
+}
+
+func (o *OctopusClientSpaceSnapshot) GetProjectDeploymentQueuedEvents(ctx context.Context, projectId string, from time.Time) ([]*events.Event, error) {
+	fromDate := from.Format("2006-01-02")
+
+	return o.projectQueuedEvents.get(ctx, projectId+"/"+fromDate, func() ([]*events.Event, error) {
+		queuedEvents, err := o.client.Events.Get(events.EventsQuery{
+			EventCategories: []string{"DeploymentQueued"},
+			Projects:        []string{projectId},
+			Skip:            0,
+			Take:            maxProjectDeploymentQueuedEvents,
+			From:            fromDate,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return queuedEvents.Items, nil
+	})
+}
+
+func (o *OctopusClientSpaceSnapshot) GetVariableSet(ctx context.Context, ownerId string) (variables.VariableSet, error) {
+	return o.variableSets.get(ctx, ownerId, func() (variables.VariableSet, error) {
+		return o.client.Variables.GetAll(ownerId)
+	})
+}
+
+func (o *OctopusClientSpaceSnapshot) GetDeploymentProcess(ctx context.Context, id string) (*deployments.DeploymentProcess, error) {
+	return o.deploymentProcesses.get(ctx, id, func() (*deployments.DeploymentProcess, error) {
+		return o.client.DeploymentProcesses.GetByID(id)
+	})
+}
+
+func (o *OctopusClientSpaceSnapshot) GetRunbooks(ctx context.Context) ([]*runbooks.Runbook, error) {
+	return o.runbooks.get(ctx, func() ([]*runbooks.Runbook, error) {
+		return o.client.Runbooks.GetAll()
+	})
+}
+
+func (o *OctopusClientSpaceSnapshot) GetProjectRunbooks(ctx context.Context, project *projects.Project) ([]*runbooks.Runbook, error) {
+	return o.projectRunbooks.get(ctx, project.ID, func() ([]*runbooks.Runbook, error) {
+		link, ok := project.Links["Runbooks"]
+		if !ok {
+			return []*runbooks.Runbook{}, nil
+		}
+
+		projectRunbooks, err := newclient.Get[resources.Resources[*runbooks.Runbook]](o.client.HttpSession(), linksTemplate.ReplaceAllString(link, ""))
+
+		if err != nil {
+			return nil, err
+		}
+
+		return projectRunbooks.Items, nil
+	})
+}
+
+func (o *OctopusClientSpaceSnapshot) GetRunbookProcess(ctx context.Context, id string) (*runbooks.RunbookProcess, error) {
+	return o.runbookProcesses.get(ctx, id, func() (*runbooks.RunbookProcess, error) {
+		return o.client.RunbookProcesses.GetByID(id)
+	})
+}
+
+func (o *OctopusClientSpaceSnapshot) GetChannels(ctx context.Context) ([]*channels.Channel, error) {
+	return o.channels.get(ctx, func() ([]*channels.Channel, error) {
+		return o.client.Channels.GetAll()
+	})
+}
+
+func (o *OctopusClientSpaceSnapshot) GetEnvironments(ctx context.Context, limit int) ([]*environments.Environment, error) {
+	allEnvironments, err := o.environments.get(ctx, func() ([]*environments.Environment, error) {
+		return client_wrapper.GetEnvironments(0, o.client, o.client.GetSpaceID())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return limitSlice(allEnvironments, limit), nil
+}
+
+func (o *OctopusClientSpaceSnapshot) GetLifecycles(ctx context.Context) ([]*lifecycles.Lifecycle, error) {
+	return o.lifecycles.get(ctx, func() ([]*lifecycles.Lifecycle, error) {
+		return o.client.Lifecycles.GetAll()
+	})
+}
+
+func (o *OctopusClientSpaceSnapshot) GetMachines(ctx context.Context, limit int) ([]*machines.DeploymentTarget, error) {
+	allMachines, err := o.machines.get(ctx, func() ([]*machines.DeploymentTarget, error) {
+		return client_wrapper.GetMachines(0, o.client, o.client.GetSpaceID())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return limitSlice(allMachines, limit), nil
+}
+
+func (o *OctopusClientSpaceSnapshot) GetMachineDeploymentTasks(ctx context.Context, machine *machines.DeploymentTarget) ([]*tasks.Task, error) {
+	return o.machineDeploymentTasks.get(ctx, machine.ID, func() ([]*tasks.Task, error) {
+		tasksLink := linksTemplate.ReplaceAllString(machine.Links["TasksTemplate"], "")
+		machineTasks, err := newclient.Get[resources.Resources[*tasks.Task]](o.client.HttpSession(), tasksLink+"?type=Deployment")
+
+		if err != nil {
+			return nil, err
+		}
+
+		return machineTasks.Items, nil
+	})
+}
+
+func (o *OctopusClientSpaceSnapshot) GetMachineEvents(ctx context.Context, machineId string) ([]*events.Event, error) {
+	return o.machineEvents.get(ctx, machineId, func() ([]*events.Event, error) {
+		machineEvents, err := o.client.Events.Get(events.EventsQuery{
+			Regarding: machineId,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return machineEvents.Items, nil
+	})
+}
+
+func (o *OctopusClientSpaceSnapshot) GetTenants(ctx context.Context, limit int) ([]*tenants.Tenant, error) {
+	allTenants, err := o.tenants.get(ctx, func() ([]*tenants.Tenant, error) {
+		return client_wrapper.GetTenants(0, o.client, o.client.GetSpaceID())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return limitSlice(allTenants, limit), nil
+}
+
+func (o *OctopusClientSpaceSnapshot) GetAccounts(ctx context.Context) ([]*accounts.AccountResource, error) {
+	return o.accounts.get(ctx, func() ([]*accounts.AccountResource, error) {
+		return newclient.GetAll[accounts.AccountResource](o.client, "/api/{spaceId}/accounts", o.client.GetSpaceID())
+	})
+}
+
+func (o *OctopusClientSpaceSnapshot) GetAccountAudits(ctx context.Context, accountId string, from time.Time, to time.Time) ([]OctopusAudit, error) {
+	fromDate := from.Format("2006-01-02T15:04:05-0700")
+	toDate := to.Format("2006-01-02T15:04:05-0700")
+
+	return o.accountAudits.get(ctx, accountId+"/"+fromDate+"/"+toDate, func() ([]OctopusAudit, error) {
+		audits, err := newclient.Get[resources.Resources[OctopusAudit]](o.client.HttpSession(), "/api/events?regardingAny="+accountId+"&from="+url.QueryEscape(fromDate)+"&to="+url.QueryEscape(toDate))
+
+		if err != nil {
+			return nil, err
+		}
+
+		return audits.Items, nil
+	})
+}
+
+func (o *OctopusClientSpaceSnapshot) GetCertificates(ctx context.Context) ([]*certificates.CertificateResource, error) {
+	return o.certificates.get(ctx, func() ([]*certificates.CertificateResource, error) {
+		return o.client.Certificates.GetAll()
+	})
+}
+
+func (o *OctopusClientSpaceSnapshot) GetFeeds(ctx context.Context) ([]feeds.IFeed, error) {
+	return o.feeds.get(ctx, func() ([]feeds.IFeed, error) {
+		return o.client.Feeds.GetAll()
+	})
+}
+
+func (o *OctopusClientSpaceSnapshot) GetWorkerPools(ctx context.Context) ([]*workerpools.WorkerPoolListResult, error) {
+	return o.workerPools.get(ctx, func() ([]*workerpools.WorkerPoolListResult, error) {
+		return o.client.WorkerPools.GetAll()
+	})
+}
+
+func (o *OctopusClientSpaceSnapshot) GetSubscriptions(ctx context.Context) ([]*OctopusSubscription, error) {
+	return o.subscriptions.get(ctx, func() ([]*OctopusSubscription, error) {
+		collection := resources.Resources[*OctopusSubscription]{}
+		_, err := api.ApiGet(o.client.Subscriptions.GetClient(), &collection, o.client.Subscriptions.BasePath+"?skip=0&take=2147483647")
+
+		if err != nil {
+			return nil, err
+		}
+
+		return collection.Items, nil
+	})
+}
+
+func (o *OctopusClientSpaceSnapshot) GetUsers(ctx context.Context) ([]*users.User, error) {
+	return o.instance.users.get(ctx, func() ([]*users.User, error) {
+		return o.client.Users.GetAll()
+	})
+}
+
+func (o *OctopusClientSpaceSnapshot) GetUserApiKeys(ctx context.Context, user *users.User) ([]APIKey, error) {
+	return o.instance.userApiKeys.get(ctx, user.ID, func() ([]APIKey, error) {
+		apiKeysLink := linksTemplate.ReplaceAllString(user.Links["ApiKeys"], "")
+		keys, err := newclient.Get[resources.Resources[APIKey]](o.client.HttpSession(), apiKeysLink)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return keys.Items, nil
+	})
+}
+
+// GetTeams returns the teams of the space. Unlike the users, the teams are not shared with the snapshots of other
+// spaces, because each space has its own teams, like the space managers.
+func (o *OctopusClientSpaceSnapshot) GetTeams(ctx context.Context) ([]*teams.Team, error) {
+	return o.teams.get(ctx, func() ([]*teams.Team, error) {
+		allTeams, err := o.client.Teams.Get(teams.TeamsQuery{
+			IncludeSystem: true,
+			Skip:          0,
+			Take:          2147483647,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return allTeams.Items, nil
+	})
+}
+
+func (o *OctopusClientSpaceSnapshot) GetDeploymentEvents(ctx context.Context, take int) ([]*events.Event, error) {
+	return o.deploymentEvents.get(ctx, fmt.Sprint(take), func() ([]*events.Event, error) {
+		deploymentEvents, err := o.client.Events.Get(events.EventsQuery{
+			EventCategories: []string{"DeploymentQueued", "DeploymentStarted"},
+			Skip:            0,
+			Take:            take,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		if deploymentEvents == nil {
+			return []*events.Event{}, nil
+		}
+
+		return deploymentEvents.Items, nil
+	})
+}
+
+func (o *OctopusClientSpaceSnapshot) GetDeployment(ctx context.Context, id string) (*deployments.Deployment, error) {
+	return o.deploymentsById.get(ctx, id, func() (*deployments.Deployment, error) {
+		deployment, err := o.client.Deployments.GetByID(id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if deployment == nil {
+			return nil, errors.New("the deployment " + id + " was not found")
+		}
+
+		return deployment, nil
+	})
+}
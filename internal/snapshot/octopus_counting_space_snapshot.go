@@ -0,0 +1,207 @@
+package snapshot
+
+import (
+	"context"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/certificates"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/channels"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/feeds"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projectgroups"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/teams"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/users"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
+	"sync/atomic"
+	"time"
+)
+
+// OctopusCountingSpaceSnapshot wraps another OctopusSpaceSnapshot and counts the resources it returns. Each check is
+// given its own counting snapshot over the shared snapshot, so the number of resources scanned by each check can be
+// reported. Variable sets are counted by the number of variables they contain, and failed requests are not counted.
+type OctopusCountingSpaceSnapshot struct {
+	snapshot OctopusSpaceSnapshot
+	count    atomic.Int64
+}
+
+func NewOctopusCountingSpaceSnapshot(snapshot OctopusSpaceSnapshot) *OctopusCountingSpaceSnapshot {
+	return &OctopusCountingSpaceSnapshot{snapshot: snapshot}
+}
+
+// ResourcesScanned returns the number of resources that have been returned by the snapshot
+func (o *OctopusCountingSpaceSnapshot) ResourcesScanned() int {
+	return int(o.count.Load())
+}
+
+func countAll[T any](o *OctopusCountingSpaceSnapshot, values []T, err error) ([]T, error) {
+	if err == nil {
+		o.count.Add(int64(len(values)))
+	}
+	return values, err
+}
+
+func countOne[T any](o *OctopusCountingSpaceSnapshot, value T, err error) (T, error) {
+	if err == nil {
+		o.count.Add(1)
+	}
+	return value, err
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetSpaceID() string {
+	return o.snapshot.GetSpaceID()
+}
+
+func (o *OctopusCountingSpaceSnapshot) Now() time.Time {
+	return o.snapshot.Now()
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetProjects(ctx context.Context, limit int) ([]*projects.Project, error) {
+	values, err := o.snapshot.GetProjects(ctx, limit)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetProjectPersistenceSettings(ctx context.Context) ([]CustomProject, error) {
+	values, err := o.snapshot.GetProjectPersistenceSettings(ctx)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetProjectGroups(ctx context.Context) ([]*projectgroups.ProjectGroup, error) {
+	values, err := o.snapshot.GetProjectGroups(ctx)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetProjectTasks(ctx context.Context, projectId string) ([]*tasks.Task, error) {
+	values, err := o.snapshot.GetProjectTasks(ctx, projectId)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetProjectDeploymentQueuedEvents(ctx context.Context, projectId string, from time.Time) ([]*events.Event, error) {
+	values, err := o.snapshot.GetProjectDeploymentQueuedEvents(ctx, projectId, from)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetVariableSet(ctx context.Context, ownerId string) (variables.VariableSet, error) {
+	value, err := o.snapshot.GetVariableSet(ctx, ownerId)
+	if err == nil {
+		o.count.Add(int64(len(value.Variables)))
+	}
+	return value, err
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetDeploymentProcess(ctx context.Context, id string) (*deployments.DeploymentProcess, error) {
+	value, err := o.snapshot.GetDeploymentProcess(ctx, id)
+	return countOne(o, value, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetRunbooks(ctx context.Context) ([]*runbooks.Runbook, error) {
+	values, err := o.snapshot.GetRunbooks(ctx)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetProjectRunbooks(ctx context.Context, project *projects.Project) ([]*runbooks.Runbook, error) {
+	values, err := o.snapshot.GetProjectRunbooks(ctx, project)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetRunbookProcess(ctx context.Context, id string) (*runbooks.RunbookProcess, error) {
+	value, err := o.snapshot.GetRunbookProcess(ctx, id)
+	return countOne(o, value, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetChannels(ctx context.Context) ([]*channels.Channel, error) {
+	values, err := o.snapshot.GetChannels(ctx)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetEnvironments(ctx context.Context, limit int) ([]*environments.Environment, error) {
+	values, err := o.snapshot.GetEnvironments(ctx, limit)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetLifecycles(ctx context.Context) ([]*lifecycles.Lifecycle, error) {
+	values, err := o.snapshot.GetLifecycles(ctx)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetMachines(ctx context.Context, limit int) ([]*machines.DeploymentTarget, error) {
+	values, err := o.snapshot.GetMachines(ctx, limit)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetMachineDeploymentTasks(ctx context.Context, machine *machines.DeploymentTarget) ([]*tasks.Task, error) {
+	values, err := o.snapshot.GetMachineDeploymentTasks(ctx, machine)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetMachineEvents(ctx context.Context, machineId string) ([]*events.Event, error) {
+	values, err := o.snapshot.GetMachineEvents(ctx, machineId)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetTenants(ctx context.Context, limit int) ([]*tenants.Tenant, error) {
+	values, err := o.snapshot.GetTenants(ctx, limit)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetAccounts(ctx context.Context) ([]*accounts.AccountResource, error) {
+	values, err := o.snapshot.GetAccounts(ctx)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetAccountAudits(ctx context.Context, accountId string, from time.Time, to time.Time) ([]OctopusAudit, error) {
+	values, err := o.snapshot.GetAccountAudits(ctx, accountId, from, to)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetCertificates(ctx context.Context) ([]*certificates.CertificateResource, error) {
+	values, err := o.snapshot.GetCertificates(ctx)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetFeeds(ctx context.Context) ([]feeds.IFeed, error) {
+	values, err := o.snapshot.GetFeeds(ctx)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetWorkerPools(ctx context.Context) ([]*workerpools.WorkerPoolListResult, error) {
+	values, err := o.snapshot.GetWorkerPools(ctx)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetSubscriptions(ctx context.Context) ([]*OctopusSubscription, error) {
+	values, err := o.snapshot.GetSubscriptions(ctx)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetUsers(ctx context.Context) ([]*users.User, error) {
+	values, err := o.snapshot.GetUsers(ctx)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetUserApiKeys(ctx context.Context, user *users.User) ([]APIKey, error) {
+	values, err := o.snapshot.GetUserApiKeys(ctx, user)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetTeams(ctx context.Context) ([]*teams.Team, error) {
+	values, err := o.snapshot.GetTeams(ctx)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetDeploymentEvents(ctx context.Context, take int) ([]*events.Event, error) {
+	values, err := o.snapshot.GetDeploymentEvents(ctx, take)
+	return countAll(o, values, err)
+}
+
+func (o *OctopusCountingSpaceSnapshot) GetDeployment(ctx context.Context, id string) (*deployments.Deployment, error) {
+	value, err := o.snapshot.GetDeployment(ctx, id)
+	return countOne(o, value, err)
+}
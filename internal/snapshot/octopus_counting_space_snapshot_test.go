@@ -0,0 +1,40 @@
+package snapshot
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCountingSnapshotCountsResources(t *testing.T) {
+	bundleSnapshot, err := NewOctopusBundleSpaceSnapshot(createTestBundle(t))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counting := NewOctopusCountingSpaceSnapshot(bundleSnapshot)
+
+	if _, err := counting.GetProjects(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := counting.GetMachines(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := counting.GetFeeds(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := counting.GetUsers(context.Background()); err == nil {
+		t.Fatal("Should have returned the error captured during the export")
+	}
+
+	if counting.ResourcesScanned() != 3 {
+		t.Fatalf("Should have counted the project, machine and feed, but counted %d", counting.ResourcesScanned())
+	}
+
+	if NewOctopusCountingSpaceSnapshot(bundleSnapshot).ResourcesScanned() != 0 {
+		t.Fatal("Should have counted the resources for each counting snapshot separately")
+	}
+}
@@ -0,0 +1,17 @@
+package snapshot
+
+import (
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/users"
+)
+
+// OctopusInstanceResources caches the resources that belong to the Octopus instance rather than to a space, like the
+// users and their API keys. The snapshots of every space in a scan share the one cache, so these resources are only
+// loaded once no matter how many spaces are scanned.
+type OctopusInstanceResources struct {
+	users       lazyValue[[]*users.User]
+	userApiKeys lazyMap[[]APIKey]
+}
+
+func NewOctopusInstanceResources() *OctopusInstanceResources {
+	return &OctopusInstanceResources{}
+}
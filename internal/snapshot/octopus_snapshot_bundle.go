@@ -0,0 +1,162 @@
+package snapshot
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/certificates"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/channels"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/feeds"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projectgroups"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/teams"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/users"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// BundleVersion is the version of the bundle format written by this version of octolint. It is incremented whenever
+// a change to the format means an older octolint can no longer read the bundle.
+const BundleVersion = 1
+
+// OctopusSnapshotBundle holds everything the checks read from a space. It is written by the export command, and
+// read back by the -snapshot argument to run the checks without a connection to the Octopus server.
+type OctopusSnapshotBundle struct {
+	Version         int       `json:"version"`
+	OctolintVersion string    `json:"octolintVersion"`
+	ServerUrl       string    `json:"serverUrl"`
+	SpaceId         string    `json:"spaceId"`
+	Created         time.Time `json:"created"`
+
+	Projects                      []*projects.Project                       `json:"projects"`
+	ProjectPersistenceSettings    []CustomProject                           `json:"projectPersistenceSettings"`
+	ProjectGroups                 []*projectgroups.ProjectGroup             `json:"projectGroups"`
+	ProjectTasks                  map[string][]*tasks.Task                  `json:"projectTasks"`
+	ProjectDeploymentQueuedEvents map[string][]*events.Event                `json:"projectDeploymentQueuedEvents"`
+	VariableSets                  map[string]variables.VariableSet          `json:"variableSets"`
+	DeploymentProcesses           map[string]*deployments.DeploymentProcess `json:"deploymentProcesses"`
+	Runbooks                      []*runbooks.Runbook                       `json:"runbooks"`
+	ProjectRunbooks               map[string][]*runbooks.Runbook            `json:"projectRunbooks"`
+	RunbookProcesses              map[string]*runbooks.RunbookProcess       `json:"runbookProcesses"`
+	Channels                      []*channels.Channel                       `json:"channels"`
+	Environments                  []*environments.Environment               `json:"environments"`
+	Lifecycles                    []*lifecycles.Lifecycle                   `json:"lifecycles"`
+	Machines                      []*machines.DeploymentTarget              `json:"machines"`
+	MachineDeploymentTasks        map[string][]*tasks.Task                  `json:"machineDeploymentTasks"`
+	MachineEvents                 map[string][]*events.Event                `json:"machineEvents"`
+	Tenants                       []*tenants.Tenant                         `json:"tenants"`
+	Accounts                      []*accounts.AccountResource               `json:"accounts"`
+	AccountAudits                 map[string][]OctopusAudit                 `json:"accountAudits"`
+	Certificates                  []*certificates.CertificateResource       `json:"certificates"`
+	// Feeds are saved as FeedResources because the IFeed interface can not be read back from JSON
+	Feeds            []*feeds.FeedResource               `json:"feeds"`
+	WorkerPools      []*workerpools.WorkerPoolListResult `json:"workerPools"`
+	Subscriptions    []*OctopusSubscription              `json:"subscriptions"`
+	Users            []*users.User                       `json:"users"`
+	UserApiKeys      map[string][]APIKey                 `json:"userApiKeys"`
+	Teams            []*teams.Team                       `json:"teams"`
+	DeploymentEvents []*events.Event                     `json:"deploymentEvents"`
+	Deployments      map[string]*deployments.Deployment  `json:"deployments"`
+
+	// Errors records the resources that could not be exported, typically due to missing permissions. The key is
+	// the resource name, optionally followed by a slash and the ID of the owning resource.
+	Errors map[string]string `json:"errors"`
+}
+
+// NewOctopusSnapshotBundle creates an empty bundle with all the maps initialized.
+func NewOctopusSnapshotBundle(serverUrl string, spaceId string, octolintVersion string, created time.Time) *OctopusSnapshotBundle {
+	return &OctopusSnapshotBundle{
+		Version:                       BundleVersion,
+		OctolintVersion:               octolintVersion,
+		ServerUrl:                     serverUrl,
+		SpaceId:                       spaceId,
+		Created:                       created,
+		ProjectTasks:                  map[string][]*tasks.Task{},
+		ProjectDeploymentQueuedEvents: map[string][]*events.Event{},
+		VariableSets:                  map[string]variables.VariableSet{},
+		DeploymentProcesses:           map[string]*deployments.DeploymentProcess{},
+		ProjectRunbooks:               map[string][]*runbooks.Runbook{},
+		RunbookProcesses:              map[string]*runbooks.RunbookProcess{},
+		MachineDeploymentTasks:        map[string][]*tasks.Task{},
+		MachineEvents:                 map[string][]*events.Event{},
+		AccountAudits:                 map[string][]OctopusAudit{},
+		UserApiKeys:                   map[string][]APIKey{},
+		Deployments:                   map[string]*deployments.Deployment{},
+		Errors:                        map[string]string{},
+	}
+}
+
+// WriteBundle saves the bundle as JSON. Files ending in .gz are compressed.
+func WriteBundle(bundle *OctopusSnapshotBundle, path string) (funcErr error) {
+	file, err := os.Create(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		funcErr = errors.Join(funcErr, file.Close())
+	}()
+
+	var writer io.Writer = file
+
+	if strings.HasSuffix(path, ".gz") {
+		gzipWriter := gzip.NewWriter(file)
+		defer func() {
+			funcErr = errors.Join(funcErr, gzipWriter.Close())
+		}()
+		writer = gzipWriter
+	}
+
+	return json.NewEncoder(writer).Encode(bundle)
+}
+
+// ReadBundle loads a bundle saved by WriteBundle. An error is returned if the bundle was written in a format newer
+// than this version of octolint understands.
+func ReadBundle(path string) (*OctopusSnapshotBundle, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	var reader io.Reader = file
+
+	if strings.HasSuffix(path, ".gz") {
+		gzipReader, err := gzip.NewReader(file)
+
+		if err != nil {
+			return nil, err
+		}
+
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	bundle := OctopusSnapshotBundle{}
+	if err := json.NewDecoder(reader).Decode(&bundle); err != nil {
+		return nil, err
+	}
+
+	if bundle.Version < 1 || bundle.Version > BundleVersion {
+		return nil, fmt.Errorf("the snapshot bundle version %d is not supported by this version of octolint, which supports up to version %d", bundle.Version, BundleVersion)
+	}
+
+	return &bundle, nil
+}
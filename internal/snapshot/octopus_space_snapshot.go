@@ -0,0 +1,78 @@
+package snapshot
+
+import (
+	"context"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/certificates"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/channels"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/feeds"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projectgroups"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/teams"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/users"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
+	"time"
+)
+
+// OctopusSpaceSnapshot is the read only view of a space that is shared by all the checks in a run. Each resource is
+// loaded once and then returned to every check that asks for it, so checks must not modify the returned values.
+// Implementations must be safe to call from multiple goroutines, as checks are executed in parallel.
+type OctopusSpaceSnapshot interface {
+	// GetSpaceID returns the ID of the space the snapshot was taken from
+	GetSpaceID() string
+	// Now returns the time the snapshot was taken. Checks use this rather than time.Now() when looking for
+	// stale resources, so a snapshot produces the same results no matter when the checks are run.
+	Now() time.Time
+	// GetProjects returns the first limit projects, or all projects if limit is 0
+	GetProjects(ctx context.Context, limit int) ([]*projects.Project, error)
+	// GetProjectPersistenceSettings returns the version control settings of all projects
+	GetProjectPersistenceSettings(ctx context.Context) ([]CustomProject, error)
+	GetProjectGroups(ctx context.Context) ([]*projectgroups.ProjectGroup, error)
+	// GetProjectTasks returns the most recent tasks associated with a project
+	GetProjectTasks(ctx context.Context, projectId string) ([]*tasks.Task, error)
+	// GetProjectDeploymentQueuedEvents returns the DeploymentQueued events for a project that occurred after the from date
+	GetProjectDeploymentQueuedEvents(ctx context.Context, projectId string, from time.Time) ([]*events.Event, error)
+	// GetVariableSet returns the variable set owned by a project or library variable set
+	GetVariableSet(ctx context.Context, ownerId string) (variables.VariableSet, error)
+	GetDeploymentProcess(ctx context.Context, id string) (*deployments.DeploymentProcess, error)
+	GetRunbooks(ctx context.Context) ([]*runbooks.Runbook, error)
+	// GetProjectRunbooks returns the runbooks that belong to a project
+	GetProjectRunbooks(ctx context.Context, project *projects.Project) ([]*runbooks.Runbook, error)
+	GetRunbookProcess(ctx context.Context, id string) (*runbooks.RunbookProcess, error)
+	GetChannels(ctx context.Context) ([]*channels.Channel, error)
+	// GetEnvironments returns the first limit environments, or all environments if limit is 0
+	GetEnvironments(ctx context.Context, limit int) ([]*environments.Environment, error)
+	GetLifecycles(ctx context.Context) ([]*lifecycles.Lifecycle, error)
+	// GetMachines returns the first limit machines, or all machines if limit is 0
+	GetMachines(ctx context.Context, limit int) ([]*machines.DeploymentTarget, error)
+	// GetMachineDeploymentTasks returns the most recent deployment tasks that were run on a machine
+	GetMachineDeploymentTasks(ctx context.Context, machine *machines.DeploymentTarget) ([]*tasks.Task, error)
+	// GetMachineEvents returns the most recent events regarding a machine
+	GetMachineEvents(ctx context.Context, machineId string) ([]*events.Event, error)
+	// GetTenants returns the first limit tenants, or all tenants if limit is 0
+	GetTenants(ctx context.Context, limit int) ([]*tenants.Tenant, error)
+	GetAccounts(ctx context.Context) ([]*accounts.AccountResource, error)
+	// GetAccountAudits returns the events regarding an account that occurred between the from and to dates
+	GetAccountAudits(ctx context.Context, accountId string, from time.Time, to time.Time) ([]OctopusAudit, error)
+	GetCertificates(ctx context.Context) ([]*certificates.CertificateResource, error)
+	GetFeeds(ctx context.Context) ([]feeds.IFeed, error)
+	GetWorkerPools(ctx context.Context) ([]*workerpools.WorkerPoolListResult, error)
+	GetSubscriptions(ctx context.Context) ([]*OctopusSubscription, error)
+	GetUsers(ctx context.Context) ([]*users.User, error)
+	// GetUserApiKeys returns the API keys that belong to a user
+	GetUserApiKeys(ctx context.Context, user *users.User) ([]APIKey, error)
+	// GetTeams returns all the teams, including the system teams
+	GetTeams(ctx context.Context) ([]*teams.Team, error)
+	// GetDeploymentEvents returns the most recent take DeploymentQueued and DeploymentStarted events
+	GetDeploymentEvents(ctx context.Context, take int) ([]*events.Event, error)
+	GetDeployment(ctx context.Context, id string) (*deployments.Deployment, error)
+}
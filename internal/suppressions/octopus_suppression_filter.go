@@ -0,0 +1,170 @@
+package suppressions
+
+import (
+	"errors"
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/registry"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ExpiresFormat is the format of the date a suppression expires on.
+const ExpiresFormat = "2006-01-02"
+
+type suppression struct {
+	config.Suppression
+	resourceName *regexp.Regexp
+	matches      int
+}
+
+// OctopusSuppressionReport describes how the suppressions were applied to a scan.
+type OctopusSuppressionReport struct {
+	// Suppressed is the number of findings that were removed from the results
+	Suppressed int
+	// Expired lists the suppressions that were not applied because they have expired
+	Expired []config.Suppression
+	// Unmatched lists the active suppressions that did not match any findings
+	Unmatched []config.Suppression
+}
+
+// OctopusSuppressionFilter removes the findings that have been accepted by a suppression from the check results.
+type OctopusSuppressionFilter struct {
+	active  []*suppression
+	expired []config.Suppression
+}
+
+// NewOctopusSuppressionFilter validates the suppressions and returns a filter that applies the suppressions that have
+// not expired by the supplied time.
+func NewOctopusSuppressionFilter(suppressions []config.Suppression, now time.Time) (*OctopusSuppressionFilter, error) {
+	filter := OctopusSuppressionFilter{
+		active:  []*suppression{},
+		expired: []config.Suppression{},
+	}
+
+	for i, s := range suppressions {
+		name := "suppression " + fmt.Sprint(i+1)
+
+		if strings.TrimSpace(s.CheckId) == "" {
+			return nil, errors.New(name + " must define the checkId")
+		}
+
+		if err := registry.ValidateCheckIds([]string{s.CheckId}); err != nil {
+			return nil, errors.New(name + " is invalid: " + err.Error())
+		}
+
+		if strings.TrimSpace(s.ResourceId) == "" && strings.TrimSpace(s.ResourceName) == "" {
+			return nil, errors.New(name + " must define the resourceId or resourceName. Use skipTests to disable a check entirely")
+		}
+
+		if strings.TrimSpace(s.Justification) == "" {
+			return nil, errors.New(name + " must define a justification")
+		}
+
+		compiled := suppression{Suppression: s}
+
+		if s.ResourceName != "" {
+			regex, err := regexp.Compile("^(?:" + s.ResourceName + ")$")
+
+			if err != nil {
+				return nil, errors.New(name + " has a resourceName that is not a valid regular expression: " + err.Error())
+			}
+
+			compiled.resourceName = regex
+		}
+
+		if s.Expires != "" {
+			expires, err := time.Parse(ExpiresFormat, s.Expires)
+
+			if err != nil {
+				return nil, errors.New(name + " has an expires date that is not in the format YYYY-MM-DD")
+			}
+
+			// The suppression applies until the end of the day it expires on
+			if !now.Before(expires.AddDate(0, 0, 1)) {
+				filter.expired = append(filter.expired, s)
+				continue
+			}
+		}
+
+		filter.active = append(filter.active, &compiled)
+	}
+
+	return &filter, nil
+}
+
+// Apply removes the suppressed findings from the results. A result whose findings are all suppressed is reported as
+// passing, and a result with some suppressed findings lists only the remaining findings.
+func (o *OctopusSuppressionFilter) Apply(results []checks.OctopusCheckResult) ([]checks.OctopusCheckResult, OctopusSuppressionReport) {
+	report := OctopusSuppressionReport{
+		Expired:   o.expired,
+		Unmatched: []config.Suppression{},
+	}
+
+	filteredResults := []checks.OctopusCheckResult{}
+	for _, r := range results {
+		filtered, suppressed := checks.WithoutFindings(r, func(finding checks.OctopusCheckFinding) bool {
+			return o.suppress(r.Code(), finding)
+		}, "suppressed")
+
+		report.Suppressed += suppressed
+		filteredResults = append(filteredResults, filtered)
+	}
+
+	for _, s := range o.active {
+		if s.matches == 0 {
+			report.Unmatched = append(report.Unmatched, s.Suppression)
+		}
+	}
+
+	return filteredResults, report
+}
+
+// suppress returns true if any active suppression matches the finding.
+func (o *OctopusSuppressionFilter) suppress(checkId string, finding checks.OctopusCheckFinding) bool {
+	matched := false
+
+	for _, s := range o.active {
+		if s.CheckId != checkId {
+			continue
+		}
+
+		if s.SpaceId != "" && s.SpaceId != finding.SpaceId {
+			continue
+		}
+
+		if s.ResourceId != "" && s.ResourceId != finding.ResourceId {
+			continue
+		}
+
+		if s.resourceName != nil && !s.resourceName.MatchString(finding.ResourceName) {
+			continue
+		}
+
+		s.matches++
+		matched = true
+	}
+
+	return matched
+}
+
+// DescribeSuppression returns a short description of the check and resource a suppression applies to.
+func DescribeSuppression(s config.Suppression) string {
+	resources := []string{}
+
+	if s.SpaceId != "" {
+		resources = append(resources, "space "+s.SpaceId)
+	}
+
+	if s.ResourceId != "" {
+		resources = append(resources, "ID "+s.ResourceId)
+	}
+
+	if s.ResourceName != "" {
+		resources = append(resources, "name "+s.ResourceName)
+	}
+
+	return s.CheckId + " (" + strings.Join(resources, ", ") + ")"
+}
@@ -0,0 +1,142 @@
+package suppressions
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/organization"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"strings"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func unusedVariablesResult() checks.OctopusCheckResult {
+	return checks.NewOctopusCheckResultImpl(
+		"The following variables may be unused:\nApp: Variable1\nApp: Variable2",
+		organization.OctoLintUnusedVariables,
+		"",
+		checks.Warning,
+		checks.Organization,
+		checks.OctopusCheckFinding{ResourceType: checks.VariableResource, ResourceId: "Variables-1", ResourceName: "Variable1", ProjectName: "App"},
+		checks.OctopusCheckFinding{ResourceType: checks.VariableResource, ResourceId: "Variables-2", ResourceName: "Variable2", ProjectName: "App"})
+}
+
+func TestSuppressSomeFindings(t *testing.T) {
+	filter, err := NewOctopusSuppressionFilter([]config.Suppression{{
+		CheckId:       organization.OctoLintUnusedVariables,
+		ResourceId:    "Variables-1",
+		Justification: "Used by an external script",
+	}}, now)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	results, report := filter.Apply([]checks.OctopusCheckResult{unusedVariablesResult()})
+
+	if report.Suppressed != 1 || len(report.Unmatched) != 0 || len(report.Expired) != 0 {
+		t.Fatalf("Unexpected report: %+v", report)
+	}
+
+	if results[0].Severity() != checks.Warning || len(results[0].Findings()) != 1 {
+		t.Fatal("Should have kept the finding that was not suppressed")
+	}
+
+	if strings.Contains(results[0].Description(), "Variable1") || !strings.Contains(results[0].Description(), "Variable2") {
+		t.Fatal("Should have removed the suppressed finding from the description")
+	}
+}
+
+func TestSuppressFindingsInOneSpace(t *testing.T) {
+	filter, err := NewOctopusSuppressionFilter([]config.Suppression{{
+		CheckId:       organization.OctoLintUnusedVariables,
+		SpaceId:       "Spaces-2",
+		ResourceId:    "Variables-1",
+		Justification: "Used by an external script",
+	}}, now)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	_, report := filter.Apply([]checks.OctopusCheckResult{unusedVariablesResult()})
+
+	if report.Suppressed != 0 || len(report.Unmatched) != 1 {
+		t.Fatal("Should not have suppressed the finding in another space")
+	}
+}
+
+func TestSuppressAllFindingsByName(t *testing.T) {
+	filter, err := NewOctopusSuppressionFilter([]config.Suppression{{
+		CheckId:       organization.OctoLintUnusedVariables,
+		ResourceName:  "Variable\\d",
+		Justification: "Used by an external script",
+		Expires:       "2024-06-01",
+	}}, now)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	results, report := filter.Apply([]checks.OctopusCheckResult{unusedVariablesResult()})
+
+	if report.Suppressed != 2 {
+		t.Fatal("Should have suppressed both findings")
+	}
+
+	if results[0].Severity() != checks.Ok || len(results[0].Findings()) != 0 {
+		t.Fatal("Should have reported the check as passing")
+	}
+}
+
+func TestExpiredAndUnmatchedSuppressions(t *testing.T) {
+	filter, err := NewOctopusSuppressionFilter([]config.Suppression{
+		{
+			CheckId:       organization.OctoLintUnusedVariables,
+			ResourceId:    "Variables-1",
+			Justification: "Used by an external script",
+			Expires:       "2024-05-31",
+		},
+		{
+			CheckId:       organization.OctoLintUnusedVariables,
+			ResourceName:  "Variable",
+			Justification: "The name must match exactly",
+		},
+	}, now)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	results, report := filter.Apply([]checks.OctopusCheckResult{unusedVariablesResult()})
+
+	if report.Suppressed != 0 || len(results[0].Findings()) != 2 {
+		t.Fatal("Should not have suppressed any findings")
+	}
+
+	if len(report.Expired) != 1 || report.Expired[0].ResourceId != "Variables-1" {
+		t.Fatal("Should have reported the expired suppression")
+	}
+
+	if len(report.Unmatched) != 1 || report.Unmatched[0].ResourceName != "Variable" {
+		t.Fatal("Should have reported the unmatched suppression")
+	}
+}
+
+func TestInvalidSuppressions(t *testing.T) {
+	invalidSuppressions := []config.Suppression{
+		{ResourceId: "Variables-1", Justification: "No check"},
+		{CheckId: "OctoLintDoesNotExist", ResourceId: "Variables-1", Justification: "Unknown check"},
+		{CheckId: organization.OctoLintUnusedVariables, Justification: "No resource"},
+		{CheckId: organization.OctoLintUnusedVariables, ResourceId: "Variables-1"},
+		{CheckId: organization.OctoLintUnusedVariables, ResourceName: "(", Justification: "Invalid regex"},
+		{CheckId: organization.OctoLintUnusedVariables, ResourceId: "Variables-1", Justification: "Invalid date", Expires: "01/06/2024"},
+	}
+
+	for _, s := range invalidSuppressions {
+		if _, err := NewOctopusSuppressionFilter([]config.Suppression{s}, now); err == nil {
+			t.Fatalf("Should have returned an error for %+v", s)
+		}
+	}
+}